@@ -0,0 +1,64 @@
+package api
+
+import (
+	"sync"
+	"time"
+)
+
+// dateAttempts serializes log attempts per day and remembers when each day
+// was last attempted. The zero value is ready to use.
+type dateAttempts struct {
+	mu      sync.Mutex
+	locks   map[string]*sync.Mutex
+	started map[string]time.Time
+}
+
+// lock blocks until no other attempt for date is running, records the
+// attempt, and returns the function that releases it
+func (da *dateAttempts) lock(date string) func() {
+	da.mu.Lock()
+	if da.locks == nil {
+		da.locks = make(map[string]*sync.Mutex)
+		da.started = make(map[string]time.Time)
+	}
+	dateLock, ok := da.locks[date]
+	if !ok {
+		dateLock = &sync.Mutex{}
+		da.locks[date] = dateLock
+	}
+	da.mu.Unlock()
+
+	dateLock.Lock()
+
+	da.mu.Lock()
+	da.started[date] = time.Now()
+	da.mu.Unlock()
+
+	return dateLock.Unlock
+}
+
+// pause lets go of date's lock for d, so other attempts for the day aren't
+// held up, then blocks until it has the lock again. The caller must hold it.
+func (da *dateAttempts) pause(date string, d time.Duration) {
+	da.mu.Lock()
+	dateLock := da.locks[date]
+	da.mu.Unlock()
+
+	dateLock.Unlock()
+	time.Sleep(d)
+	dateLock.Lock()
+}
+
+// attemptedWithin returns true if an attempt for date started less than
+// interval ago
+func (da *dateAttempts) attemptedWithin(date string, interval time.Duration) bool {
+	if interval <= 0 {
+		return false
+	}
+
+	da.mu.Lock()
+	defer da.mu.Unlock()
+
+	started, ok := da.started[date]
+	return ok && time.Since(started) < interval
+}
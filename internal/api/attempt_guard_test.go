@@ -0,0 +1,86 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateAttemptsLockSerializesADay(t *testing.T) {
+	var attempts dateAttempts
+	unlock := attempts.lock("2024-05-06")
+
+	// Another day isn't held up
+	done := make(chan struct{})
+	go func() {
+		attempts.lock("2024-05-07")()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("lock for another day blocked")
+	}
+
+	// The same day waits for the first attempt
+	locked := make(chan struct{})
+	go func() {
+		attempts.lock("2024-05-06")()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+		t.Fatal("second attempt for the day got the lock while the first held it")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlock()
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("second attempt still blocked after unlock")
+	}
+}
+
+func TestDateAttemptsPauseReleasesTheDay(t *testing.T) {
+	var attempts dateAttempts
+	unlock := attempts.lock("2024-05-06")
+
+	locked := make(chan struct{})
+	go func() {
+		// Give the pause below time to start
+		time.Sleep(10 * time.Millisecond)
+		attempts.lock("2024-05-06")()
+		close(locked)
+	}()
+
+	attempts.pause("2024-05-06", 100*time.Millisecond)
+	select {
+	case <-locked:
+	default:
+		t.Error("other attempt for the day didn't get the lock during the pause")
+	}
+	unlock()
+}
+
+func TestDateAttemptsAttemptedWithin(t *testing.T) {
+	var attempts dateAttempts
+	if attempts.attemptedWithin("2024-05-06", time.Minute) {
+		t.Error("attemptedWithin = true before any attempt")
+	}
+
+	attempts.lock("2024-05-06")()
+	if !attempts.attemptedWithin("2024-05-06", time.Minute) {
+		t.Error("attemptedWithin = false right after an attempt")
+	}
+	if attempts.attemptedWithin("2024-05-06", 0) {
+		t.Error("attemptedWithin = true with no interval")
+	}
+	if attempts.attemptedWithin("2024-05-07", time.Minute) {
+		t.Error("attemptedWithin = true for a day never attempted")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if attempts.attemptedWithin("2024-05-06", 10*time.Millisecond) {
+		t.Error("attemptedWithin = true after the interval passed")
+	}
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"timeclip/internal/database"
+	"timeclip/internal/models"
+	"timeclip/internal/notify"
+)
+
+// hasGivenUp returns true if automatic logging for the entry's day has failed
+// api.max_daily_attempts times. Force-logging ignores this.
+func hasGivenUp(config *models.Config, entry *models.DailyTimeEntry) bool {
+	maxAttempts := config.API.MaxDailyAttempts
+	return maxAttempts > 0 && entry.AutoLogFailures >= maxAttempts
+}
+
+// isLoggableDay returns true if the entry's day may be auto-logged. PTO days
+// are never logged, and days outside general.track_days are only logged when
+// api.log_non_track_days is set. Force-logging ignores this.
+func isLoggableDay(config *models.Config, entry *models.DailyTimeEntry) bool {
+	if entry.IsPTO {
+		return false
+	}
+	if config.API.LogNonTrackDays {
+		return true
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", entry.Date, time.Local)
+	if err != nil {
+		return false
+	}
+	return config.General.IsTrackDay(date)
+}
+
+// isWithinBacklog returns false for days older than api.max_backlog_days and
+// days already abandoned. Force-logging ignores this.
+func isWithinBacklog(config *models.Config, entry *models.DailyTimeEntry) bool {
+	if entry.Abandoned {
+		return false
+	}
+	cutoff := config.API.BacklogCutoff(time.Now())
+	return cutoff == "" || entry.Date >= cutoff
+}
+
+// abandonIfOld marks a day past api.max_backlog_days as abandoned when
+// api.abandon_old_days is set. It returns true if the day is too old to log.
+func abandonIfOld(db *database.DB, config *models.Config, entry *models.DailyTimeEntry) bool {
+	if isWithinBacklog(config, entry) {
+		return false
+	}
+	if config.API.AbandonOldDays && !entry.Abandoned {
+		log.Printf("🗄️  Abandoning %s: older than api.max_backlog_days (%d days)", entry.Date, config.API.MaxBacklogDays)
+		if err := db.AbandonEntry(entry.Date); err != nil {
+			log.Printf("Error abandoning %s: %v", entry.Date, err)
+		}
+	}
+	return true
+}
+
+// recordLogFailure counts a failed logging attempt for the entry's day and
+// records a give_up event once the limit is reached
+func recordLogFailure(db *database.DB, config *models.Config, entry *models.DailyTimeEntry) {
+	failures, err := db.RecordAutoLogFailure(entry.Date)
+	if err != nil {
+		log.Printf("Error recording auto-log failure: %v", err)
+		return
+	}
+	entry.AutoLogFailures = failures
+
+	maxAttempts := config.API.MaxDailyAttempts
+	if maxAttempts > 0 && failures == maxAttempts {
+		log.Printf("⚠️  Giving up auto-logging %s after %d failed attempts", entry.Date, failures)
+		if err := db.LogSystemEvent("give_up", fmt.Sprintf("Date: %s", entry.Date)); err != nil {
+			log.Printf("Error logging system event: %v", err)
+		}
+	}
+}
+
+// handleLogErrors decides what a failed logging attempt means. Rate limiting is
+// transient, so if every provider was rate limited the attempt is not counted
+// and the next check simply retries. Rejected credentials will not fix
+// themselves, so the first failure of the day raises a notification. Either
+// way the submission goes into the offline queue.
+func handleLogErrors(db *database.DB, config *models.Config, entry *models.DailyTimeEntry, errs []error) {
+	defer queueFailedLog(db, config, entry, errs)
+
+	rateLimited := len(errs) > 0
+	for _, err := range errs {
+		apiErr, ok := AsAPIError(err)
+		if !ok || !apiErr.IsRateLimitError() {
+			rateLimited = false
+		}
+	}
+	if rateLimited {
+		log.Printf("⏳ Rate limited while logging %s; will retry on the next check", entry.Date)
+		return
+	}
+
+	recordLogFailure(db, config, entry)
+
+	for _, err := range errs {
+		apiErr, ok := AsAPIError(err)
+		if !ok || !apiErr.IsAuthenticationError() {
+			continue
+		}
+		log.Printf("🔑 %s rejected the API key (status %d); check api.%s.api_key", apiErr.Service, apiErr.StatusCode, strings.ToLower(apiErr.Service))
+		if entry.AutoLogFailures == 1 {
+			message := fmt.Sprintf("%s rejected the API key, so %s was not logged. Check your config.", apiErr.Service, entry.Date)
+			if err := notify.Send("Timeclip", message); err != nil {
+				log.Printf("Error sending notification: %v", err)
+			}
+		}
+	}
+}
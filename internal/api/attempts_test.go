@@ -0,0 +1,83 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"timeclip/internal/models"
+)
+
+func TestHasGivenUp(t *testing.T) {
+	tests := []struct {
+		max, failures int
+		want          bool
+	}{
+		{0, 10, false},
+		{3, 2, false},
+		{3, 3, true},
+		{3, 4, true},
+	}
+
+	for _, tc := range tests {
+		config := testConfig("", "")
+		config.API.MaxDailyAttempts = tc.max
+		entry := &models.DailyTimeEntry{AutoLogFailures: tc.failures}
+		if got := hasGivenUp(config, entry); got != tc.want {
+			t.Errorf("max %d, %d failures: hasGivenUp = %v, want %v", tc.max, tc.failures, got, tc.want)
+		}
+	}
+}
+
+func TestRecordLogFailureGivesUp(t *testing.T) {
+	config := testConfig("", "")
+	config.API.MaxDailyAttempts = 2
+
+	db := newTestDB(t)
+	entry := addDay(t, db, &models.DailyTimeEntry{Date: "2024-05-06", ActiveMinutes: 480, GoalMinutes: 480})
+	sal := NewSimpleAutoLogger(db, config)
+
+	for i := 1; i <= 3; i++ {
+		recordLogFailure(db, config, entry)
+		if entry.AutoLogFailures != i {
+			t.Fatalf("failures = %d, want %d", entry.AutoLogFailures, i)
+		}
+	}
+	if sal.ShouldAutoLog(entry) {
+		t.Error("ShouldAutoLog = true after max_daily_attempts failures")
+	}
+
+	today := time.Now().Format("2006-01-02")
+	events, err := db.GetSystemEventsForDateRange(today, today)
+	if err != nil {
+		t.Fatalf("GetSystemEventsForDateRange: %v", err)
+	}
+	gaveUp := 0
+	for _, event := range events {
+		if event.EventType == "give_up" {
+			gaveUp++
+		}
+	}
+	if gaveUp != 1 {
+		t.Errorf("recorded %d give_up events, want one when the limit is reached", gaveUp)
+	}
+}
+
+func TestQueueFailedLogDropsGivenUpDay(t *testing.T) {
+	config := testConfig("", "")
+	config.API.MaxDailyAttempts = 1
+	config.API.QueueRetryMinutes = 5
+
+	db := newTestDB(t)
+	entry := addDay(t, db, &models.DailyTimeEntry{Date: "2024-05-06", ActiveMinutes: 480, GoalMinutes: 480})
+
+	queueFailedLog(db, config, entry, nil)
+	if pending, _ := db.GetPendingLog(entry.Date); pending == nil {
+		t.Fatal("failed day not queued")
+	}
+
+	recordLogFailure(db, config, entry)
+	queueFailedLog(db, config, entry, nil)
+	if pending, _ := db.GetPendingLog(entry.Date); pending != nil {
+		t.Errorf("given up day still queued: %+v", pending)
+	}
+}
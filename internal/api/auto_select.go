@@ -0,0 +1,92 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"timeclip/internal/models"
+)
+
+// autoSelected remembers the last workspace or project picked by
+// api.auto_select_single per provider, so the choice is only logged when it
+// changes
+var autoSelected sync.Map
+
+// resolveWorkspace returns the workspace to log a provider's entries to. The
+// configured workspace_id always wins. Without one, and with
+// api.auto_select_single on, the account's only workspace is used; an
+// account with several workspaces needs workspace_id set. With the option
+// off "" is returned and the client falls back to its own default (the
+// active workspace for Clockify).
+func resolveWorkspace(config *models.Config, provider string, client TimeTrackingAPI) (string, error) {
+	var configured string
+	switch provider {
+	case "magnetic":
+		configured = config.API.Magnetic.WorkspaceID
+	case "clockify":
+		configured = config.API.Clockify.WorkspaceID
+	}
+	if configured != "" || !config.API.AutoSelectSingle {
+		return configured, nil
+	}
+
+	cache, err := NewProjectCache(config)
+	if err != nil {
+		return "", err
+	}
+	workspaces, err := cache.Workspaces(client)
+	if err != nil {
+		return "", fmt.Errorf("failed to list %s workspaces: %w", provider, err)
+	}
+
+	switch len(workspaces) {
+	case 0:
+		return "", fmt.Errorf("no %s workspaces found", provider)
+	case 1:
+		noteAutoSelected(provider, "workspace", workspaces[0].ID, workspaces[0].Name)
+		return workspaces[0].ID, nil
+	default:
+		return "", fmt.Errorf("%s account has %d workspaces; set api.%s.workspace_id to pick one",
+			provider, len(workspaces), provider)
+	}
+}
+
+// singleProject returns the workspace's only project when
+// api.auto_select_single is on. With several projects, or when they can't be
+// listed, it returns "" and the entry is logged without a project as before.
+func (sal *SimpleAutoLogger) singleProject(config *models.Config, provider, workspaceID string) string {
+	if !config.API.AutoSelectSingle {
+		return ""
+	}
+
+	projects, err := listProjects(config, provider, workspaceID)
+	if err != nil {
+		log.Printf("⚠️  Couldn't list %s projects to auto-select one: %v", provider, err)
+		return ""
+	}
+	if len(projects) != 1 {
+		if len(projects) > 1 {
+			noteAutoSelected(provider, "project", "", "")
+		}
+		return ""
+	}
+
+	noteAutoSelected(provider, "project", projects[0].ID, projects[0].Name)
+	return projects[0].ID
+}
+
+// noteAutoSelected logs an automatic choice the first time it is made or
+// when it changes. An empty id records that the choice was ambiguous.
+func noteAutoSelected(provider, kind, id, name string) {
+	previous, loaded := autoSelected.Swap(provider+"/"+kind, id)
+	if loaded && previous.(string) == id {
+		return
+	}
+
+	if id == "" {
+		log.Printf("⚠️  Several %s %ss found; set api.%s.%s_id to pick one", provider, kind, provider, kind)
+		return
+	}
+	log.Printf("Using %s %s %s (%s), the only one available", provider, kind, name, id)
+}
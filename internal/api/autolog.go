@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"sync"
+	"time"
 
 	"timeclip/internal/database"
 	"timeclip/internal/models"
@@ -81,12 +82,14 @@ func (al *AutoLogger) Stop() {
 
 // CheckAndLog checks if an entry should be auto-logged and logs it
 func (al *AutoLogger) CheckAndLog(entry *models.DailyTimeEntry) {
-	if !al.ShouldAutoLog(entry) {
+	if al.db.IsTrackingDisabled() || !al.ShouldAutoLog(entry) {
 		return
 	}
 
-	description := fmt.Sprintf("Timeclip auto-log for %s", entry.Date)
-	
+	al.mu.RLock()
+	description := describeEntry(al.config, entry)
+	al.mu.RUnlock()
+
 	select {
 	case al.logChan <- &LogRequest{
 		Entry:       entry,
@@ -128,9 +131,23 @@ func (al *AutoLogger) ShouldAutoLog(entry *models.DailyTimeEntry) bool {
 		return false
 	}
 
-	// Check threshold
-	actualHours := float64(entry.ActiveMinutes) / 60.0
-	return actualHours >= al.thresholdHours
+	// Too many failed attempts for this day
+	if hasGivenUp(al.config, entry) {
+		return false
+	}
+
+	// PTO, or not a track day and api.log_non_track_days is off
+	if !isLoggableDay(al.config, entry) {
+		return false
+	}
+
+	// Older than api.max_backlog_days
+	if abandonIfOld(al.db, al.config, entry) {
+		return false
+	}
+
+	// Check threshold (absolute, or relative to the day's goal)
+	return entry.ActiveMinutes >= al.config.AutoLogThresholdMinutes(entry.GoalMinutes)
 }
 
 // GetEnabledAPIs returns the currently enabled API clients
@@ -159,6 +176,7 @@ func (al *AutoLogger) UpdateConfig(newConfig *models.Config) error {
 
 	al.config = newConfig
 	al.thresholdHours = newConfig.General.AutoLogThresholdHours
+	invalidateProjectCache(newConfig)
 
 	// Reinitialize APIs with new config
 	return al.initializeAPIs()
@@ -224,7 +242,9 @@ func (al *AutoLogger) processLogRequest(request *LogRequest) {
 	log.Printf("Processing auto-log for %s (%.1f hours)", entry.Date, float64(entry.ActiveMinutes)/60.0)
 
 	// Create time entry
-	timeEntry := NewTimeEntry(entry, request.Description)
+	timeEntry := NewTimeEntry(entry, request.Description).WithMinutes(loggedMinutes(al.db, al.config, entry))
+
+	var errs []error
 
 	// Try to log to preferred API first
 	preferredAPI := al.config.API.PreferredProvider
@@ -239,6 +259,7 @@ func (al *AutoLogger) processLogRequest(request *LogRequest) {
 			return
 		} else {
 			log.Printf("Failed to log to preferred API (%s): %v", preferredAPI, err)
+			errs = append(errs, err)
 		}
 	}
 
@@ -258,11 +279,13 @@ func (al *AutoLogger) processLogRequest(request *LogRequest) {
 			return
 		} else {
 			log.Printf("Failed to log to %s: %v", name, err)
+			errs = append(errs, err)
 		}
 	}
 
 	// All APIs failed
 	log.Printf("Error: Failed to log %s to any API", entry.Date)
+	handleLogErrors(al.db, al.config, entry, errs)
 }
 
 // logToAPI attempts to log a time entry to a specific API
@@ -332,7 +355,7 @@ func (al *AutoLogger) getAPINames() []string {
 func (al *AutoLogger) GetStats() (*AutoLogStats, error) {
 	// Get entries that need auto-logging
 	thresholdMinutes := int(al.thresholdHours * 60)
-	needingLog, err := al.db.GetEntriesNeedingAutoLog(thresholdMinutes)
+	needingLog, err := al.db.GetEntriesNeedingAutoLog(thresholdMinutes, al.config.API.BacklogCutoff(time.Now()))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get entries needing auto-log: %w", err)
 	}
@@ -0,0 +1,188 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"timeclip/internal/api/magnetic"
+	"timeclip/internal/database"
+	"timeclip/internal/models"
+)
+
+// BulkExportOptions selects the days written by ExportClockifyCSV and
+// ExportMagneticJSON
+type BulkExportOptions struct {
+	Start         string // First date, YYYY-MM-DD
+	End           string // Last date, YYYY-MM-DD
+	IncludeLogged bool   // Also export days already logged
+	Email         string // Clockify user the entries belong to; looked up when empty
+}
+
+// clockifyImportHeader is the column layout of Clockify's CSV time entry import
+var clockifyImportHeader = []string{
+	"Project", "Client", "Description", "Task", "Email", "Tags", "Billable",
+	"Start Date", "Start Time", "End Date", "End Time", "Duration (h)",
+}
+
+// ExportClockifyCSV writes the days in a range in the layout of Clockify's
+// CSV import, one row per entry the live path would create: the same
+// rounding, overtime and lunch splits, descriptions and start times.
+// Clockify matches projects by name, so api.clockify.project_id is looked up
+// in the project list; an unknown project is written as its ID. The
+// workspace is whichever one the file is imported into. It returns how many
+// days were written.
+func ExportClockifyCSV(db *database.DB, config *models.Config, opts BulkExportOptions, w io.Writer) (int, error) {
+	entries, err := bulkExportEntries(db, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	email := opts.Email
+	var names map[string]string
+	if len(entries) > 0 {
+		if email == "" {
+			if email, err = clockifyEmail(config); err != nil {
+				return 0, fmt.Errorf("failed to look up the Clockify user, pass an email instead: %w", err)
+			}
+		}
+		names = bulkProjectNames(config, "clockify", config.API.Clockify.WorkspaceID)
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Write(clockifyImportHeader)
+	for _, entry := range entries {
+		parts := bulkExportParts(db, config, entry)
+
+		start := entryStartTime(db, config, entry.Date)
+		if start.IsZero() {
+			start, _ = time.ParseInLocation("2006-01-02", entry.Date, time.Local)
+		}
+		for i, part := range parts {
+			projectID := bulkProjectID(config, config.API.Clockify.ProjectID, part)
+			project := names[projectID]
+			if project == "" {
+				project = projectID
+			}
+
+			from := partStart(start, parts, i)
+			to := from.Add(time.Duration(part.minutes) * time.Minute)
+			writer.Write([]string{
+				project, "", part.description, "", email, "", "",
+				from.Format("2006-01-02"), from.Format("15:04:05"),
+				to.Format("2006-01-02"), to.Format("15:04:05"),
+				fmt.Sprintf("%02d:%02d:00", part.minutes/60, part.minutes%60),
+			})
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return 0, fmt.Errorf("failed to write Clockify export: %w", err)
+	}
+	return len(entries), nil
+}
+
+// ExportMagneticJSON writes the days in a range as a JSON array of the time
+// entries the live path would post to Magnetic, with the configured
+// workspace, project and activity. It returns how many days were written.
+func ExportMagneticJSON(db *database.DB, config *models.Config, opts BulkExportOptions, w io.Writer) (int, error) {
+	entries, err := bulkExportEntries(db, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	records := make([]*magnetic.MagneticTimeEntry, 0, len(entries))
+	for _, entry := range entries {
+		for _, part := range bulkExportParts(db, config, entry) {
+			records = append(records, &magnetic.MagneticTimeEntry{
+				Date:        entry.Date,
+				Hours:       float64(part.minutes) / 60.0,
+				Description: part.description,
+				ProjectID:   bulkProjectID(config, config.API.Magnetic.ProjectID, part),
+				ActivityID:  config.API.Magnetic.ActivityID,
+				WorkspaceID: config.API.Magnetic.WorkspaceID,
+			})
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(records); err != nil {
+		return 0, fmt.Errorf("failed to write Magnetic export: %w", err)
+	}
+	return len(entries), nil
+}
+
+// bulkExportEntries returns the days in the range that would be logged:
+// not PTO, with active time, and not yet logged unless asked for
+func bulkExportEntries(db *database.DB, opts BulkExportOptions) ([]*models.DailyTimeEntry, error) {
+	entries, err := db.GetEntriesForDateRange(opts.Start, opts.End)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []*models.DailyTimeEntry
+	for _, entry := range entries {
+		if entry.IsPTO || entry.ActiveMinutes <= 0 || (entry.AutoLogged && !opts.IncludeLogged) {
+			continue
+		}
+		selected = append(selected, entry)
+	}
+	return selected, nil
+}
+
+// bulkExportParts splits a day like prepareParts, without recording the
+// rounding since nothing is submitted
+func bulkExportParts(db *database.DB, config *models.Config, entry *models.DailyTimeEntry) []logPart {
+	description := describeEntry(config, entry)
+	minutes := roundMinutes(entry.ActiveMinutes, config.API.RoundLoggedMinutes)
+	return splitAtLunch(db, config, entry, splitOvertime(config, entry, minutes, description))
+}
+
+// bulkProjectID returns the project for an exported part: the part's own
+// project, the provider's project_id, or api.fallback_project_id
+func bulkProjectID(config *models.Config, configured string, part logPart) string {
+	switch {
+	case part.projectID != "":
+		return part.projectID
+	case configured != "":
+		return configured
+	}
+	return config.API.FallbackProjectID
+}
+
+// bulkProjectNames maps project IDs to names from the project cache. Without
+// a listing the map is empty and IDs are written instead.
+func bulkProjectNames(config *models.Config, provider, workspaceID string) map[string]string {
+	names := make(map[string]string)
+
+	projects, err := listProjects(config, provider, workspaceID)
+	if err != nil {
+		log.Printf("⚠️  Couldn't list %s projects, exporting project IDs instead of names: %v", provider, err)
+		return names
+	}
+	for _, project := range projects {
+		names[project.ID] = project.Name
+	}
+	return names
+}
+
+// clockifyEmail returns the email of the Clockify user the API key belongs to
+func clockifyEmail(config *models.Config) (string, error) {
+	client, err := newClockifyClient(config)
+	if err != nil {
+		return "", err
+	}
+	user, err := client.GetCurrentUser()
+	if err != nil {
+		return "", err
+	}
+	if user.Email == "" {
+		return "", fmt.Errorf("Clockify returned no email for the current user")
+	}
+	return user.Email, nil
+}
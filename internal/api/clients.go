@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"timeclip/internal/api/clockify"
+	"timeclip/internal/api/magnetic"
+	"timeclip/internal/models"
+)
+
+// requests is the context of every provider request. LogOnQuit cancels it
+// when it stops waiting, so no submission completes after it was queued.
+var requests, cancelRequests = context.WithCancel(context.Background())
+
+// newMagneticClient creates a Magnetic client from the application configuration
+func newMagneticClient(config *models.Config) (*magnetic.Client, error) {
+	return magnetic.NewClient(&magnetic.Config{
+		BaseURL:     config.API.Magnetic.BaseURL,
+		APIKey:      config.API.Magnetic.APIKey,
+		WorkspaceID: config.API.Magnetic.WorkspaceID,
+		ProjectID:   config.API.Magnetic.ProjectID,
+		ActivityID:  config.API.Magnetic.ActivityID,
+		Timeout:     config.API.ProviderTimeoutSeconds("magnetic"),
+		Retries:     config.API.ProviderRetryAttempts("magnetic"),
+		ErrorFields: config.API.Magnetic.ErrorFields,
+		Context:     requests,
+	})
+}
+
+// newClockifyClient creates a Clockify client from the application configuration
+func newClockifyClient(config *models.Config) (*clockify.Client, error) {
+	return clockify.NewClient(&clockify.Config{
+		BaseURL:      config.API.Clockify.BaseURL,
+		APIKey:       config.API.Clockify.APIKey,
+		WorkspaceID:  config.API.Clockify.WorkspaceID,
+		ProjectID:    config.API.Clockify.ProjectID,
+		Timeout:      config.API.ProviderTimeoutSeconds("clockify"),
+		Retries:      config.API.ProviderRetryAttempts("clockify"),
+		CustomFields: config.API.Clockify.CustomFields,
+		ErrorFields:  config.API.Clockify.ErrorFields,
+		Context:      requests,
+	})
+}
+
+// MagneticActivities lists the activities of a Magnetic project
+func MagneticActivities(config *models.Config, projectID string) ([]*models.Activity, error) {
+	if config.API.Magnetic.APIKey == "" {
+		return nil, fmt.Errorf("Magnetic is not configured")
+	}
+
+	client, err := newMagneticClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Magnetic client: %w", err)
+	}
+
+	activities, err := client.GetActivities(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Magnetic activities: %w", err)
+	}
+	return activities, nil
+}
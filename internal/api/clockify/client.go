@@ -2,15 +2,24 @@ package clockify
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"timeclip/internal/models"
 )
 
+// currentUsers caches the user behind each API key for the life of the
+// process, since clients are created per request
+var currentUsers sync.Map // base URL + API key -> *ClockifyUser
+
 // Client represents a Clockify time tracking API client
 type Client struct {
 	config     *Config
@@ -25,6 +34,12 @@ type Config struct {
 	ProjectID   string
 	Timeout     int
 	Retries     int
+	// CustomFields maps Clockify custom field IDs to the value set on every entry
+	CustomFields map[string]string
+	// ErrorFields are response fields that mark a 2xx response as failed when set
+	ErrorFields []string
+	// Context cancels requests in flight when it is done; nil never does
+	Context context.Context
 }
 
 // ClockifyTimeEntry represents a time entry in Clockify's format
@@ -35,6 +50,13 @@ type ClockifyTimeEntry struct {
 	ProjectID   string `json:"projectId,omitempty"`
 	TaskID      string `json:"taskId,omitempty"`
 	TagIDs      []string `json:"tagIds,omitempty"`
+	CustomFields []ClockifyCustomField `json:"customFields,omitempty"`
+}
+
+// ClockifyCustomField represents a custom field value on a Clockify time entry
+type ClockifyCustomField struct {
+	CustomFieldID string `json:"customFieldId"`
+	Value         string `json:"value"`
 }
 
 // ClockifyProject represents a project in Clockify
@@ -60,6 +82,42 @@ type ClockifyUser struct {
 	ActiveWorkspace string `json:"activeWorkspace"`
 }
 
+// ClockifyTimeInterval represents the start/end of an existing Clockify time entry
+type ClockifyTimeInterval struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Duration string `json:"duration"`
+}
+
+// ClockifyTimeEntryRecord represents a time entry returned by Clockify
+type ClockifyTimeEntryRecord struct {
+	ID           string               `json:"id"`
+	Description  string               `json:"description"`
+	ProjectID    string               `json:"projectId"`
+	TimeInterval ClockifyTimeInterval `json:"timeInterval"`
+}
+
+// Date returns the YYYY-MM-DD date the entry starts on
+func (r *ClockifyTimeEntryRecord) Date() string {
+	if len(r.TimeInterval.Start) < 10 {
+		return ""
+	}
+	return r.TimeInterval.Start[:10]
+}
+
+// Minutes returns the entry length in minutes, or 0 if it is still running
+func (r *ClockifyTimeEntryRecord) Minutes() int {
+	start, err := time.Parse(time.RFC3339, r.TimeInterval.Start)
+	if err != nil {
+		return 0
+	}
+	end, err := time.Parse(time.RFC3339, r.TimeInterval.End)
+	if err != nil {
+		return 0
+	}
+	return int(end.Sub(start).Minutes())
+}
+
 // NewClient creates a new Clockify API client
 func NewClient(config *Config) (*Client, error) {
 	if config == nil {
@@ -118,6 +176,12 @@ func (c *Client) ValidateConfig() error {
 		return fmt.Errorf("API key is required")
 	}
 
+	for fieldID := range c.config.CustomFields {
+		if strings.TrimSpace(fieldID) == "" {
+			return fmt.Errorf("custom field IDs cannot be empty")
+		}
+	}
+
 	return nil
 }
 
@@ -135,12 +199,29 @@ func (c *Client) Authenticate() error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode == 401 || resp.StatusCode == 403 {
-		return fmt.Errorf("authentication failed: invalid API credentials (status %d)", resp.StatusCode)
+		return &models.APIError{Service: c.Name(), StatusCode: resp.StatusCode, Message: "authentication failed: invalid API credentials"}
 	}
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("authentication failed (status %d): %s", resp.StatusCode, string(body))
+		return &models.APIError{Service: c.Name(), StatusCode: resp.StatusCode, Message: "authentication failed", Details: string(body)}
+	}
+
+	// A base URL pointing at some other server can answer 200 too, so the
+	// response has to be an actual user
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read auth response: %w", err)
+	}
+	var user ClockifyUser
+	if err := json.Unmarshal(body, &user); err != nil || (user.ID == "" && user.Email == "") {
+		return &models.APIError{Service: c.Name(), StatusCode: resp.StatusCode,
+			Message: "authentication failed: /user did not return a Clockify user; check api.clockify.base_url", Details: string(body)}
+	}
+
+	// Remember the user so the workspace can be defaulted without another request
+	if user.ID != "" {
+		currentUsers.Store(c.userCacheKey(), &user)
 	}
 
 	return nil
@@ -149,6 +230,7 @@ func (c *Client) Authenticate() error {
 // TimeEntry represents a time entry for the Clockify API
 type TimeEntry struct {
 	Date        time.Time `json:"date"`
+	Start       time.Time `json:"start,omitempty"` // Defaults to the start of Date
 	Hours       float64   `json:"hours"`
 	Minutes     int       `json:"minutes"`
 	Description string    `json:"description"`
@@ -165,19 +247,26 @@ func (c *Client) CreateTimeEntry(entry interface{}) (*models.APIResponse, error)
 	}
 	workspaceID := c.getWorkspaceID(timeEntry)
 	if workspaceID == "" {
-		return nil, fmt.Errorf("workspace ID is required")
+		var err error
+		if workspaceID, err = c.DefaultWorkspaceID(); err != nil {
+			return nil, err
+		}
 	}
 
 	// Calculate start and end times
 	// For simplicity, we'll create an entry that spans the entire duration
 	startTime := timeEntry.Date
+	if !timeEntry.Start.IsZero() {
+		startTime = timeEntry.Start
+	}
 	endTime := startTime.Add(time.Duration(timeEntry.Minutes) * time.Minute)
 
 	clockifyEntry := &ClockifyTimeEntry{
-		Start:       startTime.Format("2006-01-02T15:04:05.000Z"),
-		End:         endTime.Format("2006-01-02T15:04:05.000Z"),
+		Start:       startTime.UTC().Format("2006-01-02T15:04:05.000Z"),
+		End:         endTime.UTC().Format("2006-01-02T15:04:05.000Z"),
 		Description: timeEntry.Description,
 		ProjectID:   c.getProjectID(timeEntry),
+		CustomFields: c.customFields(),
 	}
 
 	jsonData, err := json.Marshal(clockifyEntry)
@@ -203,7 +292,23 @@ func (c *Client) CreateTimeEntry(entry interface{}) (*models.APIResponse, error)
 	}
 
 	if resp.StatusCode >= 400 {
-		return models.NewAPIResponse(false, "Failed to create time entry"), fmt.Errorf("API request failed (status %d): %s", resp.StatusCode, string(body))
+		return models.NewAPIResponse(false, "Failed to create time entry"), &models.APIError{
+			Service:    c.Name(),
+			StatusCode: resp.StatusCode,
+			Message:    "failed to create time entry",
+			Details:    string(body),
+		}
+	}
+
+	// A proxy may answer 200 with an error payload, which must not be
+	// taken for a created entry
+	if reported := models.ErrorInBody(body, c.config.ErrorFields); reported != "" {
+		return models.NewAPIResponse(false, "Failed to create time entry"), &models.APIError{
+			Service:    c.Name(),
+			StatusCode: resp.StatusCode,
+			Message:    "response reports an error: " + reported,
+			Details:    string(body),
+		}
 	}
 
 	// Parse response
@@ -229,7 +334,7 @@ func (c *Client) GetWorkspaces() ([]*models.Workspace, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("failed to retrieve workspaces (status %d)", resp.StatusCode)
+		return nil, &models.APIError{Service: c.Name(), StatusCode: resp.StatusCode, Message: "failed to retrieve workspaces"}
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -268,7 +373,7 @@ func (c *Client) GetProjects(workspaceID string) ([]*models.Project, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("failed to retrieve projects (status %d)", resp.StatusCode)
+		return nil, &models.APIError{Service: c.Name(), StatusCode: resp.StatusCode, Message: "failed to retrieve projects"}
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -296,11 +401,120 @@ func (c *Client) GetProjects(workspaceID string) ([]*models.Project, error) {
 	return projects, nil
 }
 
+// GetCurrentUser retrieves the user that owns the API key. The result is
+// cached for the life of the process.
+func (c *Client) GetCurrentUser() (*ClockifyUser, error) {
+	if cached, ok := currentUsers.Load(c.userCacheKey()); ok {
+		return cached.(*ClockifyUser), nil
+	}
+
+	req, err := c.createRequest("GET", "/user", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &models.APIError{Service: c.Name(), StatusCode: resp.StatusCode, Message: "failed to retrieve user", Details: string(body)}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var user ClockifyUser
+	if err := json.Unmarshal(body, &user); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	currentUsers.Store(c.userCacheKey(), &user)
+	return &user, nil
+}
+
+// DefaultWorkspaceID returns the configured workspace, or the user's active
+// workspace when none is configured
+func (c *Client) DefaultWorkspaceID() (string, error) {
+	if c.config.WorkspaceID != "" {
+		return c.config.WorkspaceID, nil
+	}
+
+	user, err := c.GetCurrentUser()
+	if err != nil {
+		return "", fmt.Errorf("failed to get active workspace: %w", err)
+	}
+	if user.ActiveWorkspace == "" {
+		return "", fmt.Errorf("workspace ID is required")
+	}
+	return user.ActiveWorkspace, nil
+}
+
+// userCacheKey identifies the account behind this client in currentUsers
+func (c *Client) userCacheKey() string {
+	return c.config.BaseURL + "|" + c.config.APIKey
+}
+
+// GetTimeEntries retrieves the current user's time entries between start and end
+func (c *Client) GetTimeEntries(start, end time.Time) ([]*ClockifyTimeEntryRecord, error) {
+	workspaceID, err := c.DefaultWorkspaceID()
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := c.GetCurrentUser()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	query := url.Values{}
+	query.Set("start", start.Format("2006-01-02T15:04:05Z"))
+	query.Set("end", end.Format("2006-01-02T15:04:05Z"))
+	query.Set("page-size", "1000")
+
+	endpoint := fmt.Sprintf("/workspaces/%s/user/%s/time-entries?%s", workspaceID, user.ID, query.Encode())
+	req, err := c.createRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, &models.APIError{Service: c.Name(), StatusCode: resp.StatusCode, Message: "failed to retrieve time entries"}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var records []*ClockifyTimeEntryRecord
+	if err := json.Unmarshal(body, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return records, nil
+}
+
 // createRequest creates an HTTP request with proper authentication
 func (c *Client) createRequest(method, endpoint string, body io.Reader) (*http.Request, error) {
 	url := c.config.BaseURL + endpoint
 	
-	req, err := http.NewRequest(method, url, body)
+	ctx := c.config.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, err
 	}
@@ -327,4 +541,26 @@ func (c *Client) getWorkspaceID(entry *TimeEntry) string {
 		return entry.WorkspaceID
 	}
 	return c.config.WorkspaceID
-}
\ No newline at end of file
+}
+
+// customFields returns the configured custom fields in Clockify's payload format
+func (c *Client) customFields() []ClockifyCustomField {
+	if len(c.config.CustomFields) == 0 {
+		return nil
+	}
+
+	fieldIDs := make([]string, 0, len(c.config.CustomFields))
+	for fieldID := range c.config.CustomFields {
+		fieldIDs = append(fieldIDs, fieldID)
+	}
+	sort.Strings(fieldIDs)
+
+	fields := make([]ClockifyCustomField, 0, len(fieldIDs))
+	for _, fieldID := range fieldIDs {
+		fields = append(fields, ClockifyCustomField{
+			CustomFieldID: fieldID,
+			Value:         c.config.CustomFields[fieldID],
+		})
+	}
+	return fields
+}
@@ -0,0 +1,98 @@
+package clockify
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"timeclip/internal/models"
+)
+
+// newTestClient returns a client for a server that answers every request
+// with status and body
+func newTestClient(t *testing.T, status int, body string, errorFields ...string) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(&Config{BaseURL: server.URL, APIKey: "key", WorkspaceID: "ws", ErrorFields: errorFields})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func TestCreateTimeEntryErrorInBody(t *testing.T) {
+	entry := &TimeEntry{Date: time.Date(2024, 5, 6, 9, 0, 0, 0, time.UTC), Minutes: 480, Description: "Work"}
+
+	tests := []struct {
+		name        string
+		body        string
+		errorFields []string
+		wantErr     bool
+	}{
+		{"created", `{"id":"entry-1"}`, []string{"error"}, false},
+		{"error reported", `{"error":"Workspace is locked"}`, []string{"error"}, true},
+		{"error fields not configured", `{"error":"Workspace is locked"}`, nil, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := newTestClient(t, http.StatusOK, tc.body, tc.errorFields...)
+			response, err := client.CreateTimeEntry(entry)
+			if !tc.wantErr {
+				if err != nil || !response.Success {
+					t.Errorf("CreateTimeEntry = %+v, %v; want success", response, err)
+				}
+				return
+			}
+
+			var apiErr *models.APIError
+			if !errors.As(err, &apiErr) || apiErr.Message != "response reports an error: error: Workspace is locked" {
+				t.Errorf("CreateTimeEntry error = %v, want the reported error", err)
+			}
+			if response == nil || response.Success {
+				t.Errorf("CreateTimeEntry response = %+v, want a failure", response)
+			}
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		wantStatus int // 0 for success
+		wantMsg    string
+	}{
+		{"user", http.StatusOK, `{"id":"user-1","email":"me@example.com","activeWorkspace":"ws"}`, 0, ""},
+		{"bad key", http.StatusUnauthorized, `{"message":"invalid"}`, http.StatusUnauthorized, "invalid API credentials"},
+		{"server error", http.StatusBadGateway, "bad gateway", http.StatusBadGateway, "authentication failed"},
+		{"other server's page", http.StatusOK, "<html>Welcome</html>", http.StatusOK, "did not return a"},
+		{"json without a user", http.StatusOK, `{"status":"ok"}`, http.StatusOK, "did not return a"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := newTestClient(t, tc.status, tc.body).Authenticate()
+			if tc.wantStatus == 0 {
+				if err != nil {
+					t.Errorf("Authenticate = %v, want success", err)
+				}
+				return
+			}
+
+			var apiErr *models.APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tc.wantStatus || !strings.Contains(apiErr.Message, tc.wantMsg) {
+				t.Errorf("Authenticate = %v, want a %d error mentioning %q", err, tc.wantStatus, tc.wantMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"timeclip/internal/models"
+)
+
+// DefaultDescriptionTemplate is used when no description template is configured
+const DefaultDescriptionTemplate = "Timeclip auto-log for {date}"
+
+// BuildDescription renders a description template for a daily time entry.
+//
+// Supported placeholders:
+//   - {date}: the entry date (YYYY-MM-DD)
+//   - {hours}: active hours with one decimal (e.g. 7.5)
+//   - {minutes}: active minutes
+//   - {goal_status}: "goal reached", "under goal" or "no goal"
+//
+// {machine} is filled in by describeEntry.
+func BuildDescription(template string, entry *models.DailyTimeEntry) string {
+	if template == "" {
+		template = DefaultDescriptionTemplate
+	}
+
+	goalStatus := "under goal"
+	if !entry.HasGoal() {
+		goalStatus = "no goal"
+	} else if entry.IsGoalReached() {
+		goalStatus = "goal reached"
+	}
+
+	replacer := strings.NewReplacer(
+		"{date}", entry.Date,
+		"{hours}", fmt.Sprintf("%.1f", float64(entry.ActiveMinutes)/60.0),
+		"{minutes}", fmt.Sprintf("%d", entry.ActiveMinutes),
+		"{goal_status}", goalStatus,
+	)
+
+	return replacer.Replace(template)
+}
+
+// describeEntry builds the auto-log description for an entry. The machine
+// label replaces a {machine} placeholder, or is prepended as "[label] " when
+// the template doesn't use one.
+func describeEntry(config *models.Config, entry *models.DailyTimeEntry) string {
+	return describeWith(config, config.API.DescriptionTemplate, entry)
+}
+
+// describeWith renders template for an entry and applies the machine label
+// like describeEntry. The day's notes are appended only with api.include_notes.
+func describeWith(config *models.Config, template string, entry *models.DailyTimeEntry) string {
+	description := BuildDescription(template, entry)
+	if config.API.IncludeNotes && entry.Notes != "" {
+		description = fmt.Sprintf("%s: %s", description, entry.Notes)
+	}
+	label := config.General.Label()
+
+	if strings.Contains(description, "{machine}") {
+		return strings.ReplaceAll(description, "{machine}", label)
+	}
+	if label == "" {
+		return description
+	}
+	return fmt.Sprintf("[%s] %s", label, description)
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"testing"
+
+	"timeclip/internal/models"
+)
+
+func TestBuildDescription(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		entry    models.DailyTimeEntry
+		want     string
+	}{
+		{
+			name:  "default template",
+			entry: models.DailyTimeEntry{Date: "2024-05-06", ActiveMinutes: 450, GoalMinutes: 480},
+			want:  "Timeclip auto-log for 2024-05-06",
+		},
+		{
+			name:     "hours and minutes",
+			template: "{date}: {hours}h ({minutes} min)",
+			entry:    models.DailyTimeEntry{Date: "2024-05-06", ActiveMinutes: 450, GoalMinutes: 480},
+			want:     "2024-05-06: 7.5h (450 min)",
+		},
+		{
+			name:     "under goal",
+			template: "Work ({goal_status})",
+			entry:    models.DailyTimeEntry{Date: "2024-05-06", ActiveMinutes: 450, GoalMinutes: 480},
+			want:     "Work (under goal)",
+		},
+		{
+			name:     "goal reached",
+			template: "Work ({goal_status})",
+			entry:    models.DailyTimeEntry{Date: "2024-05-06", ActiveMinutes: 480, GoalMinutes: 480},
+			want:     "Work (goal reached)",
+		},
+		{
+			name:     "overtime",
+			template: "Work ({goal_status})",
+			entry:    models.DailyTimeEntry{Date: "2024-05-06", ActiveMinutes: 560, GoalMinutes: 480},
+			want:     "Work (goal reached)",
+		},
+		{
+			name:     "no goal",
+			template: "Work ({goal_status})",
+			entry:    models.DailyTimeEntry{Date: "2024-05-11", ActiveMinutes: 90},
+			want:     "Work (no goal)",
+		},
+		{
+			name:     "unknown placeholder kept",
+			template: "{date} {project}",
+			entry:    models.DailyTimeEntry{Date: "2024-05-06", ActiveMinutes: 60, GoalMinutes: 480},
+			want:     "2024-05-06 {project}",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := BuildDescription(tc.template, &tc.entry); got != tc.want {
+				t.Errorf("BuildDescription(%q) = %q, want %q", tc.template, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDescribeEntry(t *testing.T) {
+	entry := &models.DailyTimeEntry{Date: "2024-05-06", ActiveMinutes: 480, GoalMinutes: 480, Notes: "release prep"}
+
+	tests := []struct {
+		name     string
+		template string
+		label    string
+		notes    bool
+		want     string
+	}{
+		{"no label", "Work {date}", "", false, "Work 2024-05-06"},
+		{"label prepended", "Work {date}", "laptop", false, "[laptop] Work 2024-05-06"},
+		{"label placeholder", "Work on {machine}", "laptop", false, "Work on laptop"},
+		{"notes", "Work {date}", "", true, "Work 2024-05-06: release prep"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			config := testConfig("", "")
+			config.API.DescriptionTemplate = tc.template
+			config.General.MachineLabel = tc.label
+			config.API.IncludeNotes = tc.notes
+
+			if got := describeEntry(config, entry); got != tc.want {
+				t.Errorf("describeEntry = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
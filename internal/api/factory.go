@@ -3,8 +3,6 @@ package api
 import (
 	"fmt"
 
-	"timeclip/internal/api/clockify"
-	"timeclip/internal/api/magnetic"
 	"timeclip/internal/models"
 )
 
@@ -23,27 +21,13 @@ func (f *Factory) CreateAPI(provider string, config *models.Config) (TimeTrackin
 		if !config.API.Magnetic.Enabled {
 			return nil, fmt.Errorf("magnetic API is disabled in configuration")
 		}
-		return magnetic.NewClient(&magnetic.Config{
-			BaseURL:     config.API.Magnetic.BaseURL,
-			APIKey:      config.API.Magnetic.APIKey,
-			WorkspaceID: config.API.Magnetic.WorkspaceID,
-			ProjectID:   config.API.Magnetic.ProjectID,
-			Timeout:     config.API.TimeoutSeconds,
-			Retries:     config.API.RetryAttempts,
-		})
+		return newMagneticClient(config)
 
 	case "clockify":
 		if !config.API.Clockify.Enabled {
 			return nil, fmt.Errorf("clockify API is disabled in configuration")
 		}
-		return clockify.NewClient(&clockify.Config{
-			BaseURL:     config.API.Clockify.BaseURL,
-			APIKey:      config.API.Clockify.APIKey,
-			WorkspaceID: config.API.Clockify.WorkspaceID,
-			ProjectID:   config.API.Clockify.ProjectID,
-			Timeout:     config.API.TimeoutSeconds,
-			Retries:     config.API.RetryAttempts,
-		})
+		return newClockifyClient(config)
 
 	default:
 		return nil, fmt.Errorf("unknown time tracking provider: %s", provider)
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"timeclip/internal/models"
+	"timeclip/internal/notify"
+)
+
+// ErrFirstWriteNotConfirmed is returned when api.confirm_first_write holds
+// back a submission that wasn't approved
+var ErrFirstWriteNotConfirmed = errors.New("first write to a provider not approved")
+
+// Dialog buttons for the first write confirmation
+const (
+	firstWriteHold = "Not Now"
+	firstWriteSend = "Send"
+)
+
+var (
+	firstWriteMu         sync.Mutex // Makes concurrent submissions wait for a single dialog
+	firstWriteDeclinedOn string     // Day the dialog was declined; it isn't shown again that day
+	askFirstWrite        = notify.Ask
+)
+
+// confirmFirstWrite asks before the first entry is ever created at a
+// provider when api.confirm_first_write is on. The approval is stored next
+// to the database, so it is asked once. Declining, or a dialog that can't be
+// shown, holds the submission (see holdFirstWrite); after a decline the
+// dialog isn't shown again until the next day.
+func (sal *SimpleAutoLogger) confirmFirstWrite(config *models.Config, provider string, entry *models.DailyTimeEntry, parts []logPart) error {
+	if !config.API.ConfirmFirstWrite || sal.db.IsFirstWriteConfirmed() {
+		return nil
+	}
+
+	firstWriteMu.Lock()
+	defer firstWriteMu.Unlock()
+	if sal.db.IsFirstWriteConfirmed() {
+		return nil
+	}
+	today := time.Now().Format("2006-01-02")
+	if firstWriteDeclinedOn == today {
+		return ErrFirstWriteNotConfirmed
+	}
+
+	log.Printf("✋ Asking before the first write to %s", provider)
+	choice, err := askFirstWrite("Timeclip", firstWriteSummary(config, provider, entry, parts), firstWriteHold, firstWriteSend)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrFirstWriteNotConfirmed, err)
+	}
+	if choice != firstWriteSend {
+		firstWriteDeclinedOn = today
+		log.Printf("First write to %s held back; asking again tomorrow", provider)
+		return ErrFirstWriteNotConfirmed
+	}
+
+	if err := sal.db.SetFirstWriteConfirmed(); err != nil {
+		log.Printf("Error recording first write approval: %v", err)
+	}
+	log.Printf("First write to %s approved", provider)
+	return nil
+}
+
+// holdFirstWrite handles a submission that api.confirm_first_write held
+// back. Waiting for approval isn't a failure: it doesn't count toward
+// api.max_daily_attempts, no fallback provider is tried (it would ask the
+// same question), and the day stays queued until the dialog is shown again.
+func (sal *SimpleAutoLogger) holdFirstWrite(config *models.Config, entry *models.DailyTimeEntry, err error) error {
+	log.Printf("✋ Holding %s until the first write is approved", entry.Date)
+	if config.API.QueueRetryMinutes <= 0 {
+		return err
+	}
+
+	now := time.Now()
+	wait := time.Duration(config.API.QueueRetryMinutes) * time.Minute
+	firstWriteMu.Lock()
+	if firstWriteDeclinedOn == now.Format("2006-01-02") {
+		year, month, day := now.Date()
+		wait = time.Date(year, month, day+1, 0, 0, 0, 0, now.Location()).Sub(now)
+	}
+	firstWriteMu.Unlock()
+
+	if holdErr := sal.db.HoldPendingLog(entry.Date, err.Error(), wait); holdErr != nil {
+		log.Printf("Error queueing held log: %v", holdErr)
+	}
+	return err
+}
+
+// firstWriteSummary describes what is about to be sent for the confirmation dialog
+func firstWriteSummary(config *models.Config, provider string, entry *models.DailyTimeEntry, parts []logPart) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Timeclip is about to create its first real time entries in %s:\n\n", provider)
+	for _, part := range parts {
+		fmt.Fprintf(&b, "• %s  %dh %02dm  %q\n", entry.Date, part.minutes/60, part.minutes%60, part.description)
+	}
+
+	workspace, project := providerTarget(config, provider)
+	if workspace != "" {
+		fmt.Fprintf(&b, "\nWorkspace: %s", workspace)
+	}
+	if project != "" {
+		fmt.Fprintf(&b, "\nProject: %s", project)
+	}
+
+	b.WriteString("\n\nSend them? You won't be asked again.")
+	return b.String()
+}
+
+// providerTarget returns the configured workspace and project of a provider
+func providerTarget(config *models.Config, provider string) (string, string) {
+	switch provider {
+	case "magnetic":
+		return config.API.Magnetic.WorkspaceID, config.API.Magnetic.ProjectID
+	case "clockify":
+		return config.API.Clockify.WorkspaceID, config.API.Clockify.ProjectID
+	}
+	return "", ""
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"timeclip/internal/models"
+)
+
+// stubFirstWriteDialog answers the first write dialog with choice and
+// counts how often it was shown
+func stubFirstWriteDialog(t *testing.T, choice *string) *int {
+	t.Helper()
+	shown := 0
+	askFirstWrite = func(title, message string, buttons ...string) (string, error) {
+		shown++
+		return *choice, nil
+	}
+	firstWriteDeclinedOn = ""
+	t.Cleanup(func() {
+		askFirstWrite = notifyAsk
+		firstWriteDeclinedOn = ""
+	})
+	return &shown
+}
+
+// notifyAsk is the real dialog, restored after each test
+var notifyAsk = askFirstWrite
+
+func TestDeclinedFirstWriteIsHeld(t *testing.T) {
+	clockify, clockifyURL := startFakeProvider(t)
+	magnetic, magneticURL := startFakeProvider(t)
+
+	config := testConfig(clockifyURL, magneticURL)
+	config.API.ConfirmFirstWrite = true
+	config.API.QueueRetryMinutes = 5
+
+	choice := firstWriteHold
+	shown := stubFirstWriteDialog(t, &choice)
+
+	db := newTestDB(t)
+	entry := addDay(t, db, &models.DailyTimeEntry{Date: "2024-03-06", ActiveMinutes: 480, GoalMinutes: 480})
+	sal := NewSimpleAutoLogger(db, config)
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		if err := sal.logEntry(entry); !errors.Is(err, ErrFirstWriteNotConfirmed) {
+			t.Fatalf("attempt %d: logEntry = %v, want ErrFirstWriteNotConfirmed", attempt, err)
+		}
+	}
+
+	if *shown != 1 {
+		t.Errorf("dialog shown %d times, want once for the day", *shown)
+	}
+	if clockify.count() != 0 || magnetic.count() != 0 {
+		t.Errorf("posts = clockify %d, magnetic %d, want none", clockify.count(), magnetic.count())
+	}
+
+	stored, err := db.GetEntryForDate(entry.Date)
+	if err != nil {
+		t.Fatalf("GetEntryForDate: %v", err)
+	}
+	if stored.AutoLogFailures != 0 || stored.AutoLogged {
+		t.Errorf("failures = %d, logged = %v, want a hold, not a failure", stored.AutoLogFailures, stored.AutoLogged)
+	}
+
+	pending, err := db.GetPendingLog(entry.Date)
+	if err != nil {
+		t.Fatalf("GetPendingLog: %v", err)
+	}
+	if pending == nil {
+		t.Fatal("held day not queued")
+	}
+	if pending.Attempts != 0 {
+		t.Errorf("queued attempts = %d, want 0", pending.Attempts)
+	}
+	year, month, day := time.Now().Date()
+	tomorrow := time.Date(year, month, day+1, 0, 0, 0, 0, time.Local)
+	if pending.NextAttemptAt.Before(tomorrow.Add(-time.Second)) {
+		t.Errorf("next attempt at %v, want tomorrow (%v)", pending.NextAttemptAt, tomorrow)
+	}
+}
+
+func TestApprovedFirstWriteIsAskedOnce(t *testing.T) {
+	clockify, clockifyURL := startFakeProvider(t)
+
+	config := testConfig(clockifyURL, "")
+	config.API.ConfirmFirstWrite = true
+
+	choice := firstWriteSend
+	shown := stubFirstWriteDialog(t, &choice)
+
+	db := newTestDB(t)
+	sal := NewSimpleAutoLogger(db, config)
+	for _, date := range []string{"2024-03-07", "2024-03-08"} {
+		entry := addDay(t, db, &models.DailyTimeEntry{Date: date, ActiveMinutes: 480, GoalMinutes: 480})
+		if err := sal.logEntry(entry); err != nil {
+			t.Fatalf("logEntry %s: %v", date, err)
+		}
+	}
+
+	if *shown != 1 {
+		t.Errorf("dialog shown %d times, want once", *shown)
+	}
+	if clockify.count() != 2 {
+		t.Errorf("Clockify got %d posts, want 2", clockify.count())
+	}
+	if !db.IsFirstWriteConfirmed() {
+		t.Error("approval not stored")
+	}
+}
+
+func TestFirstWriteUsesTheAttemptsConfig(t *testing.T) {
+	clockify, clockifyURL := startFakeProvider(t)
+
+	config := testConfig(clockifyURL, "")
+	config.API.ConfirmFirstWrite = true
+
+	choice := firstWriteHold
+	shown := stubFirstWriteDialog(t, &choice)
+
+	db := newTestDB(t)
+	entry := addDay(t, db, &models.DailyTimeEntry{Date: "2024-03-09", ActiveMinutes: 480, GoalMinutes: 480})
+	sal := NewSimpleAutoLogger(db, config)
+
+	// Turning the confirmation off mid-attempt doesn't skip the dialog
+	sal.UpdateConfig(testConfig(clockifyURL, ""))
+	_, parts := sal.prepareParts(config, entry)
+	if err := sal.submit(config, "clockify", entry, parts); !errors.Is(err, ErrFirstWriteNotConfirmed) {
+		t.Fatalf("submit = %v, want ErrFirstWriteNotConfirmed", err)
+	}
+	if *shown != 1 || clockify.count() != 0 {
+		t.Errorf("dialog shown %d times, %d posts; want the attempt's config to ask first", *shown, clockify.count())
+	}
+}
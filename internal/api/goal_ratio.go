@@ -0,0 +1,38 @@
+package api
+
+import (
+	"fmt"
+	"log"
+
+	"timeclip/internal/models"
+)
+
+// belowGoalRatio returns true if a day with a goal has less than
+// api.min_goal_ratio_to_log of it. Days without a goal are never held back.
+func belowGoalRatio(config *models.Config, entry *models.DailyTimeEntry) bool {
+	ratio := config.API.MinGoalRatioToLog
+	if ratio <= 0 || !entry.HasGoal() {
+		return false
+	}
+	return float64(entry.ActiveMinutes) < ratio*float64(entry.GoalMinutes)
+}
+
+// skipLowRatio returns true if the entry is held back by
+// api.min_goal_ratio_to_log, recording a skipped_low_ratio event the first
+// time a day is skipped
+func (sal *SimpleAutoLogger) skipLowRatio(config *models.Config, entry *models.DailyTimeEntry) bool {
+	if !belowGoalRatio(config, entry) {
+		return false
+	}
+
+	if _, noted := sal.lowRatioNoted.LoadOrStore(entry.Date, true); !noted {
+		log.Printf("Not logging %s: %d of %d goal minutes is below api.min_goal_ratio_to_log (%.2f)",
+			entry.Date, entry.ActiveMinutes, entry.GoalMinutes, config.API.MinGoalRatioToLog)
+		details := fmt.Sprintf("Date: %s, Minutes: %d, Goal: %d, Ratio: %.2f",
+			entry.Date, entry.ActiveMinutes, entry.GoalMinutes, config.API.MinGoalRatioToLog)
+		if err := sal.db.LogSystemEvent("skipped_low_ratio", details); err != nil {
+			log.Printf("Error logging system event: %v", err)
+		}
+	}
+	return true
+}
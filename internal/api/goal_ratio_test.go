@@ -0,0 +1,67 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"timeclip/internal/models"
+)
+
+func TestBelowGoalRatio(t *testing.T) {
+	tests := []struct {
+		ratio        float64
+		active, goal int
+		want         bool
+	}{
+		{0, 60, 480, false},
+		{0.5, 239, 480, true},
+		{0.5, 240, 480, false},
+		{0.5, 60, 0, false},
+		{1, 479, 480, true},
+	}
+
+	for _, tc := range tests {
+		config := testConfig("", "")
+		config.API.MinGoalRatioToLog = tc.ratio
+		entry := &models.DailyTimeEntry{ActiveMinutes: tc.active, GoalMinutes: tc.goal}
+		if got := belowGoalRatio(config, entry); got != tc.want {
+			t.Errorf("ratio %v, %d/%d: belowGoalRatio = %v, want %v", tc.ratio, tc.active, tc.goal, got, tc.want)
+		}
+	}
+}
+
+func TestShouldAutoLogSkipsLowRatio(t *testing.T) {
+	config := testConfig("http://127.0.0.1:0", "")
+	config.General.AutoLogThresholdHours = 1
+	config.API.MinGoalRatioToLog = 0.5
+
+	// A recent track day, well within api.max_backlog_days
+	monday := models.StartOfWeek(time.Now(), time.Monday).AddDate(0, 0, -7).Format("2006-01-02")
+
+	db := newTestDB(t)
+	sal := NewSimpleAutoLogger(db, config)
+	short := &models.DailyTimeEntry{Date: monday, ActiveMinutes: 100, GoalMinutes: 480}
+	for i := 0; i < 2; i++ {
+		if sal.ShouldAutoLog(short) {
+			t.Fatal("ShouldAutoLog = true below the goal ratio")
+		}
+	}
+	if !sal.ShouldAutoLog(&models.DailyTimeEntry{Date: monday, ActiveMinutes: 240, GoalMinutes: 480}) {
+		t.Error("ShouldAutoLog = false at the goal ratio")
+	}
+
+	today := time.Now().Format("2006-01-02")
+	events, err := db.GetSystemEventsForDateRange(today, today)
+	if err != nil {
+		t.Fatalf("GetSystemEventsForDateRange: %v", err)
+	}
+	skipped := 0
+	for _, event := range events {
+		if event.EventType == "skipped_low_ratio" {
+			skipped++
+		}
+	}
+	if skipped != 1 {
+		t.Errorf("recorded %d skipped_low_ratio events, want one for the day", skipped)
+	}
+}
@@ -1,7 +1,7 @@
 package api
 
 import (
-	"fmt"
+	"errors"
 	"time"
 
 	"timeclip/internal/models"
@@ -61,36 +61,30 @@ func (te *TimeEntry) WithProject(workspaceID, projectID string) *TimeEntry {
 	return te
 }
 
+// WithMinutes sets the submitted duration, e.g. after rounding
+func (te *TimeEntry) WithMinutes(minutes int) *TimeEntry {
+	te.Minutes = minutes
+	te.Hours = float64(minutes) / 60.0
+	return te
+}
+
 // WithTags adds tags to the time entry
 func (te *TimeEntry) WithTags(tags ...string) *TimeEntry {
 	te.Tags = append(te.Tags, tags...)
 	return te
 }
 
-// APIError represents an error from a time tracking API
-type APIError struct {
-	Service    string `json:"service"`
-	StatusCode int    `json:"status_code"`
-	Message    string `json:"message"`
-	Details    string `json:"details,omitempty"`
-}
+// APIError represents an error from a time tracking API. It lives in models
+// so the provider clients can return it without importing this package.
+type APIError = models.APIError
 
-// Error implements the error interface
-func (ae *APIError) Error() string {
-	if ae.Details != "" {
-		return fmt.Sprintf("%s API error (%d): %s - %s", ae.Service, ae.StatusCode, ae.Message, ae.Details)
+// AsAPIError returns the *APIError wrapped in err, if any
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
 	}
-	return fmt.Sprintf("%s API error (%d): %s", ae.Service, ae.StatusCode, ae.Message)
-}
-
-// IsAuthenticationError returns true if the error is related to authentication
-func (ae *APIError) IsAuthenticationError() bool {
-	return ae.StatusCode == 401 || ae.StatusCode == 403
-}
-
-// IsRateLimitError returns true if the error is due to rate limiting
-func (ae *APIError) IsRateLimitError() bool {
-	return ae.StatusCode == 429
+	return nil, false
 }
 
 // APIConfig contains common API configuration
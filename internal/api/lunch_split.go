@@ -0,0 +1,104 @@
+package api
+
+import (
+	"log"
+	"sort"
+	"time"
+
+	"timeclip/internal/database"
+	"timeclip/internal/models"
+)
+
+// splitAtLunch lays the parts of a day out back to back from its start and,
+// with api.split_at_lunch, cuts them at the general.breaks windows: a part
+// running into a break ends at the break's start and continues as a new
+// part at its end, and later parts move past the break. Every part then
+// carries its own start. Days that don't reach a break, and days without
+// a known start, keep their parts unchanged.
+func splitAtLunch(db *database.DB, config *models.Config, entry *models.DailyTimeEntry, parts []logPart) []logPart {
+	if !config.API.SplitAtLunch || len(config.General.Breaks) == 0 {
+		return parts
+	}
+
+	start := lunchSplitStart(db, config, entry.Date)
+	if start.IsZero() {
+		log.Printf("Not splitting %s at lunch: set general.work_start or api.use_real_start_time so the day has a start", entry.Date)
+		return parts
+	}
+
+	breaks := breakTimes(config.General.Breaks, start)
+	cursor := start
+	var split []logPart
+	for _, part := range parts {
+		remaining := part.minutes
+		for remaining > 0 {
+			cursor = skipBreaks(cursor, breaks)
+			minutes := remaining
+			if next, ok := nextBreakStart(cursor, breaks); ok {
+				if untilBreak := int(next.Sub(cursor) / time.Minute); untilBreak < minutes {
+					minutes = untilBreak
+				}
+			}
+
+			piece := part
+			piece.minutes = minutes
+			piece.start = cursor
+			split = append(split, piece)
+
+			cursor = cursor.Add(time.Duration(minutes) * time.Minute)
+			remaining -= minutes
+		}
+	}
+
+	if len(split) > len(parts) {
+		log.Printf("Splitting %s at the break into %d entries", entry.Date, len(split))
+		return split
+	}
+	return parts
+}
+
+// lunchSplitStart returns when the day's logged time starts: the real start
+// with api.use_real_start_time, otherwise general.work_start
+func lunchSplitStart(db *database.DB, config *models.Config, date string) time.Time {
+	if start := entryStartTime(db, config, date); !start.IsZero() {
+		return start
+	}
+	return workStartOn(config, date)
+}
+
+// breakTimes returns the break windows on start's day as start/end times,
+// in order
+func breakTimes(windows []models.TimeWindow, start time.Time) [][2]time.Time {
+	midnight := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+
+	var breaks [][2]time.Time
+	for _, window := range windows {
+		from, to, err := window.Bounds()
+		if err != nil {
+			continue
+		}
+		breaks = append(breaks, [2]time.Time{midnight.Add(from), midnight.Add(to)})
+	}
+	sort.Slice(breaks, func(i, j int) bool { return breaks[i][0].Before(breaks[j][0]) })
+	return breaks
+}
+
+// skipBreaks moves t to the end of the break it falls in, if any
+func skipBreaks(t time.Time, breaks [][2]time.Time) time.Time {
+	for _, window := range breaks {
+		if !t.Before(window[0]) && t.Before(window[1]) {
+			t = window[1]
+		}
+	}
+	return t
+}
+
+// nextBreakStart returns the start of the first break after t
+func nextBreakStart(t time.Time, breaks [][2]time.Time) (time.Time, bool) {
+	for _, window := range breaks {
+		if window[0].After(t) {
+			return window[0], true
+		}
+	}
+	return time.Time{}, false
+}
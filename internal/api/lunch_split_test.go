@@ -0,0 +1,99 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"timeclip/internal/models"
+)
+
+func TestSplitAtLunch(t *testing.T) {
+	db := newTestDB(t)
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 5, 6, hour, minute, 0, 0, time.Local)
+	}
+	lunchConfig := func() *models.Config {
+		config := testConfig("", "")
+		config.API.SplitAtLunch = true
+		config.General.WorkStart = "09:00"
+		config.General.Breaks = []models.TimeWindow{{Start: "12:00", End: "13:00"}}
+		return config
+	}
+
+	tests := []struct {
+		name   string
+		config func(*models.Config)
+		parts  []logPart
+		want   []logPart
+	}{
+		{
+			name:  "full day",
+			parts: []logPart{{minutes: 480, description: "Work"}},
+			want: []logPart{
+				{minutes: 180, description: "Work", start: at(9, 0)},
+				{minutes: 300, description: "Work", start: at(13, 0)},
+			},
+		},
+		{
+			name: "overtime moves past the break",
+			parts: []logPart{
+				{minutes: 480, description: "Work"},
+				{minutes: 90, description: "Overtime", projectID: "overtime"},
+			},
+			want: []logPart{
+				{minutes: 180, description: "Work", start: at(9, 0)},
+				{minutes: 300, description: "Work", start: at(13, 0)},
+				{minutes: 90, description: "Overtime", projectID: "overtime", start: at(18, 0)},
+			},
+		},
+		{
+			name: "two breaks",
+			config: func(c *models.Config) {
+				c.General.Breaks = append(c.General.Breaks, models.TimeWindow{Start: "10:00", End: "10:15"})
+			},
+			parts: []logPart{{minutes: 300, description: "Work"}},
+			want: []logPart{
+				{minutes: 60, description: "Work", start: at(9, 0)},
+				{minutes: 105, description: "Work", start: at(10, 15)},
+				{minutes: 135, description: "Work", start: at(13, 0)},
+			},
+		},
+		{
+			name:  "done before lunch",
+			parts: []logPart{{minutes: 150, description: "Work"}},
+			want:  []logPart{{minutes: 150, description: "Work"}},
+		},
+		{
+			name:   "starts in the break",
+			config: func(c *models.Config) { c.General.WorkStart = "12:30" },
+			parts:  []logPart{{minutes: 60, description: "Work"}},
+			want:   []logPart{{minutes: 60, description: "Work"}},
+		},
+		{
+			name:   "no start",
+			config: func(c *models.Config) { c.General.WorkStart = "" },
+			parts:  []logPart{{minutes: 480, description: "Work"}},
+			want:   []logPart{{minutes: 480, description: "Work"}},
+		},
+		{
+			name:   "split off",
+			config: func(c *models.Config) { c.API.SplitAtLunch = false },
+			parts:  []logPart{{minutes: 480, description: "Work"}},
+			want:   []logPart{{minutes: 480, description: "Work"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			config := lunchConfig()
+			if tc.config != nil {
+				tc.config(config)
+			}
+			entry := &models.DailyTimeEntry{Date: "2024-05-06", GoalMinutes: 480}
+			if got := splitAtLunch(db, config, entry, tc.parts); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("splitAtLunch =\n %+v\nwant\n %+v", got, tc.want)
+			}
+		})
+	}
+}
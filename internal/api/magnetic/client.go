@@ -2,6 +2,7 @@ package magnetic
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -23,8 +24,13 @@ type Config struct {
 	APIKey      string
 	WorkspaceID string
 	ProjectID   string
+	ActivityID  string
 	Timeout     int
 	Retries     int
+	// ErrorFields are response fields that mark a 2xx response as failed when set
+	ErrorFields []string
+	// Context cancels requests in flight when it is done; nil never does
+	Context context.Context
 }
 
 // MagneticTimeEntry represents a time entry in Magnetic's format
@@ -33,6 +39,7 @@ type MagneticTimeEntry struct {
 	Hours       float64 `json:"hours"`
 	Description string  `json:"description"`
 	ProjectID   string  `json:"projectId,omitempty"`
+	ActivityID  string  `json:"activityId,omitempty"`
 	WorkspaceID string  `json:"workspaceId,omitempty"`
 }
 
@@ -43,12 +50,25 @@ type MagneticProject struct {
 	WorkspaceID string `json:"workspaceId"`
 }
 
+// MagneticActivity represents an activity (phase) within a Magnetic project
+type MagneticActivity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
 // MagneticWorkspace represents a workspace in Magnetic
 type MagneticWorkspace struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
 }
 
+// MagneticUser represents the user returned by /user/profile
+type MagneticUser struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
 // NewClient creates a new Magnetic API client
 func NewClient(config *Config) (*Client, error) {
 	if config == nil {
@@ -125,12 +145,24 @@ func (c *Client) Authenticate() error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode == 401 || resp.StatusCode == 403 {
-		return fmt.Errorf("authentication failed: invalid API credentials (status %d)", resp.StatusCode)
+		return &models.APIError{Service: c.Name(), StatusCode: resp.StatusCode, Message: "authentication failed: invalid API credentials"}
 	}
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("authentication failed (status %d): %s", resp.StatusCode, string(body))
+		return &models.APIError{Service: c.Name(), StatusCode: resp.StatusCode, Message: "authentication failed", Details: string(body)}
+	}
+
+	// A base URL pointing at some other server can answer 200 too, so the
+	// response has to be an actual user
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read auth response: %w", err)
+	}
+	var user MagneticUser
+	if err := json.Unmarshal(body, &user); err != nil || (user.ID == "" && user.Email == "") {
+		return &models.APIError{Service: c.Name(), StatusCode: resp.StatusCode,
+			Message: "authentication failed: /user/profile did not return a Magnetic user; check api.magnetic.base_url", Details: string(body)}
 	}
 
 	return nil
@@ -143,6 +175,7 @@ type TimeEntry struct {
 	Minutes     int       `json:"minutes"`
 	Description string    `json:"description"`
 	ProjectID   string    `json:"project_id,omitempty"`
+	ActivityID  string    `json:"activity_id,omitempty"`
 	WorkspaceID string    `json:"workspace_id,omitempty"`
 }
 
@@ -158,6 +191,7 @@ func (c *Client) CreateTimeEntry(entry interface{}) (*models.APIResponse, error)
 		Hours:       timeEntry.Hours,
 		Description: timeEntry.Description,
 		ProjectID:   c.getProjectID(timeEntry),
+		ActivityID:  c.getActivityID(timeEntry),
 		WorkspaceID: c.getWorkspaceID(timeEntry),
 	}
 
@@ -183,7 +217,23 @@ func (c *Client) CreateTimeEntry(entry interface{}) (*models.APIResponse, error)
 	}
 
 	if resp.StatusCode >= 400 {
-		return models.NewAPIResponse(false, "Failed to create time entry"), fmt.Errorf("API request failed (status %d): %s", resp.StatusCode, string(body))
+		return models.NewAPIResponse(false, "Failed to create time entry"), &models.APIError{
+			Service:    c.Name(),
+			StatusCode: resp.StatusCode,
+			Message:    "failed to create time entry",
+			Details:    string(body),
+		}
+	}
+
+	// A proxy may answer 200 with an error payload, which must not be
+	// taken for a created entry
+	if reported := models.ErrorInBody(body, c.config.ErrorFields); reported != "" {
+		return models.NewAPIResponse(false, "Failed to create time entry"), &models.APIError{
+			Service:    c.Name(),
+			StatusCode: resp.StatusCode,
+			Message:    "response reports an error: " + reported,
+			Details:    string(body),
+		}
 	}
 
 	// Parse response
@@ -209,7 +259,7 @@ func (c *Client) GetWorkspaces() ([]*models.Workspace, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("failed to retrieve workspaces (status %d)", resp.StatusCode)
+		return nil, &models.APIError{Service: c.Name(), StatusCode: resp.StatusCode, Message: "failed to retrieve workspaces"}
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -248,7 +298,7 @@ func (c *Client) GetProjects(workspaceID string) ([]*models.Project, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("failed to retrieve projects (status %d)", resp.StatusCode)
+		return nil, &models.APIError{Service: c.Name(), StatusCode: resp.StatusCode, Message: "failed to retrieve projects"}
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -273,11 +323,55 @@ func (c *Client) GetProjects(workspaceID string) ([]*models.Project, error) {
 	return projects, nil
 }
 
+// GetActivities retrieves the activities (phases) of a project
+func (c *Client) GetActivities(projectID string) ([]*models.Activity, error) {
+	endpoint := fmt.Sprintf("/projects/%s/activities", projectID)
+	req, err := c.createRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, &models.APIError{Service: c.Name(), StatusCode: resp.StatusCode, Message: "failed to retrieve activities"}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var magneticActivities []MagneticActivity
+	if err := json.Unmarshal(body, &magneticActivities); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	activities := make([]*models.Activity, len(magneticActivities))
+	for i, ma := range magneticActivities {
+		activities[i] = &models.Activity{
+			ID:        ma.ID,
+			Name:      ma.Name,
+			ProjectID: projectID, // The listing is per project
+		}
+	}
+
+	return activities, nil
+}
+
 // createRequest creates an HTTP request with proper authentication
 func (c *Client) createRequest(method, endpoint string, body io.Reader) (*http.Request, error) {
 	url := c.config.BaseURL + endpoint
 	
-	req, err := http.NewRequest(method, url, body)
+	ctx := c.config.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, err
 	}
@@ -298,6 +392,14 @@ func (c *Client) getProjectID(entry *TimeEntry) string {
 	return c.config.ProjectID
 }
 
+// getActivityID returns the activity ID to use for the time entry
+func (c *Client) getActivityID(entry *TimeEntry) string {
+	if entry.ActivityID != "" {
+		return entry.ActivityID
+	}
+	return c.config.ActivityID
+}
+
 // getWorkspaceID returns the workspace ID to use for the time entry
 func (c *Client) getWorkspaceID(entry *TimeEntry) string {
 	if entry.WorkspaceID != "" {
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"timeclip/internal/models"
+)
+
+// logPart is one provider entry submitted for a day
+type logPart struct {
+	minutes     int
+	description string
+	projectID   string    // Overrides the resolved project when set
+	start       time.Time // Set when the part doesn't follow the one before it (api.split_at_lunch)
+}
+
+// splitOvertime returns the entries to submit for a day's logged minutes.
+// With api.overtime_description set, a day over its goal is split into the
+// goal minutes with the normal description and the remainder with the
+// overtime description, logged to api.overtime_project_id when set. Days at
+// or under their goal are a single entry.
+func splitOvertime(config *models.Config, entry *models.DailyTimeEntry, minutes int, description string) []logPart {
+	regular := logPart{minutes: minutes, description: description}
+	if config.API.OvertimeDescription == "" || entry.GoalMinutes <= 0 || minutes <= entry.GoalMinutes {
+		return []logPart{regular}
+	}
+
+	regular.minutes = entry.GoalMinutes
+	overtime := logPart{
+		minutes:     minutes - entry.GoalMinutes,
+		description: describeWith(config, config.API.OvertimeDescription, entry),
+		projectID:   config.API.OvertimeProjectID,
+	}
+	return []logPart{regular, overtime}
+}
+
+// partStart returns when the part at index starts: its own start if it has
+// one, otherwise right after the parts before it
+func partStart(start time.Time, parts []logPart, index int) time.Time {
+	if !parts[index].start.IsZero() {
+		return parts[index].start
+	}
+	for _, part := range parts[:index] {
+		if !part.start.IsZero() {
+			start = part.start
+		}
+		start = start.Add(time.Duration(part.minutes) * time.Minute)
+	}
+	return start
+}
+
+// partsError reports a failed part, noting parts that were already submitted.
+// They are recorded with the day, and a retry resumes after them.
+func partsError(parts []logPart, index int, err error) error {
+	if index == 0 {
+		return err
+	}
+
+	var sent []string
+	for _, part := range parts[:index] {
+		sent = append(sent, fmt.Sprintf("%d minutes (%s)", part.minutes, part.description))
+	}
+	return fmt.Errorf("%w (already submitted: %s)", err, strings.Join(sent, ", "))
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"timeclip/internal/models"
+)
+
+func TestSplitOvertime(t *testing.T) {
+	config := testConfig("", "")
+	config.API.OvertimeDescription = "Overtime {date}"
+	config.API.OvertimeProjectID = "overtime-project"
+
+	tests := []struct {
+		name    string
+		goal    int
+		minutes int
+		want    []logPart
+	}{
+		{
+			name:    "under goal",
+			goal:    480,
+			minutes: 300,
+			want:    []logPart{{minutes: 300, description: "Work"}},
+		},
+		{
+			name:    "at goal",
+			goal:    480,
+			minutes: 480,
+			want:    []logPart{{minutes: 480, description: "Work"}},
+		},
+		{
+			name:    "over goal",
+			goal:    480,
+			minutes: 570,
+			want: []logPart{
+				{minutes: 480, description: "Work"},
+				{minutes: 90, description: "Overtime 2024-05-06", projectID: "overtime-project"},
+			},
+		},
+		{
+			name:    "no goal",
+			goal:    0,
+			minutes: 120,
+			want:    []logPart{{minutes: 120, description: "Work"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			entry := &models.DailyTimeEntry{Date: "2024-05-06", ActiveMinutes: tc.minutes, GoalMinutes: tc.goal}
+			if got := splitOvertime(config, entry, tc.minutes, "Work"); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("splitOvertime = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+
+	// Without an overtime description the day stays one entry
+	config.API.OvertimeDescription = ""
+	entry := &models.DailyTimeEntry{Date: "2024-05-06", ActiveMinutes: 570, GoalMinutes: 480}
+	if got := splitOvertime(config, entry, 570, "Work"); len(got) != 1 || got[0].minutes != 570 {
+		t.Errorf("splitOvertime = %+v, want a single 570 minute part", got)
+	}
+}
+
+func TestPartStart(t *testing.T) {
+	day := time.Date(2024, 5, 6, 9, 0, 0, 0, time.UTC)
+	afternoon := time.Date(2024, 5, 6, 13, 0, 0, 0, time.UTC)
+	parts := []logPart{
+		{minutes: 180},
+		{minutes: 240, start: afternoon},
+		{minutes: 60},
+	}
+
+	want := []time.Time{day, afternoon, afternoon.Add(240 * time.Minute)}
+	for i := range parts {
+		if got := partStart(day, parts, i); !got.Equal(want[i]) {
+			t.Errorf("partStart(%d) = %v, want %v", i, got, want[i])
+		}
+	}
+
+	back := []logPart{{minutes: 480}, {minutes: 90}}
+	if got := partStart(day, back, 1); !got.Equal(day.Add(480 * time.Minute)) {
+		t.Errorf("overtime part starts at %v, want right after the goal", got)
+	}
+}
+
+func TestPartsError(t *testing.T) {
+	errFailed := errors.New("request failed")
+	parts := []logPart{
+		{minutes: 480, description: "Work"},
+		{minutes: 90, description: "Overtime"},
+	}
+
+	if err := partsError(parts, 0, errFailed); err != errFailed {
+		t.Errorf("partsError(0) = %v, want the error unchanged", err)
+	}
+
+	err := partsError(parts, 1, errFailed)
+	if !errors.Is(err, errFailed) {
+		t.Errorf("partsError(1) = %v, want it to wrap the failure", err)
+	}
+	if want := "request failed (already submitted: 480 minutes (Work))"; err.Error() != want {
+		t.Errorf("partsError(1) = %q, want %q", err, want)
+	}
+}
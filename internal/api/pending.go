@@ -0,0 +1,190 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"timeclip/internal/database"
+	"timeclip/internal/models"
+	"timeclip/internal/notify"
+)
+
+// drainCheckInterval is how often the offline queue is checked for due retries
+const drainCheckInterval = time.Minute
+
+// queueFailedLog keeps a failed submission in the offline queue so it is
+// retried in the background, or drops it once the day has been given up on
+func queueFailedLog(db *database.DB, config *models.Config, entry *models.DailyTimeEntry, errs []error) {
+	if config.API.QueueRetryMinutes <= 0 {
+		return
+	}
+
+	if hasGivenUp(config, entry) {
+		if err := db.RemovePendingLog(entry.Date); err != nil {
+			log.Printf("Error removing pending log: %v", err)
+		}
+		return
+	}
+
+	var reasons []string
+	for _, err := range errs {
+		reasons = append(reasons, err.Error())
+	}
+
+	retryInterval := time.Duration(config.API.QueueRetryMinutes) * time.Minute
+	attempts, err := db.QueuePendingLog(entry.Date, strings.Join(reasons, "; "), retryInterval)
+	if err != nil {
+		log.Printf("Error queueing failed log: %v", err)
+		return
+	}
+	log.Printf("📥 Queued %s for a background retry (%d failed attempts)", entry.Date, attempts)
+}
+
+// isQueued returns true if a date is waiting in the offline queue, in which
+// case the drain worker owns its retries
+func (sal *SimpleAutoLogger) isQueued(date string) bool {
+	sal.mu.RLock()
+	enabled := sal.config.API.QueueRetryMinutes > 0
+	sal.mu.RUnlock()
+	if !enabled {
+		return false
+	}
+
+	pending, err := sal.db.GetPendingLog(date)
+	if err != nil {
+		log.Printf("Error checking offline queue: %v", err)
+		return false
+	}
+	return pending != nil
+}
+
+// drainLoop retries queued submissions until stop is closed. Anything queued
+// before a restart is retried straight away. With api.log_basis = weekly it
+// also logs weeks that have ended.
+func (sal *SimpleAutoLogger) drainLoop(stop <-chan struct{}) {
+	sal.drainPending()
+	sal.sweepFinishedWeeks()
+
+	ticker := time.NewTicker(drainCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			sal.drainPending()
+			sal.sweepFinishedWeeks()
+		}
+	}
+}
+
+// drainPending retries every queued submission whose backoff has expired.
+// Days that no longer need logging are dropped first; a backlog larger than
+// api.catchup_confirm_threshold is then held until it is approved.
+func (sal *SimpleAutoLogger) drainPending() {
+	sal.mu.RLock()
+	config := sal.config
+	sal.mu.RUnlock()
+
+	if config.API.QueueRetryMinutes <= 0 || sal.db.IsTrackingDisabled() {
+		return
+	}
+
+	queued, err := sal.db.GetPendingLogs()
+	if err != nil {
+		log.Printf("Error reading offline queue: %v", err)
+		return
+	}
+
+	var remaining []*models.PendingLog
+	due := make(map[string]*models.DailyTimeEntry)
+	now := time.Now()
+	for _, pending := range queued {
+		entry, err := sal.db.GetEntryForDate(pending.Date)
+		if err != nil {
+			log.Printf("Error loading queued entry for %s: %v", pending.Date, err)
+			continue
+		}
+
+		// Days marked as PTO after failing, and days that have grown older
+		// than api.max_backlog_days, are dropped rather than logged
+		if entry.AutoLogged || entry.IsPTO || hasGivenUp(config, entry) || abandonIfOld(sal.db, config, entry) {
+			if err := sal.db.RemovePendingLog(pending.Date); err != nil {
+				log.Printf("Error removing pending log: %v", err)
+			}
+			continue
+		}
+
+		remaining = append(remaining, pending)
+		if !pending.NextAttemptAt.After(now) {
+			due[pending.Date] = entry
+		}
+	}
+
+	if sal.holdCatchUp(config, remaining) {
+		return
+	}
+
+	for _, pending := range remaining {
+		entry, ok := due[pending.Date]
+		if !ok {
+			continue
+		}
+
+		log.Printf("🔁 Retrying queued log for %s (attempt %d)", pending.Date, pending.Attempts+1)
+		// A failure re-queues the entry with a longer backoff
+		sal.logEntry(entry)
+	}
+}
+
+// holdCatchUp returns true while the queue is too large to submit without
+// approval, notifying once each time the number of held days changes
+func (sal *SimpleAutoLogger) holdCatchUp(config *models.Config, pending []*models.PendingLog) bool {
+	waiting := models.AwaitingApproval(pending, config.API.CatchupConfirmThreshold)
+
+	sal.mu.Lock()
+	notified := sal.catchUpNotified
+	sal.catchUpNotified = waiting
+	sal.mu.Unlock()
+
+	if waiting == 0 {
+		return false
+	}
+	if waiting != notified {
+		log.Printf("✋ Holding %d queued days until they are approved", waiting)
+		message := fmt.Sprintf("%d days pending — review and approve them from the menu or with timeclip --approve-pending", waiting)
+		if err := notify.Send("Timeclip", message); err != nil {
+			log.Printf("Error sending notification: %v", err)
+		}
+	}
+	return true
+}
+
+// PendingApproval returns how many queued days are held until approved
+func (sal *SimpleAutoLogger) PendingApproval() int {
+	sal.mu.RLock()
+	config := sal.config
+	sal.mu.RUnlock()
+
+	pending, err := sal.db.GetPendingLogs()
+	if err != nil {
+		log.Printf("Error reading offline queue: %v", err)
+		return 0
+	}
+	return models.AwaitingApproval(pending, config.API.CatchupConfirmThreshold)
+}
+
+// ApprovePending approves the held queue and submits it straight away
+func (sal *SimpleAutoLogger) ApprovePending() error {
+	approved, err := sal.db.ApprovePendingLogs()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Approved %d queued days", approved)
+	go sal.drainPending()
+	return nil
+}
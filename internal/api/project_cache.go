@@ -0,0 +1,150 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"timeclip/internal/database"
+	"timeclip/internal/models"
+)
+
+// projectCacheFile is stored next to the database so the running app and
+// command line tools share one cache
+const projectCacheFile = "projects_cache.json"
+
+// ProjectCache caches workspace and project listings on disk for
+// api.cache_ttl_minutes, so discovery doesn't hit the network every time
+// but still picks up newly created projects. A TTL of 0 disables caching.
+type ProjectCache struct {
+	mu   sync.Mutex
+	path string
+	ttl  time.Duration
+}
+
+// projectCacheEntry is one cached listing
+type projectCacheEntry struct {
+	FetchedAt  time.Time           `json:"fetched_at"`
+	Workspaces []*models.Workspace `json:"workspaces,omitempty"`
+	Projects   []*models.Project   `json:"projects,omitempty"`
+}
+
+// NewProjectCache creates the project cache for the configured database location
+func NewProjectCache(config *models.Config) (*ProjectCache, error) {
+	dbPath, err := database.ExpandPath(config.DatabasePath())
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProjectCache{
+		path: filepath.Join(filepath.Dir(dbPath), projectCacheFile),
+		ttl:  time.Duration(config.API.CacheTTLMinutes) * time.Minute,
+	}, nil
+}
+
+// Workspaces returns the client's workspaces, from the cache while it is fresh
+func (pc *ProjectCache) Workspaces(client TimeTrackingAPI) ([]*models.Workspace, error) {
+	key := client.Name() + "/workspaces"
+	if entry, ok := pc.lookup(key); ok {
+		return entry.Workspaces, nil
+	}
+
+	workspaces, err := client.GetWorkspaces()
+	if err != nil {
+		return nil, err
+	}
+
+	pc.store(key, &projectCacheEntry{FetchedAt: time.Now(), Workspaces: workspaces})
+	return workspaces, nil
+}
+
+// Projects returns the projects in a workspace, from the cache while it is fresh
+func (pc *ProjectCache) Projects(client TimeTrackingAPI, workspaceID string) ([]*models.Project, error) {
+	key := client.Name() + "/projects/" + workspaceID
+	if entry, ok := pc.lookup(key); ok {
+		return entry.Projects, nil
+	}
+
+	projects, err := client.GetProjects(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	pc.store(key, &projectCacheEntry{FetchedAt: time.Now(), Projects: projects})
+	return projects, nil
+}
+
+// Invalidate drops all cached listings
+func (pc *ProjectCache) Invalidate() error {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	if err := os.Remove(pc.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove project cache: %w", err)
+	}
+	return nil
+}
+
+// lookup returns a cached listing if it is younger than the TTL
+func (pc *ProjectCache) lookup(key string) (*projectCacheEntry, bool) {
+	if pc.ttl <= 0 {
+		return nil, false
+	}
+
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	entry, ok := pc.read()[key]
+	if !ok || time.Since(entry.FetchedAt) >= pc.ttl {
+		return nil, false
+	}
+	return entry, true
+}
+
+// store saves a listing. Cache write failures only cost a refetch, so they are ignored.
+func (pc *ProjectCache) store(key string, entry *projectCacheEntry) {
+	if pc.ttl <= 0 {
+		return
+	}
+
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	entries := pc.read()
+	entries[key] = entry
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return
+	}
+	os.WriteFile(pc.path, data, 0600)
+}
+
+// read loads the cache file, treating a missing or corrupt file as empty
+func (pc *ProjectCache) read() map[string]*projectCacheEntry {
+	entries := make(map[string]*projectCacheEntry)
+
+	data, err := os.ReadFile(pc.path)
+	if err != nil {
+		return entries
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return make(map[string]*projectCacheEntry)
+	}
+	return entries
+}
+
+// invalidateProjectCache drops cached listings after a config change
+func invalidateProjectCache(config *models.Config) {
+	cache, err := NewProjectCache(config)
+	if err == nil {
+		err = cache.Invalidate()
+	}
+	if err != nil {
+		log.Printf("Error invalidating project cache: %v", err)
+	}
+}
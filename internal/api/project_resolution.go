@@ -0,0 +1,135 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"timeclip/internal/models"
+)
+
+// ErrNoProject is returned when projects are configured for a provider but
+// none of them can currently be logged to
+var ErrNoProject = errors.New("no usable project")
+
+// projectCandidate is one step of the project fallback chain
+type projectCandidate struct {
+	id     string
+	source string
+}
+
+// resolveProject picks the project to log a provider's entry for date to,
+// trying in order: the project picked from the menu, the provider's
+// project_id, then api.fallback_project_id. A candidate is skipped when it is
+// missing from the workspace's project list (Clockify leaves out archived
+// projects). When the list can't be fetched the first candidate is used
+// unchecked. With no candidates at all the workspace's only project is used
+// when api.auto_select_single is on, otherwise the entry is logged without a
+// project.
+func (sal *SimpleAutoLogger) resolveProject(config *models.Config, provider, date, workspaceID string) (string, error) {
+	candidates := sal.projectCandidates(config, provider, date)
+	if len(candidates) == 0 {
+		return sal.singleProject(config, provider, workspaceID), nil
+	}
+
+	available, err := sal.availableProjects(config, provider, workspaceID)
+	if err != nil {
+		log.Printf("⚠️  Couldn't check %s projects (%v); using %s from %s", provider, err, candidates[0].id, candidates[0].source)
+		return candidates[0].id, nil
+	}
+
+	var skipped []string
+	for _, candidate := range candidates {
+		if !available[candidate.id] {
+			skipped = append(skipped, fmt.Sprintf("%s (%s)", candidate.id, candidate.source))
+			continue
+		}
+		if len(skipped) > 0 {
+			log.Printf("⚠️  Skipped unavailable project %s", strings.Join(skipped, ", "))
+		}
+		log.Printf("Logging %s to %s project %s from %s", date, provider, candidate.id, candidate.source)
+		return candidate.id, nil
+	}
+
+	return "", fmt.Errorf("%w for %s: %s are archived or deleted", ErrNoProject, provider, strings.Join(skipped, ", "))
+}
+
+// projectCandidates returns the non-empty, distinct steps of the fallback chain
+func (sal *SimpleAutoLogger) projectCandidates(config *models.Config, provider, date string) []projectCandidate {
+	var configured string
+	switch provider {
+	case "magnetic":
+		configured = config.API.Magnetic.ProjectID
+	case "clockify":
+		configured = config.API.Clockify.ProjectID
+	}
+
+	chain := []projectCandidate{
+		{sal.projectIDFor(provider, date, ""), "menu selection"},
+		{configured, "api." + provider + ".project_id"},
+		{config.API.FallbackProjectID, "api.fallback_project_id"},
+	}
+
+	var candidates []projectCandidate
+	seen := make(map[string]bool)
+	for _, candidate := range chain {
+		if candidate.id == "" || seen[candidate.id] {
+			continue
+		}
+		seen[candidate.id] = true
+		candidates = append(candidates, candidate)
+	}
+	return candidates
+}
+
+// availableProjects returns the IDs of the projects that can be logged to in
+// the provider's workspace, using the project cache
+func (sal *SimpleAutoLogger) availableProjects(config *models.Config, provider, workspaceID string) (map[string]bool, error) {
+	projects, err := listProjects(config, provider, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	available := make(map[string]bool, len(projects))
+	for _, project := range projects {
+		available[project.ID] = true
+	}
+	return available, nil
+}
+
+// listProjects returns the projects in the provider's workspace, using the
+// project cache
+func listProjects(config *models.Config, provider, workspaceID string) ([]*models.Project, error) {
+	var client TimeTrackingAPI
+	switch provider {
+	case "magnetic":
+		magneticClient, err := newMagneticClient(config)
+		if err != nil {
+			return nil, err
+		}
+		client = magneticClient
+	case "clockify":
+		clockifyClient, err := newClockifyClient(config)
+		if err != nil {
+			return nil, err
+		}
+		if workspaceID == "" {
+			if workspaceID, err = clockifyClient.DefaultWorkspaceID(); err != nil {
+				return nil, err
+			}
+		}
+		client = clockifyClient
+	default:
+		return nil, fmt.Errorf("unknown provider %s", provider)
+	}
+	if workspaceID == "" {
+		return nil, fmt.Errorf("no workspace_id configured")
+	}
+
+	cache, err := NewProjectCache(config)
+	if err != nil {
+		return nil, err
+	}
+	return cache.Projects(client, workspaceID)
+}
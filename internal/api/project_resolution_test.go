@@ -0,0 +1,69 @@
+package api
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"timeclip/internal/models"
+)
+
+func TestResolveProject(t *testing.T) {
+	tests := []struct {
+		name     string
+		selected string
+		project  string
+		fallback string
+		listed   []string // Projects the workspace lists; nil can't reach it
+		archived []string
+		want     string
+		wantErr  error
+	}{
+		{"menu selection", "picked", "configured", "fallback", []string{"picked", "configured", "fallback"}, nil, "picked", nil},
+		{"selection archived", "picked", "configured", "fallback", []string{"configured", "fallback"}, []string{"picked"}, "configured", nil},
+		{"configured archived", "picked", "configured", "fallback", []string{"fallback"}, []string{"picked", "configured"}, "fallback", nil},
+		{"no selection", "", "configured", "fallback", []string{"configured", "fallback"}, nil, "configured", nil},
+		{"only the fallback", "", "", "fallback", []string{"fallback"}, nil, "fallback", nil},
+		{"all archived", "picked", "configured", "fallback", []string{"other"}, []string{"picked", "configured", "fallback"}, "", ErrNoProject},
+		{"deleted project", "", "configured", "", []string{"other"}, nil, "", ErrNoProject},
+		{"listing failed", "", "configured", "fallback", nil, nil, "configured", nil},
+		{"nothing configured", "", "", "", []string{"other"}, nil, "", nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			clockify, clockifyURL := startFakeProvider(t)
+			for _, id := range tc.listed {
+				clockify.projects = append(clockify.projects, map[string]any{"id": id, "name": id})
+			}
+			for _, id := range tc.archived {
+				clockify.projects = append(clockify.projects, map[string]any{"id": id, "name": id, "archived": true})
+			}
+
+			if tc.listed == nil {
+				clockifyURL = "http://127.0.0.1:0"
+			}
+			config := testConfig(clockifyURL, "")
+			config.Database.Path = filepath.Join(t.TempDir(), "timeclip.db") // Keeps the project cache there
+			config.API.Clockify.ProjectID = tc.project
+			config.API.FallbackProjectID = tc.fallback
+
+			sal := NewSimpleAutoLogger(newTestDB(t), config)
+			if tc.selected != "" {
+				if err := sal.SelectProject(&models.Project{ID: tc.selected, Name: tc.selected}); err != nil {
+					t.Fatalf("SelectProject: %v", err)
+				}
+			}
+
+			today := time.Now().Format("2006-01-02")
+			got, err := sal.resolveProject(config, "clockify", today, "ws")
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("resolveProject error = %v, want %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("resolveProject = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"timeclip/internal/models"
+)
+
+// projectSelection is a project picked at runtime for one day's logging
+type projectSelection struct {
+	provider string
+	date     string
+	project  *models.Project
+}
+
+// ListProjects returns the projects in the preferred provider's configured
+// workspace, using the project cache
+func (sal *SimpleAutoLogger) ListProjects() ([]*models.Project, error) {
+	sal.mu.RLock()
+	config := sal.config
+	sal.mu.RUnlock()
+
+	client, err := sal.factory.CreatePreferredAPI(config)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceID := preferredWorkspaceID(config)
+	if workspaceID == "" && config.API.PreferredProvider == "clockify" {
+		clockifyClient, err := newClockifyClient(config)
+		if err != nil {
+			return nil, err
+		}
+		if workspaceID, err = clockifyClient.DefaultWorkspaceID(); err != nil {
+			return nil, err
+		}
+	}
+	if workspaceID == "" {
+		return nil, fmt.Errorf("no workspace_id configured for %s", config.API.PreferredProvider)
+	}
+
+	cache, err := NewProjectCache(config)
+	if err != nil {
+		return nil, err
+	}
+	return cache.Projects(client, workspaceID)
+}
+
+// SelectedProjectID returns the project entries are logged to today: the one
+// picked with SelectProject, or the configured project
+func (sal *SimpleAutoLogger) SelectedProjectID() string {
+	sal.mu.RLock()
+	config := sal.config
+	sal.mu.RUnlock()
+
+	today := time.Now().Format("2006-01-02")
+	switch config.API.PreferredProvider {
+	case "magnetic":
+		return sal.projectIDFor("magnetic", today, config.API.Magnetic.ProjectID)
+	case "clockify":
+		return sal.projectIDFor("clockify", today, config.API.Clockify.ProjectID)
+	}
+	return ""
+}
+
+// SelectProject logs today's entry to project instead of the configured one.
+// The choice lasts for the rest of the day and is not saved to the config.
+func (sal *SimpleAutoLogger) SelectProject(project *models.Project) error {
+	if project == nil {
+		return fmt.Errorf("project cannot be nil")
+	}
+
+	sal.mu.Lock()
+	today := time.Now().Format("2006-01-02")
+	sal.selection = &projectSelection{
+		provider: sal.config.API.PreferredProvider,
+		date:     today,
+		project:  project,
+	}
+	sal.mu.Unlock()
+
+	log.Printf("Logging %s to project %s (%s)", today, project.Name, project.ID)
+	return sal.db.LogSystemEvent("project_selected", fmt.Sprintf("Date: %s, Project: %s", today, project.ID))
+}
+
+// projectIDFor returns the project to log a provider's entry for date to
+func (sal *SimpleAutoLogger) projectIDFor(provider, date, configured string) string {
+	sal.mu.RLock()
+	defer sal.mu.RUnlock()
+
+	if sal.selection != nil && sal.selection.provider == provider && sal.selection.date == date {
+		return sal.selection.project.ID
+	}
+	return configured
+}
+
+// preferredWorkspaceID returns the workspace configured for the preferred provider
+func preferredWorkspaceID(config *models.Config) string {
+	switch config.API.PreferredProvider {
+	case "magnetic":
+		return config.API.Magnetic.WorkspaceID
+	case "clockify":
+		return config.API.Clockify.WorkspaceID
+	}
+	return ""
+}
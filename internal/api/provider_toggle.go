@@ -0,0 +1,97 @@
+package api
+
+import (
+	"log"
+	"time"
+
+	"timeclip/internal/models"
+)
+
+// providerNames lists the supported providers in menu order
+var providerNames = []string{"magnetic", "clockify"}
+
+// ConfiguredProviders returns the providers that are enabled and have an API
+// key in the config, whether or not they are switched off for today
+func (sal *SimpleAutoLogger) ConfiguredProviders() []string {
+	sal.mu.RLock()
+	config := sal.config
+	sal.mu.RUnlock()
+
+	var providers []string
+	for _, name := range providerNames {
+		if providerConfigured(config, name) {
+			providers = append(providers, name)
+		}
+	}
+	return providers
+}
+
+// IsProviderActive returns true unless the provider was switched off for today
+func (sal *SimpleAutoLogger) IsProviderActive(provider string) bool {
+	return !sal.disabledToday()[provider]
+}
+
+// SetProviderActive switches a provider off (or back on) for the rest of
+// today. The choice survives a restart but not midnight, and the config is
+// left untouched.
+func (sal *SimpleAutoLogger) SetProviderActive(provider string, active bool) error {
+	today := time.Now().Format("2006-01-02")
+	if err := sal.db.SetProviderDisabled(today, provider, !active); err != nil {
+		return err
+	}
+
+	if active {
+		log.Printf("Logging to %s turned back on", provider)
+	} else {
+		log.Printf("Logging to %s turned off for %s", provider, today)
+	}
+	return nil
+}
+
+// providerUsable returns true if entries can be logged to a provider now:
+// it is configured and not switched off for today
+func (sal *SimpleAutoLogger) providerUsable(config *models.Config, provider string) bool {
+	return providerConfigured(config, provider) && !sal.disabledToday()[provider]
+}
+
+// allProvidersSwitchedOff returns true if providers are configured but every
+// one of them is switched off for today
+func (sal *SimpleAutoLogger) allProvidersSwitchedOff(config *models.Config) bool {
+	disabled := sal.disabledToday()
+	configured := false
+	for _, name := range providerNames {
+		if !providerConfigured(config, name) {
+			continue
+		}
+		if !disabled[name] {
+			return false
+		}
+		configured = true
+	}
+	return configured
+}
+
+// disabledToday returns the providers switched off for today
+func (sal *SimpleAutoLogger) disabledToday() map[string]bool {
+	names, err := sal.db.GetDisabledProviders(time.Now().Format("2006-01-02"))
+	if err != nil {
+		log.Printf("Error reading provider overrides: %v", err)
+	}
+
+	disabled := make(map[string]bool, len(names))
+	for _, name := range names {
+		disabled[name] = true
+	}
+	return disabled
+}
+
+// providerConfigured returns true if a provider is enabled with an API key
+func providerConfigured(config *models.Config, provider string) bool {
+	switch provider {
+	case "magnetic":
+		return config.API.Magnetic.Enabled && config.API.Magnetic.APIKey != ""
+	case "clockify":
+		return config.API.Clockify.Enabled && config.API.Clockify.APIKey != ""
+	}
+	return false
+}
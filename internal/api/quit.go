@@ -0,0 +1,92 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// logOnQuitTimeout bounds how long quitting waits for the provider
+var logOnQuitTimeout = 10 * time.Second
+
+// logOnQuitCancelWait bounds the wait for a canceled submission to return
+const logOnQuitCancelWait = 2 * time.Second
+
+// LogOnQuit logs today's time before Timeclip exits when api.log_on_quit is
+// set. Days that are already logged, given up on, outside the track days (see
+// api.log_non_track_days), shorter than api.log_on_quit_min_minutes or below
+// api.min_goal_ratio_to_log are skipped. It returns after logOnQuitTimeout at the latest; a submission still
+// in flight then is canceled and retried from the offline queue next launch.
+func (sal *SimpleAutoLogger) LogOnQuit() {
+	sal.mu.RLock()
+	config := sal.config
+	sal.mu.RUnlock()
+
+	if !config.API.LogOnQuit || sal.db.IsTrackingDisabled() {
+		return
+	}
+
+	entry, err := sal.db.GetTodayEntry()
+	if err != nil {
+		log.Printf("Error loading today's entry to log on quit: %v", err)
+		return
+	}
+
+	if entry.AutoLogged || hasGivenUp(config, entry) || !isLoggableDay(config, entry) {
+		return
+	}
+	if entry.ActiveMinutes == 0 || entry.ActiveMinutes < config.API.LogOnQuitMinMinutes {
+		log.Printf("Not logging %s on quit: only %d minutes tracked", entry.Date, entry.ActiveMinutes)
+		return
+	}
+	if sal.skipLowRatio(config, entry) {
+		return
+	}
+
+	log.Printf("Logging %s before quitting...", entry.Date)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- sal.ForceLog(entry)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			log.Printf("❌ Failed to log %s on quit: %v", entry.Date, err)
+		}
+	case <-time.After(logOnQuitTimeout):
+		log.Printf("⏳ Gave up waiting to log %s on quit after %v", entry.Date, logOnQuitTimeout)
+		// Cut the submission off before queueing it, so it can't go through
+		// after all and be sent again from the queue next launch
+		cancelRequests()
+		select {
+		case err := <-done:
+			// A failed attempt has been recorded and queued like any other
+			if err == nil {
+				log.Printf("✅ Logged %s on quit just in time", entry.Date)
+			}
+			return
+		case <-time.After(logOnQuitCancelWait):
+		}
+		queueFailedLog(sal.db, config, entry, []error{fmt.Errorf("interrupted by quitting after %v", logOnQuitTimeout)})
+	}
+}
+
+// LogToday force-logs today's entry, e.g. from "Log and Quit"
+func (sal *SimpleAutoLogger) LogToday() error {
+	entry, err := sal.db.GetTodayEntry()
+	if err != nil {
+		return fmt.Errorf("failed to load today's entry: %w", err)
+	}
+	return sal.ForceLog(entry)
+}
+
+// LogTodayTo force-logs today's entry to one provider, see ForceLogTo
+func (sal *SimpleAutoLogger) LogTodayTo(provider string) error {
+	entry, err := sal.db.GetTodayEntry()
+	if err != nil {
+		return fmt.Errorf("failed to load today's entry: %w", err)
+	}
+	return sal.ForceLogTo(entry, provider)
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"timeclip/internal/models"
+)
+
+func TestLogOnQuitCancelsBeforeQueueing(t *testing.T) {
+	timeout := logOnQuitTimeout
+	logOnQuitTimeout = 100 * time.Millisecond
+	t.Cleanup(func() {
+		logOnQuitTimeout = timeout
+		requests, cancelRequests = context.WithCancel(context.Background())
+	})
+
+	// Clockify that doesn't answer until the request is canceled
+	var canceled atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The server notices a dropped connection once the body is read
+		io.ReadAll(r.Body)
+		select {
+		case <-r.Context().Done():
+			canceled.Store(true)
+		case <-time.After(5 * time.Second):
+			w.Write([]byte(`{"id":"late"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	config := testConfig(server.URL, "")
+	config.API.LogOnQuit = true
+	config.API.QueueRetryMinutes = 5
+
+	db := newTestDB(t)
+	today := time.Now().Format("2006-01-02")
+	addDay(t, db, &models.DailyTimeEntry{Date: today, ActiveMinutes: 120, GoalMinutes: 480})
+
+	started := time.Now()
+	NewSimpleAutoLogger(db, config).LogOnQuit()
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Errorf("LogOnQuit took %v, want it to stop soon after the timeout", elapsed)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for !canceled.Load() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !canceled.Load() {
+		t.Error("submission still in flight after LogOnQuit returned")
+	}
+
+	pending, err := db.GetPendingLog(today)
+	if err != nil {
+		t.Fatalf("GetPendingLog: %v", err)
+	}
+	if pending == nil {
+		t.Fatal("interrupted day not queued")
+	}
+	if pending.Attempts != 1 {
+		t.Errorf("queued attempts = %d, want 1", pending.Attempts)
+	}
+	if stored, _ := db.GetEntryForDate(today); stored.AutoLogged {
+		t.Error("interrupted day marked logged")
+	}
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"timeclip/internal/database"
+	"timeclip/internal/models"
+)
+
+// Discrepancy describes a day whose local logging state doesn't match the provider
+type Discrepancy struct {
+	Date          string `json:"date"`
+	ActiveMinutes int    `json:"active_minutes"`
+	LocalLogged   bool   `json:"local_logged"`
+	RemoteMinutes int    `json:"remote_minutes"`
+	RemoteFound   bool   `json:"remote_found"`
+	Fixed         bool   `json:"fixed"`
+}
+
+// MissingRemotely returns true if the day is marked logged locally but has no provider entry
+func (d *Discrepancy) MissingRemotely() bool {
+	return d.LocalLogged && !d.RemoteFound
+}
+
+// Reconcile compares locally logged days between start and end (YYYY-MM-DD) with
+// the entries actually present in Clockify. When fix is true, days that are marked
+// logged locally but missing remotely are submitted again.
+func Reconcile(db *database.DB, config *models.Config, start, end string, fix bool) ([]*Discrepancy, error) {
+	if !config.API.Clockify.Enabled || config.API.Clockify.APIKey == "" {
+		return nil, fmt.Errorf("reconciliation is only supported for Clockify, which is not configured")
+	}
+
+	startDate, err := time.Parse("2006-01-02", start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date %q: %w", start, err)
+	}
+	endDate, err := time.Parse("2006-01-02", end)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end date %q: %w", end, err)
+	}
+
+	entries, err := db.GetEntriesForDateRange(start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load local entries: %w", err)
+	}
+
+	client, err := newClockifyClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Clockify client: %w", err)
+	}
+
+	records, err := client.GetTimeEntries(startDate, endDate.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Clockify time entries: %w", err)
+	}
+
+	remoteMinutes := make(map[string]int)
+	for _, record := range records {
+		if date := record.Date(); date != "" {
+			remoteMinutes[date] += record.Minutes()
+		}
+	}
+
+	var discrepancies []*Discrepancy
+	for _, entry := range entries {
+		minutes, found := remoteMinutes[entry.Date]
+		if entry.AutoLogged == found {
+			continue
+		}
+
+		discrepancies = append(discrepancies, &Discrepancy{
+			Date:          entry.Date,
+			ActiveMinutes: entry.ActiveMinutes,
+			LocalLogged:   entry.AutoLogged,
+			RemoteMinutes: minutes,
+			RemoteFound:   found,
+		})
+	}
+
+	if fix {
+		logger := NewSimpleAutoLogger(db, config)
+		for _, discrepancy := range discrepancies {
+			if !discrepancy.MissingRemotely() {
+				continue
+			}
+
+			entry, err := db.GetEntryForDate(discrepancy.Date)
+			if err != nil {
+				log.Printf("Error loading entry for %s: %v", discrepancy.Date, err)
+				continue
+			}
+
+			if err := logger.ForceLog(entry); err != nil {
+				log.Printf("❌ Failed to re-submit %s: %v", discrepancy.Date, err)
+				continue
+			}
+			discrepancy.Fixed = true
+			db.LogSystemEvent("reconcile_fixed", fmt.Sprintf("Date: %s", discrepancy.Date))
+		}
+	}
+
+	return discrepancies, nil
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"fmt"
+	"log"
+
+	"timeclip/internal/database"
+	"timeclip/internal/models"
+)
+
+// loggedMinutes returns the total to submit for an entry. With
+// api.round_logged_minutes set, the active minutes are rounded to the nearest
+// step (halves round up) and the rounding is recorded for audit; a non-empty
+// day never rounds down to zero. The stored entry is left untouched.
+func loggedMinutes(db *database.DB, config *models.Config, entry *models.DailyTimeEntry) int {
+	actual := entry.ActiveMinutes
+	rounded := roundMinutes(actual, config.API.RoundLoggedMinutes)
+	if rounded == actual {
+		return actual
+	}
+
+	log.Printf("Rounding logged total for %s: %d → %d minutes", entry.Date, actual, rounded)
+	details := fmt.Sprintf("Date: %s, Actual: %d, Sent: %d", entry.Date, actual, rounded)
+	if err := db.LogSystemEvent("logged_rounded", details); err != nil {
+		log.Printf("Error logging system event: %v", err)
+	}
+
+	return rounded
+}
+
+// roundMinutes rounds minutes to the nearest multiple of step. A step of 0
+// or less returns minutes unchanged.
+func roundMinutes(minutes, step int) int {
+	if step <= 0 || minutes <= 0 {
+		return minutes
+	}
+
+	rounded := (minutes + step/2) / step * step
+	if rounded == 0 {
+		rounded = step
+	}
+	return rounded
+}
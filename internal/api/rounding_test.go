@@ -0,0 +1,67 @@
+package api
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"timeclip/internal/models"
+)
+
+func TestRoundMinutes(t *testing.T) {
+	tests := []struct {
+		minutes, step, want int
+	}{
+		{437, 0, 437},
+		{437, -15, 437},
+		{437, 15, 435},
+		{443, 15, 450},
+		{442, 15, 435},
+		{450, 15, 450},
+		{7, 15, 15},
+		{3, 30, 30},
+		{0, 15, 0},
+		{44, 6, 42},
+		{45, 6, 48},
+	}
+
+	for _, tc := range tests {
+		if got := roundMinutes(tc.minutes, tc.step); got != tc.want {
+			t.Errorf("roundMinutes(%d, %d) = %d, want %d", tc.minutes, tc.step, got, tc.want)
+		}
+	}
+}
+
+func TestLoggedMinutesRecordsRounding(t *testing.T) {
+	db := newTestDB(t)
+	config := testConfig("", "")
+	config.API.RoundLoggedMinutes = 15
+
+	entry := &models.DailyTimeEntry{Date: "2024-05-06", ActiveMinutes: 443, GoalMinutes: 480}
+	if got := loggedMinutes(db, config, entry); got != 450 {
+		t.Errorf("loggedMinutes = %d, want 450", got)
+	}
+	if entry.ActiveMinutes != 443 {
+		t.Errorf("entry changed to %d minutes, want it left alone", entry.ActiveMinutes)
+	}
+
+	// Already on a step: nothing to record
+	if got := loggedMinutes(db, config, &models.DailyTimeEntry{Date: "2024-05-07", ActiveMinutes: 450}); got != 450 {
+		t.Errorf("loggedMinutes = %d, want 450", got)
+	}
+
+	today := time.Now().Format("2006-01-02")
+	events, err := db.GetSystemEventsForDateRange(today, today)
+	if err != nil {
+		t.Fatalf("GetSystemEventsForDateRange: %v", err)
+	}
+	var rounded []string
+	for _, event := range events {
+		if event.EventType == "logged_rounded" {
+			rounded = append(rounded, event.Details)
+		}
+	}
+	if len(rounded) != 1 || !strings.Contains(rounded[0], "Actual: 443, Sent: 450") {
+		t.Errorf("logged_rounded events = %q, want one for 443 → 450", rounded)
+	}
+}
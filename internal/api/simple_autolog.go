@@ -1,8 +1,11 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -20,6 +23,11 @@ type SimpleAutoLogger struct {
 	factory        *Factory
 	isRunning      bool
 	thresholdHours float64
+	selection      *projectSelection // Project picked from the menu for today
+	stopDrain      chan struct{}     // Stops the offline queue worker
+	catchUpNotified int              // Held queue size last notified about
+	attempts       dateAttempts      // Serializes log attempts per day
+	lowRatioNoted  sync.Map          // Dates already reported as below api.min_goal_ratio_to_log
 }
 
 // NewSimpleAutoLogger creates a new simple auto-logger
@@ -41,12 +49,19 @@ func (sal *SimpleAutoLogger) Start() error {
 		return fmt.Errorf("auto-logger is already running")
 	}
 
+	// Only the tracking machine logs; an observer would log the same day twice
+	if sal.config.General.ObserverMode {
+		return fmt.Errorf("auto-logging is disabled in observer mode")
+	}
+
 	// Test API connections
 	if err := sal.testAPIConnections(); err != nil {
 		return fmt.Errorf("failed to initialize APIs: %w", err)
 	}
 
 	sal.isRunning = true
+	sal.stopDrain = make(chan struct{})
+	go sal.drainLoop(sal.stopDrain)
 	log.Printf("Simple auto-logger started with threshold: %.1f hours", sal.thresholdHours)
 	return nil
 }
@@ -61,12 +76,25 @@ func (sal *SimpleAutoLogger) Stop() {
 	}
 
 	sal.isRunning = false
+	close(sal.stopDrain)
 	log.Println("Simple auto-logger stopped")
 }
 
 // CheckAndLog checks if an entry should be auto-logged and logs it
 func (sal *SimpleAutoLogger) CheckAndLog(entry *models.DailyTimeEntry) {
-	if !sal.ShouldAutoLog(entry) {
+	if sal.db.IsTrackingDisabled() || !sal.ShouldAutoLog(entry) {
+		return
+	}
+
+	// Queued days are retried by the drain worker on its own backoff
+	if sal.isQueued(entry.Date) {
+		return
+	}
+
+	sal.mu.RLock()
+	interval := time.Duration(sal.config.API.MinAttemptIntervalSeconds) * time.Second
+	sal.mu.RUnlock()
+	if sal.attempts.attemptedWithin(entry.Date, interval) {
 		return
 	}
 
@@ -80,8 +108,29 @@ func (sal *SimpleAutoLogger) ShouldAutoLog(entry *models.DailyTimeEntry) bool {
 		return false
 	}
 
-	actualHours := float64(entry.ActiveMinutes) / 60.0
-	return actualHours >= sal.thresholdHours
+	if hasGivenUp(sal.config, entry) || !isLoggableDay(sal.config, entry) {
+		return false
+	}
+
+	// Too old to log without being asked to
+	if abandonIfOld(sal.db, sal.config, entry) {
+		return false
+	}
+
+	// Waiting out the day rather than recording failures
+	if sal.allProvidersSwitchedOff(sal.config) {
+		return false
+	}
+
+	// Weekly logging takes every tracked day, but only once its week is over
+	var due bool
+	if sal.config.API.LogBasis == models.LogBasisWeekly {
+		due = entry.ActiveMinutes > 0 && weekFinished(sal.config, entry.Date, time.Now())
+	} else {
+		due = entry.ActiveMinutes >= sal.config.AutoLogThresholdMinutes(entry.GoalMinutes)
+	}
+
+	return due && !sal.skipLowRatio(sal.config, entry)
 }
 
 // IsRunning returns true if the auto-logger is currently running
@@ -91,6 +140,17 @@ func (sal *SimpleAutoLogger) IsRunning() bool {
 	return sal.isRunning
 }
 
+// UpdateConfig swaps in a reloaded configuration. Cached project listings are
+// dropped, since the workspace or credentials may have changed.
+func (sal *SimpleAutoLogger) UpdateConfig(newConfig *models.Config) {
+	sal.mu.Lock()
+	defer sal.mu.Unlock()
+
+	sal.config = newConfig
+	sal.thresholdHours = newConfig.General.AutoLogThresholdHours
+	invalidateProjectCache(newConfig)
+}
+
 // ForceLog forces logging of an entry regardless of threshold
 func (sal *SimpleAutoLogger) ForceLog(entry *models.DailyTimeEntry) error {
 	if entry == nil {
@@ -100,202 +160,428 @@ func (sal *SimpleAutoLogger) ForceLog(entry *models.DailyTimeEntry) error {
 	return sal.logEntry(entry)
 }
 
+// ForceLogTo logs an entry to one provider regardless of threshold, skipping
+// the preferred provider and fallback order, e.g. for a one-off correction.
+// The provider must be enabled and have an API key; switching it off for
+// today in the menu doesn't stop an explicit request. A failure is returned
+// without queueing a retry.
+func (sal *SimpleAutoLogger) ForceLogTo(entry *models.DailyTimeEntry, provider string) error {
+	if entry == nil {
+		return fmt.Errorf("entry cannot be nil")
+	}
+
+	sal.mu.RLock()
+	config := sal.config
+	sal.mu.RUnlock()
+
+	if !slices.Contains(providerNames, provider) {
+		return fmt.Errorf("unknown provider %q, expected one of: %s", provider, strings.Join(providerNames, ", "))
+	}
+	if !providerConfigured(config, provider) {
+		return fmt.Errorf("%s is not enabled or has no API key", provider)
+	}
+
+	unlock := sal.attempts.lock(entry.Date)
+	defer unlock()
+	if current, err := sal.db.GetEntryForDate(entry.Date); err == nil && current.AutoLogged {
+		return fmt.Errorf("%s is already logged", entry.Date)
+	}
+
+	log.Printf("Logging %s to %s on request (%.1f hours)", entry.Date, provider, float64(entry.ActiveMinutes)/60.0)
+
+	description, parts := sal.prepareParts(config, entry)
+
+	if resumed, _, err := sal.db.LoggedParts(entry.Date); err == nil && resumed != "" && resumed != provider {
+		return fmt.Errorf("%s is partly logged to %s; finish it there first", entry.Date, resumed)
+	}
+
+	if err := sal.submit(config, provider, entry, parts); err != nil {
+		log.Printf("❌ Failed to log %s to %s: %v", entry.Date, provider, err)
+		return fmt.Errorf("failed to log to %s: %w", provider, err)
+	}
+
+	if err := sal.confirmLogged(config, provider, entry, parts, fmt.Sprintf("Successfully logged to %s (forced): %s", provider, description)); err != nil {
+		return err
+	}
+	log.Printf("✅ Successfully logged %s to %s", entry.Date, provider)
+	return nil
+}
+
+// prepareParts builds the description and the regular/overtime parts of an entry
+func (sal *SimpleAutoLogger) prepareParts(config *models.Config, entry *models.DailyTimeEntry) (string, []logPart) {
+	description := describeEntry(config, entry)
+	minutes := loggedMinutes(sal.db, config, entry)
+	parts := splitOvertime(config, entry, minutes, description)
+	if len(parts) > 1 {
+		log.Printf("Splitting %s into %d regular and %d overtime minutes", entry.Date, parts[0].minutes, parts[1].minutes)
+	}
+	return description, splitAtLunch(sal.db, config, entry, parts)
+}
+
 // logEntry handles the actual logging process
 func (sal *SimpleAutoLogger) logEntry(entry *models.DailyTimeEntry) error {
 	sal.mu.RLock()
 	config := sal.config
 	sal.mu.RUnlock()
 
+	// A second attempt for the same day waits here, then finds it logged
+	unlock := sal.attempts.lock(entry.Date)
+	defer unlock()
+	if current, err := sal.db.GetEntryForDate(entry.Date); err == nil && current.AutoLogged {
+		log.Printf("Skipping %s: already logged", entry.Date)
+		return nil
+	}
+
 	log.Printf("Auto-logging entry for %s (%.1f hours)", entry.Date, float64(entry.ActiveMinutes)/60.0)
 
-	description := fmt.Sprintf("Timeclip auto-log for %s", entry.Date)
+	description, parts := sal.prepareParts(config, entry)
+
+	// A day left part way through goes back to the same provider only, so
+	// it isn't split across providers
+	if provider, sent, err := sal.db.LoggedParts(entry.Date); err != nil {
+		log.Printf("Error reading logged parts: %v", err)
+	} else if sent > 0 {
+		return sal.resumeEntry(config, provider, sent, entry, description, parts)
+	}
 
 	// Try preferred API first
 	preferredProvider := config.API.PreferredProvider
-	
-	var err error
+
+	var errs []error
 	switch preferredProvider {
 	case "magnetic":
-		if config.API.Magnetic.Enabled && config.API.Magnetic.APIKey != "" {
-			err = sal.logToMagnetic(entry, description)
+		if sal.providerUsable(config, "magnetic") {
+			err := sal.submit(config, "magnetic", entry, parts)
 			if err == nil {
-				sal.markAsLogged(entry, fmt.Sprintf("Successfully logged to Magnetic: %s", description))
+				if err := sal.confirmLogged(config, "magnetic", entry, parts, fmt.Sprintf("Successfully logged to Magnetic: %s", description)); err != nil {
+					return err
+				}
 				log.Printf("✅ Successfully logged %s to Magnetic", entry.Date)
 				return nil
 			}
+			if errors.Is(err, ErrFirstWriteNotConfirmed) {
+				return sal.holdFirstWrite(config, entry, err)
+			}
 			log.Printf("❌ Failed to log to Magnetic: %v", err)
+			errs = append(errs, err)
 		}
 	case "clockify":
-		if config.API.Clockify.Enabled && config.API.Clockify.APIKey != "" {
-			err = sal.logToClockify(entry, description)
+		if sal.providerUsable(config, "clockify") {
+			err := sal.submit(config, "clockify", entry, parts)
 			if err == nil {
-				sal.markAsLogged(entry, fmt.Sprintf("Successfully logged to Clockify: %s", description))
+				if err := sal.confirmLogged(config, "clockify", entry, parts, fmt.Sprintf("Successfully logged to Clockify: %s", description)); err != nil {
+					return err
+				}
 				log.Printf("✅ Successfully logged %s to Clockify", entry.Date)
 				return nil
 			}
+			if errors.Is(err, ErrFirstWriteNotConfirmed) {
+				return sal.holdFirstWrite(config, entry, err)
+			}
 			log.Printf("❌ Failed to log to Clockify: %v", err)
+			errs = append(errs, err)
 		}
 	}
 
-	// Try fallback APIs if preferred failed
-	if preferredProvider != "magnetic" && config.API.Magnetic.Enabled && config.API.Magnetic.APIKey != "" {
-		if err := sal.logToMagnetic(entry, description); err == nil {
-			sal.markAsLogged(entry, fmt.Sprintf("Successfully logged to Magnetic (fallback): %s", description))
+	// Try fallback APIs if preferred failed, unless it took some of the
+	// parts: the rest must follow there, or the time is logged twice
+	if sal.heldForResume(config, entry, errs) {
+		return fmt.Errorf("failed to finish logging %s", entry.Date)
+	}
+	if preferredProvider != "magnetic" && sal.providerUsable(config, "magnetic") {
+		err := sal.submit(config, "magnetic", entry, parts)
+		if err == nil {
+			if err := sal.confirmLogged(config, "magnetic", entry, parts, fmt.Sprintf("Successfully logged to Magnetic (fallback): %s", description)); err != nil {
+				return err
+			}
 			log.Printf("✅ Successfully logged %s to Magnetic (fallback)", entry.Date)
 			return nil
 		}
+		if errors.Is(err, ErrFirstWriteNotConfirmed) {
+			return sal.holdFirstWrite(config, entry, err)
+		}
+		log.Printf("❌ Failed to log to Magnetic (fallback): %v", err)
+		errs = append(errs, err)
 	}
 
-	if preferredProvider != "clockify" && config.API.Clockify.Enabled && config.API.Clockify.APIKey != "" {
-		if err := sal.logToClockify(entry, description); err == nil {
-			sal.markAsLogged(entry, fmt.Sprintf("Successfully logged to Clockify (fallback): %s", description))
+	if sal.heldForResume(config, entry, errs) {
+		return fmt.Errorf("failed to finish logging %s", entry.Date)
+	}
+	if preferredProvider != "clockify" && sal.providerUsable(config, "clockify") {
+		err := sal.submit(config, "clockify", entry, parts)
+		if err == nil {
+			if err := sal.confirmLogged(config, "clockify", entry, parts, fmt.Sprintf("Successfully logged to Clockify (fallback): %s", description)); err != nil {
+				return err
+			}
 			log.Printf("✅ Successfully logged %s to Clockify (fallback)", entry.Date)
 			return nil
 		}
+		if errors.Is(err, ErrFirstWriteNotConfirmed) {
+			return sal.holdFirstWrite(config, entry, err)
+		}
+		log.Printf("❌ Failed to log to Clockify (fallback): %v", err)
+		errs = append(errs, err)
 	}
 
 	log.Printf("❌ Failed to log %s to any API", entry.Date)
+	handleLogErrors(sal.db, config, entry, errs)
 	return fmt.Errorf("failed to log to any available API")
 }
 
-// logToMagnetic logs an entry to Magnetic API
-func (sal *SimpleAutoLogger) logToMagnetic(entry *models.DailyTimeEntry, description string) error {
-	config := sal.config.API.Magnetic
-
-	client, err := magnetic.NewClient(&magnetic.Config{
-		BaseURL:     config.BaseURL,
-		APIKey:      config.APIKey,
-		WorkspaceID: config.WorkspaceID,
-		ProjectID:   config.ProjectID,
-		Timeout:     sal.config.API.TimeoutSeconds,
-		Retries:     sal.config.API.RetryAttempts,
-	})
+// heldForResume returns true if a failed attempt left some of the day's
+// parts at a provider. The failure is recorded and the day queued, so the
+// next attempt resumes there instead of sending everything to a fallback.
+func (sal *SimpleAutoLogger) heldForResume(config *models.Config, entry *models.DailyTimeEntry, errs []error) bool {
+	provider, sent, err := sal.db.LoggedParts(entry.Date)
 	if err != nil {
-		return fmt.Errorf("failed to create Magnetic client: %w", err)
+		log.Printf("Error reading logged parts: %v", err)
+		return false
 	}
-
-	// Create time entry
-	date, _ := time.Parse("2006-01-02", entry.Date)
-	timeEntry := &magnetic.TimeEntry{
-		Date:        date,
-		Hours:       float64(entry.ActiveMinutes) / 60.0,
-		Minutes:     entry.ActiveMinutes,
-		Description: description,
-		ProjectID:   config.ProjectID,
-		WorkspaceID: config.WorkspaceID,
+	if sent == 0 {
+		return false
 	}
 
-	response, err := client.CreateTimeEntry(timeEntry)
-	if err != nil {
-		return fmt.Errorf("failed to create time entry: %w", err)
+	log.Printf("⚠️  %s is partly logged to %s (%d entries); not falling back, it will resume there", entry.Date, provider, sent)
+	handleLogErrors(sal.db, config, entry, errs)
+	return true
+}
+
+// resumeEntry finishes a day whose first sent parts already reached
+// provider. Parts are rebuilt from the current totals, so only the ones
+// after sent are submitted.
+func (sal *SimpleAutoLogger) resumeEntry(config *models.Config, provider string, sent int, entry *models.DailyTimeEntry, description string, parts []logPart) error {
+	log.Printf("Resuming %s on %s after %d of %d entries", entry.Date, provider, sent, len(parts))
+
+	if !sal.providerUsable(config, provider) {
+		err := fmt.Errorf("%s is partly logged to %s, which is not available", entry.Date, provider)
+		log.Printf("❌ %v", err)
+		handleLogErrors(sal.db, config, entry, []error{err})
+		return err
 	}
 
-	if !response.Success {
-		return fmt.Errorf("API returned error: %s", response.Message)
+	if err := sal.submit(config, provider, entry, parts); err != nil {
+		if errors.Is(err, ErrFirstWriteNotConfirmed) {
+			return sal.holdFirstWrite(config, entry, err)
+		}
+		log.Printf("❌ Failed to resume %s on %s: %v", entry.Date, provider, err)
+		handleLogErrors(sal.db, config, entry, []error{err})
+		return fmt.Errorf("failed to resume logging to %s: %w", provider, err)
 	}
 
+	if err := sal.confirmLogged(config, provider, entry, parts, fmt.Sprintf("Successfully logged to %s (resumed): %s", provider, description)); err != nil {
+		return err
+	}
+	log.Printf("✅ Successfully logged %s to %s (resumed)", entry.Date, provider)
 	return nil
 }
 
-// logToClockify logs an entry to Clockify API
-func (sal *SimpleAutoLogger) logToClockify(entry *models.DailyTimeEntry, description string) error {
-	config := sal.config.API.Clockify
-
-	client, err := clockify.NewClient(&clockify.Config{
-		BaseURL:     config.BaseURL,
-		APIKey:      config.APIKey,
-		WorkspaceID: config.WorkspaceID,
-		ProjectID:   config.ProjectID,
-		Timeout:     sal.config.API.TimeoutSeconds,
-		Retries:     sal.config.API.RetryAttempts,
-	})
+// submit sends the parts of a day to provider, skipping parts an earlier
+// attempt already sent there. When a later part fails, the parts that got
+// through are recorded so the next attempt resumes after them; the day is
+// only marked logged once every part is in. config is the snapshot the
+// attempt started with, so one day is never sent with mixed settings.
+func (sal *SimpleAutoLogger) submit(config *models.Config, provider string, entry *models.DailyTimeEntry, parts []logPart) error {
+	from := 0
+	if resumed, sent, err := sal.db.LoggedParts(entry.Date); err == nil && resumed == provider {
+		from = min(sent, len(parts))
+	}
+
+	if err := sal.confirmFirstWrite(config, provider, entry, parts[from:]); err != nil {
+		return err
+	}
+
+	var sent int
+	var err error
+	switch provider {
+	case "magnetic":
+		sent, err = sal.logToMagnetic(config, entry, parts, from)
+	case "clockify":
+		sent, err = sal.logToClockify(config, entry, parts, from)
+	default:
+		return fmt.Errorf("unknown provider %q", provider)
+	}
+
+	if err != nil && sent > from {
+		if recordErr := sal.db.SetLoggedParts(entry.Date, provider, sent); recordErr != nil {
+			log.Printf("Error recording logged parts: %v", recordErr)
+		}
+	}
+	return err
+}
+
+// logToMagnetic logs an entry to Magnetic API, one time entry per part,
+// starting at parts[from]. It returns how many parts have been submitted.
+func (sal *SimpleAutoLogger) logToMagnetic(config *models.Config, entry *models.DailyTimeEntry, parts []logPart, from int) (int, error) {
+	client, err := newMagneticClient(config)
 	if err != nil {
-		return fmt.Errorf("failed to create Clockify client: %w", err)
+		return from, fmt.Errorf("failed to create Magnetic client: %w", err)
 	}
 
-	// Create time entry
-	date, _ := time.Parse("2006-01-02", entry.Date)
-	timeEntry := &clockify.TimeEntry{
-		Date:        date,
-		Hours:       float64(entry.ActiveMinutes) / 60.0,
-		Minutes:     entry.ActiveMinutes,
-		Description: description,
-		ProjectID:   config.ProjectID,
-		WorkspaceID: config.WorkspaceID,
+	workspaceID, err := resolveWorkspace(config, "magnetic", client)
+	if err != nil {
+		return from, err
 	}
 
-	response, err := client.CreateTimeEntry(timeEntry)
+	projectID, err := sal.resolveProject(config, "magnetic", entry.Date, workspaceID)
 	if err != nil {
-		return fmt.Errorf("failed to create time entry: %w", err)
+		return from, err
 	}
 
-	if !response.Success {
-		return fmt.Errorf("API returned error: %s", response.Message)
+	date, _ := time.Parse("2006-01-02", entry.Date)
+	for i := from; i < len(parts); i++ {
+		part := parts[i]
+		// Create time entry
+		timeEntry := &magnetic.TimeEntry{
+			Date:        date,
+			Hours:       float64(part.minutes) / 60.0,
+			Minutes:     part.minutes,
+			Description: part.description,
+			ProjectID:   projectID,
+			WorkspaceID: workspaceID,
+		}
+		if part.projectID != "" {
+			timeEntry.ProjectID = part.projectID
+		}
+
+		if err := checkMagneticActivity(client, config.API.Magnetic.ActivityID, timeEntry.ProjectID); err != nil {
+			return i, partsError(parts, i, err)
+		}
+
+		response, err := createWithRefresh(config, "magnetic", client, timeEntry)
+		if err != nil {
+			return i, partsError(parts, i, fmt.Errorf("failed to create time entry: %w", err))
+		}
+
+		if !response.Success {
+			return i, partsError(parts, i, fmt.Errorf("API returned error: %s", response.Message))
+		}
 	}
 
-	return nil
+	return len(parts), nil
 }
 
-// markAsLogged marks an entry as auto-logged in the database
-func (sal *SimpleAutoLogger) markAsLogged(entry *models.DailyTimeEntry, response string) {
-	if err := sal.db.MarkAsAutoLogged(entry.Date, response); err != nil {
-		log.Printf("Error marking entry as logged: %v", err)
+// checkMagneticActivity makes sure api.magnetic.activity_id belongs to the
+// project being logged to. When the activities can't be listed the entry is
+// sent anyway and Magnetic has the final say.
+func checkMagneticActivity(client *magnetic.Client, activityID, projectID string) error {
+	if activityID == "" || projectID == "" {
+		return nil
+	}
+
+	activities, err := client.GetActivities(projectID)
+	if err != nil {
+		log.Printf("⚠️  Couldn't check Magnetic activities (%v); using activity %s unchecked", err, activityID)
+		return nil
+	}
+	for _, activity := range activities {
+		if activity.ID == activityID {
+			return nil
+		}
 	}
+	return fmt.Errorf("activity %s is not part of Magnetic project %s; check api.magnetic.activity_id", activityID, projectID)
 }
 
-// testAPIConnections tests the configured API connections
-func (sal *SimpleAutoLogger) testAPIConnections() error {
-	var errors []string
-
-	// Test Magnetic if enabled
-	if sal.config.API.Magnetic.Enabled && sal.config.API.Magnetic.APIKey != "" {
-		client, err := magnetic.NewClient(&magnetic.Config{
-			BaseURL:     sal.config.API.Magnetic.BaseURL,
-			APIKey:      sal.config.API.Magnetic.APIKey,
-			WorkspaceID: sal.config.API.Magnetic.WorkspaceID,
-			ProjectID:   sal.config.API.Magnetic.ProjectID,
-			Timeout:     sal.config.API.TimeoutSeconds,
-			Retries:     sal.config.API.RetryAttempts,
-		})
+// logToClockify logs an entry to Clockify API, one time entry per part,
+// starting at parts[from]. Parts run back to back from the entry's start
+// time. It returns how many parts have been submitted.
+func (sal *SimpleAutoLogger) logToClockify(config *models.Config, entry *models.DailyTimeEntry, parts []logPart, from int) (int, error) {
+	client, err := newClockifyClient(config)
+	if err != nil {
+		return from, fmt.Errorf("failed to create Clockify client: %w", err)
+	}
+
+	workspaceID, err := resolveWorkspace(config, "clockify", client)
+	if err != nil {
+		return from, err
+	}
+
+	projectID, err := sal.resolveProject(config, "clockify", entry.Date, workspaceID)
+	if err != nil {
+		return from, err
+	}
+
+	date, _ := time.Parse("2006-01-02", entry.Date)
+	start := clockifyStart(sal.db, config, entry.Date)
+	for i := from; i < len(parts); i++ {
+		part := parts[i]
+		// Create time entry
+		timeEntry := &clockify.TimeEntry{
+			Date:        date,
+			Start:       partStart(start, parts, i),
+			Hours:       float64(part.minutes) / 60.0,
+			Minutes:     part.minutes,
+			Description: part.description,
+			ProjectID:   projectID,
+			WorkspaceID: workspaceID,
+		}
+		if part.projectID != "" {
+			timeEntry.ProjectID = part.projectID
+		}
+
+		response, err := createWithRefresh(config, "clockify", client, timeEntry)
 		if err != nil {
-			errors = append(errors, fmt.Sprintf("Magnetic client creation failed: %v", err))
-		} else {
-			log.Printf("✅ Magnetic API client created successfully")
-			// Test basic authentication
-			if authErr := client.Authenticate(); authErr != nil {
-				log.Printf("⚠️  Magnetic API authentication warning: %v", authErr)
-			} else {
-				log.Printf("✅ Magnetic API authentication successful")
-			}
+			return i, partsError(parts, i, fmt.Errorf("failed to create time entry: %w", err))
+		}
+
+		if !response.Success {
+			return i, partsError(parts, i, fmt.Errorf("API returned error: %s", response.Message))
 		}
 	}
 
-	// Test Clockify if enabled
-	if sal.config.API.Clockify.Enabled && sal.config.API.Clockify.APIKey != "" {
-		client, err := clockify.NewClient(&clockify.Config{
-			BaseURL:     sal.config.API.Clockify.BaseURL,
-			APIKey:      sal.config.API.Clockify.APIKey,
-			WorkspaceID: sal.config.API.Clockify.WorkspaceID,
-			ProjectID:   sal.config.API.Clockify.ProjectID,
-			Timeout:     sal.config.API.TimeoutSeconds,
-			Retries:     sal.config.API.RetryAttempts,
-		})
-		if err != nil {
-			errors = append(errors, fmt.Sprintf("Clockify client creation failed: %v", err))
-		} else {
-			log.Printf("✅ Clockify API client created successfully")
-			// Test basic authentication
-			if authErr := client.Authenticate(); authErr != nil {
-				log.Printf("⚠️  Clockify API authentication warning: %v", authErr)
-			} else {
-				log.Printf("✅ Clockify API authentication successful")
-			}
+	return len(parts), nil
+}
+
+// confirmLogged marks a day logged after a successful submission, once
+// api.verify_after_log (if set) has found it at the provider. A day that
+// can't be verified stays unlogged and counts as a failed attempt.
+func (sal *SimpleAutoLogger) confirmLogged(config *models.Config, provider string, entry *models.DailyTimeEntry, parts []logPart, response string) error {
+	if verifies(config, provider) {
+		// The day's lock is let go while the provider catches up. With every
+		// part recorded as sent, an attempt that gets in meanwhile only
+		// verifies instead of submitting the day again.
+		if err := sal.db.SetLoggedParts(entry.Date, provider, len(parts)); err != nil {
+			log.Printf("Error recording logged parts: %v", err)
 		}
+		sal.attempts.pause(entry.Date, verifyDelay)
+		if current, err := sal.db.GetEntryForDate(entry.Date); err == nil && current.AutoLogged {
+			return nil
+		}
+	}
+
+	if err := verifyLogged(sal.db, config, provider, entry, parts); err != nil {
+		log.Printf("❌ Logged %s to %s but could not verify it: %v", entry.Date, provider, err)
+		// Not there after all, so the next attempt submits it again
+		if err := sal.db.SetLoggedParts(entry.Date, "", 0); err != nil {
+			log.Printf("Error clearing logged parts: %v", err)
+		}
+		recordLogFailure(sal.db, config, entry)
+		return err
+	}
+
+	sal.markAsLogged(entry, response)
+	return nil
+}
+
+// markAsLogged marks an entry as auto-logged in the database
+func (sal *SimpleAutoLogger) markAsLogged(entry *models.DailyTimeEntry, response string) {
+	if err := sal.db.MarkAsAutoLogged(entry.Date, response); err != nil {
+		log.Printf("Error marking entry as logged: %v", err)
 	}
+}
 
-	if len(errors) > 0 {
-		return fmt.Errorf("API connection errors: %v", errors)
+// testAPIConnections checks the configured providers' credentials, all at
+// once and bounded by api.startup_check_timeout_seconds, and logs a summary.
+// Failures are only reported: logging falls back to the providers that work
+// and retries the others later. 0 skips the check.
+func (sal *SimpleAutoLogger) testAPIConnections() error {
+	timeout := time.Duration(sal.config.API.StartupCheckTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		return nil
 	}
 
+	started := time.Now()
+	checks := checkProviders(sal.config, timeout)
+	reportProviderChecks(sal.config, checks)
+	log.Printf("Provider check took %v", time.Since(started).Round(time.Millisecond))
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,227 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"timeclip/internal/database"
+	"timeclip/internal/models"
+)
+
+// newTestDB opens a fresh database in a temp directory
+func newTestDB(t *testing.T) *database.DB {
+	t.Helper()
+	db, err := database.NewDB(filepath.Join(t.TempDir(), "timeclip.db"))
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// addDay stores a day and returns it as read back
+func addDay(t *testing.T, db *database.DB, entry *models.DailyTimeEntry) *models.DailyTimeEntry {
+	t.Helper()
+	if _, err := db.ImportEntries([]*models.DailyTimeEntry{entry}, 1, true); err != nil {
+		t.Fatalf("ImportEntries: %v", err)
+	}
+	stored, err := db.GetEntryForDate(entry.Date)
+	if err != nil {
+		t.Fatalf("GetEntryForDate: %v", err)
+	}
+	return stored
+}
+
+// fakeProvider records the time entries posted to it. Posts listed in
+// failOn (1-based) are answered with a 400. Listing time entries returns
+// the ones created plus records, the way Clockify does; with unlisted set,
+// created entries never show up there. Listing projects returns projects.
+type fakeProvider struct {
+	mu       sync.Mutex
+	posts    []string
+	failOn   map[int]bool
+	records  []map[string]any
+	unlisted bool
+	projects []map[string]any
+}
+
+func (fp *fakeProvider) handler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		fp.mu.Lock()
+		defer fp.mu.Unlock()
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/user"):
+			w.Write([]byte(`{"id":"user-1","activeWorkspace":"ws"}`))
+		case strings.HasSuffix(r.URL.Path, "/time-entries") && fp.records != nil:
+			json.NewEncoder(w).Encode(fp.records)
+		case strings.HasSuffix(r.URL.Path, "/projects") && fp.projects != nil:
+			json.NewEncoder(w).Encode(fp.projects)
+		default:
+			w.Write([]byte(`[]`))
+		}
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+	fp.posts = append(fp.posts, string(body))
+	n := len(fp.posts)
+
+	if fp.failOn[n] {
+		http.Error(w, `{"message":"backend unavailable"}`, http.StatusBadRequest)
+		return
+	}
+
+	id := fmt.Sprintf("entry-%d", n)
+	var posted struct {
+		Start       string `json:"start"`
+		End         string `json:"end"`
+		Description string `json:"description"`
+	}
+	if json.Unmarshal(body, &posted) == nil && posted.Start != "" && !fp.unlisted {
+		fp.addRecord(id, posted.Description, posted.Start, posted.End)
+	}
+	w.Write([]byte(`{"id":"` + id + `"}`))
+}
+
+// addRecord adds a time entry to the list the provider returns. The caller
+// holds fp.mu.
+func (fp *fakeProvider) addRecord(id, description, start, end string) {
+	fp.records = append(fp.records, map[string]any{
+		"id":           id,
+		"description":  description,
+		"timeInterval": map[string]string{"start": start, "end": end},
+	})
+}
+
+func (fp *fakeProvider) count() int {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+	return len(fp.posts)
+}
+
+// startFakeProvider serves a fakeProvider for the duration of the test
+func startFakeProvider(t *testing.T, failOn ...int) (*fakeProvider, string) {
+	t.Helper()
+	fp := &fakeProvider{failOn: make(map[int]bool)}
+	for _, n := range failOn {
+		fp.failOn[n] = true
+	}
+	server := httptest.NewServer(http.HandlerFunc(fp.handler))
+	t.Cleanup(server.Close)
+	return fp, server.URL
+}
+
+// testConfig returns a config logging to Clockify at clockifyURL, with
+// Magnetic at magneticURL as the fallback
+func testConfig(clockifyURL, magneticURL string) *models.Config {
+	config := models.DefaultConfig()
+	config.API.PreferredProvider = "clockify"
+	config.API.Clockify = models.ClockifyConfig{Enabled: true, BaseURL: clockifyURL, APIKey: "key", WorkspaceID: "ws"}
+	config.API.Magnetic = models.MagneticConfig{Enabled: magneticURL != "", BaseURL: magneticURL, APIKey: "key", WorkspaceID: "ws"}
+	config.API.QueueRetryMinutes = 0
+	return config
+}
+
+func TestLogEntryResumesPartialSendOnSameProvider(t *testing.T) {
+	// The second Clockify post fails: the overtime part of the first attempt
+	clockify, clockifyURL := startFakeProvider(t, 2)
+	magnetic, magneticURL := startFakeProvider(t)
+
+	config := testConfig(clockifyURL, magneticURL)
+	config.API.OvertimeDescription = "Overtime"
+
+	db := newTestDB(t)
+	entry := addDay(t, db, &models.DailyTimeEntry{Date: "2024-03-04", ActiveMinutes: 540, GoalMinutes: 480})
+	sal := NewSimpleAutoLogger(db, config)
+
+	if err := sal.logEntry(entry); err == nil {
+		t.Fatal("first attempt succeeded, want the overtime part to fail")
+	}
+
+	provider, sent, err := db.LoggedParts(entry.Date)
+	if err != nil {
+		t.Fatalf("LoggedParts: %v", err)
+	}
+	if provider != "clockify" || sent != 1 {
+		t.Errorf("logged parts = %q/%d, want clockify/1", provider, sent)
+	}
+	if magnetic.count() != 0 {
+		t.Errorf("fallback got %d posts, want none while the day is partly on Clockify", magnetic.count())
+	}
+	if stored, _ := db.GetEntryForDate(entry.Date); stored.AutoLogged {
+		t.Error("day marked logged after a partial send")
+	}
+
+	if err := sal.logEntry(entry); err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+
+	if clockify.count() != 3 {
+		t.Fatalf("Clockify got %d posts, want 3 (regular, failed overtime, overtime)", clockify.count())
+	}
+	if !strings.Contains(clockify.posts[2], "Overtime") {
+		t.Errorf("resumed post = %s, want the overtime part", clockify.posts[2])
+	}
+	if magnetic.count() != 0 {
+		t.Errorf("fallback got %d posts after resume, want none", magnetic.count())
+	}
+
+	stored, err := db.GetEntryForDate(entry.Date)
+	if err != nil {
+		t.Fatalf("GetEntryForDate: %v", err)
+	}
+	if !stored.AutoLogged {
+		t.Error("day not marked logged after the resume")
+	}
+	if _, sent, _ := db.LoggedParts(entry.Date); sent != 0 {
+		t.Errorf("logged parts = %d after completion, want cleared", sent)
+	}
+}
+
+func TestLogEntryFallsBackWhenNothingWasSent(t *testing.T) {
+	clockify, clockifyURL := startFakeProvider(t, 1)
+	magnetic, magneticURL := startFakeProvider(t)
+
+	db := newTestDB(t)
+	entry := addDay(t, db, &models.DailyTimeEntry{Date: "2024-03-05", ActiveMinutes: 420, GoalMinutes: 480})
+	sal := NewSimpleAutoLogger(db, testConfig(clockifyURL, magneticURL))
+
+	if err := sal.logEntry(entry); err != nil {
+		t.Fatalf("logEntry: %v", err)
+	}
+	if clockify.count() != 1 || magnetic.count() != 1 {
+		t.Errorf("posts = clockify %d, magnetic %d, want 1 each", clockify.count(), magnetic.count())
+	}
+	if stored, _ := db.GetEntryForDate(entry.Date); !stored.AutoLogged {
+		t.Error("day not marked logged after the fallback succeeded")
+	}
+}
+
+func TestSubmitUsesTheAttemptsConfig(t *testing.T) {
+	reloaded, reloadedURL := startFakeProvider(t)
+	started, startedURL := startFakeProvider(t)
+
+	db := newTestDB(t)
+	entry := addDay(t, db, &models.DailyTimeEntry{Date: "2024-03-06", ActiveMinutes: 420, GoalMinutes: 480})
+	sal := NewSimpleAutoLogger(db, testConfig(startedURL, ""))
+	config := sal.config
+
+	// A reload while the attempt is under way doesn't redirect it
+	sal.UpdateConfig(testConfig(reloadedURL, ""))
+	_, parts := sal.prepareParts(config, entry)
+	if err := sal.submit(config, "clockify", entry, parts); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	if started.count() != 1 || reloaded.count() != 0 {
+		t.Errorf("posts = started %d, reloaded %d, want all to the attempt's provider", started.count(), reloaded.count())
+	}
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"log"
+	"time"
+
+	"timeclip/internal/database"
+	"timeclip/internal/models"
+)
+
+// entryStartTime returns when a logged entry should start. With
+// api.use_real_start_time set this is the day's first active or resume event,
+// falling back to general.work_start. The zero time keeps the provider's
+// default of midnight.
+func entryStartTime(db *database.DB, config *models.Config, date string) time.Time {
+	if !config.API.UseRealStartTime {
+		return time.Time{}
+	}
+
+	start, err := db.GetFirstActiveTime(date)
+	if err != nil {
+		log.Printf("Error getting first active time for %s: %v", date, err)
+	} else if !start.IsZero() {
+		return start
+	}
+
+	return workStartOn(config, date)
+}
+
+// workStartOn returns general.work_start on date, or the zero time when it
+// isn't set
+func workStartOn(config *models.Config, date string) time.Time {
+	if config.General.WorkStart == "" {
+		return time.Time{}
+	}
+	offset, err := models.ParseTimeOfDay(config.General.WorkStart)
+	if err != nil {
+		return time.Time{}
+	}
+	day, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	return day.Add(offset)
+}
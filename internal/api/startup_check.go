@@ -0,0 +1,135 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	"timeclip/internal/models"
+	"timeclip/internal/notify"
+)
+
+// providerCheck is the startup check result for one provider
+type providerCheck struct {
+	provider string
+	err      error // nil when the provider accepted the credentials
+}
+
+// summary describes the result in a few words, telling rejected
+// credentials apart from network problems
+func (pc providerCheck) summary() string {
+	if pc.err == nil {
+		return "ok"
+	}
+	if apiErr, ok := AsAPIError(pc.err); ok {
+		if apiErr.IsAuthenticationError() {
+			return fmt.Sprintf("rejected the API key (%d)", apiErr.StatusCode)
+		}
+		return fmt.Sprintf("error (%d)", apiErr.StatusCode)
+	}
+	var netErr net.Error
+	if errors.As(pc.err, &netErr) {
+		if netErr.Timeout() {
+			return "timed out"
+		}
+		return "unreachable"
+	}
+	return pc.err.Error()
+}
+
+// checkProviders authenticates with every configured provider at the same
+// time. Providers that haven't answered after timeout are reported as timed
+// out; their requests are left to finish in the background.
+func checkProviders(config *models.Config, timeout time.Duration) []providerCheck {
+	var providers []string
+	for _, name := range providerNames {
+		if providerConfigured(config, name) {
+			providers = append(providers, name)
+		}
+	}
+
+	results := make(chan providerCheck, len(providers))
+	for _, provider := range providers {
+		go func(provider string) {
+			results <- providerCheck{provider: provider, err: authenticateProvider(config, provider)}
+		}(provider)
+	}
+
+	checked := make(map[string]providerCheck)
+	deadline := time.After(timeout)
+	for len(checked) < len(providers) {
+		select {
+		case result := <-results:
+			checked[result.provider] = result
+		case <-deadline:
+			for _, provider := range providers {
+				if _, ok := checked[provider]; !ok {
+					checked[provider] = providerCheck{provider: provider, err: fmt.Errorf("no answer within %v", timeout)}
+				}
+			}
+		}
+	}
+
+	// Report in the usual provider order
+	checks := make([]providerCheck, 0, len(providers))
+	for _, provider := range providers {
+		checks = append(checks, checked[provider])
+	}
+	return checks
+}
+
+// authenticateProvider creates a client for provider and checks its credentials
+func authenticateProvider(config *models.Config, provider string) error {
+	switch provider {
+	case "magnetic":
+		client, err := newMagneticClient(config)
+		if err != nil {
+			return fmt.Errorf("failed to create Magnetic client: %w", err)
+		}
+		return client.Authenticate()
+	case "clockify":
+		client, err := newClockifyClient(config)
+		if err != nil {
+			return fmt.Errorf("failed to create Clockify client: %w", err)
+		}
+		return client.Authenticate()
+	}
+	return fmt.Errorf("unknown provider %q", provider)
+}
+
+// reportProviderChecks logs a one-line summary of the startup check, with
+// the full errors below it, and notifies when the preferred provider failed
+func reportProviderChecks(config *models.Config, checks []providerCheck) {
+	if len(checks) == 0 {
+		log.Printf("⚠️  No providers are enabled with an API key; nothing will be logged")
+		return
+	}
+
+	var parts []string
+	var failed []providerCheck
+	for _, check := range checks {
+		parts = append(parts, check.provider+" "+check.summary())
+		if check.err != nil {
+			failed = append(failed, check)
+		}
+	}
+
+	if len(failed) == 0 {
+		log.Printf("✅ Providers checked: %s", strings.Join(parts, ", "))
+		return
+	}
+	log.Printf("⚠️  Providers checked: %s", strings.Join(parts, ", "))
+	for _, check := range failed {
+		log.Printf("   %s: %v", check.provider, check.err)
+		if check.provider != config.API.PreferredProvider {
+			continue
+		}
+		message := fmt.Sprintf("Your preferred provider %s failed its startup check: %s", check.provider, check.summary())
+		if err := notify.Send("Timeclip", message); err != nil {
+			log.Printf("Error sending notification: %v", err)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"timeclip/internal/models"
+)
+
+// timeoutError is a net.Error, as returned for a request that timed out
+type timeoutError struct{ timeout bool }
+
+func (e timeoutError) Error() string   { return "i/o timeout" }
+func (e timeoutError) Timeout() bool   { return e.timeout }
+func (e timeoutError) Temporary() bool { return false }
+
+func TestProviderCheckSummary(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, "ok"},
+		{&models.APIError{Service: "Clockify", StatusCode: 401}, "rejected the API key (401)"},
+		{fmt.Errorf("check: %w", &models.APIError{Service: "Clockify", StatusCode: 403}), "rejected the API key (403)"},
+		{&models.APIError{Service: "Clockify", StatusCode: 502}, "error (502)"},
+		{fmt.Errorf("authentication request failed: %w", timeoutError{timeout: true}), "timed out"},
+		{fmt.Errorf("authentication request failed: %w", timeoutError{}), "unreachable"},
+		{errors.New("no answer within 5s"), "no answer within 5s"},
+	}
+
+	for _, tc := range tests {
+		if got := (providerCheck{provider: "clockify", err: tc.err}).summary(); got != tc.want {
+			t.Errorf("summary(%v) = %q, want %q", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestCheckProviders(t *testing.T) {
+	_, clockifyURL := startFakeProvider(t)
+
+	release := make(chan struct{})
+	magnetic := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	t.Cleanup(magnetic.Close)
+	t.Cleanup(func() { close(release) })
+
+	config := testConfig(clockifyURL, magnetic.URL)
+
+	started := time.Now()
+	checks := checkProviders(config, 100*time.Millisecond)
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Errorf("checkProviders took %v, want it to stop at the timeout", elapsed)
+	}
+
+	if len(checks) != 2 {
+		t.Fatalf("checks = %+v, want one per enabled provider", checks)
+	}
+	got := map[string]providerCheck{}
+	for _, check := range checks {
+		got[check.provider] = check
+	}
+	if got["clockify"].err != nil {
+		t.Errorf("clockify check = %v, want ok", got["clockify"].err)
+	}
+	if err := got["magnetic"].err; err == nil || err.Error() != "no answer within 100ms" {
+		t.Errorf("magnetic check = %v, want a timeout", err)
+	}
+
+	// Disabled providers aren't checked
+	config.API.Magnetic.Enabled = false
+	if checks := checkProviders(config, time.Second); len(checks) != 1 || checks[0].provider != "clockify" {
+		t.Errorf("checks = %+v, want only clockify", checks)
+	}
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"fmt"
+	"log"
+
+	"timeclip/internal/models"
+)
+
+// TokenRefresher is implemented by clients whose credentials expire, such as
+// OAuth-based providers. The Magnetic and Clockify clients use static API
+// keys and don't implement it.
+type TokenRefresher interface {
+	// RefreshToken obtains new credentials after the provider rejected the
+	// current ones
+	RefreshToken() error
+}
+
+// createWithRefresh creates a time entry and, when the provider answers 401
+// and the client can refresh its credentials, refreshes them and tries once
+// more (api.<provider>.refresh_on_unauthorized). Otherwise the 401 is
+// returned as an APIError, which the auto-logger reports as a credentials
+// problem instead of retrying it quietly.
+func createWithRefresh(config *models.Config, provider string, client interface {
+	CreateTimeEntry(entry interface{}) (*models.APIResponse, error)
+}, entry interface{}) (*models.APIResponse, error) {
+	response, err := client.CreateTimeEntry(entry)
+
+	apiErr, ok := AsAPIError(err)
+	if !ok || apiErr.StatusCode != 401 || !config.API.RefreshOnUnauthorized(provider) {
+		return response, err
+	}
+	refresher, ok := client.(TokenRefresher)
+	if !ok {
+		return response, err
+	}
+
+	log.Printf("🔑 %s rejected the token; refreshing and retrying once", apiErr.Service)
+	if refreshErr := refresher.RefreshToken(); refreshErr != nil {
+		return response, fmt.Errorf("%w (token refresh failed: %v)", err, refreshErr)
+	}
+	return client.CreateTimeEntry(entry)
+}
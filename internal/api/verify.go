@@ -0,0 +1,108 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"timeclip/internal/database"
+	"timeclip/internal/models"
+)
+
+// verifyDelay gives the provider a moment to make a new entry visible before
+// api.verify_after_log looks for it
+var verifyDelay = 3 * time.Second
+
+// verifyToleranceMinutes allows for the provider rounding entry durations
+const verifyToleranceMinutes = 1
+
+// warnVerifyUnsupported makes sure the "can't verify Magnetic" notice is only logged once
+var warnVerifyUnsupported sync.Once
+
+// verifies returns true if api.verify_after_log checks submissions to provider
+func verifies(config *models.Config, provider string) bool {
+	if !config.API.VerifyAfterLog {
+		return false
+	}
+	if provider != "clockify" {
+		warnVerifyUnsupported.Do(func() {
+			log.Printf("⚠️  api.verify_after_log is only supported for Clockify; %s entries are not verified", provider)
+		})
+		return false
+	}
+	return true
+}
+
+// verifyLogged checks with api.verify_after_log that every part of a day
+// just submitted to provider shows up there: an entry with the part's
+// description and start time, at least as long as the part. Other entries
+// on the same day don't count. It returns nil when verification is off or
+// not supported by the provider. The caller waits verifyDelay first.
+func verifyLogged(db *database.DB, config *models.Config, provider string, entry *models.DailyTimeEntry, parts []logPart) error {
+	if !verifies(config, provider) {
+		return nil
+	}
+
+	err := findClockifyParts(config, clockifyStart(db, config, entry.Date), parts)
+	if err == nil {
+		return nil
+	}
+
+	if logErr := db.LogSystemEvent("verify_failed", fmt.Sprintf("Date: %s, Error: %v", entry.Date, err)); logErr != nil {
+		log.Printf("Error logging system event: %v", logErr)
+	}
+	return fmt.Errorf("failed to verify logged entry: %w", err)
+}
+
+// findClockifyParts looks up the user's Clockify entries around start and
+// returns an error naming the first part that has no matching entry. Each
+// entry matches one part at most.
+func findClockifyParts(config *models.Config, start time.Time, parts []logPart) error {
+	client, err := newClockifyClient(config)
+	if err != nil {
+		return fmt.Errorf("failed to create Clockify client: %w", err)
+	}
+
+	// A day around the start covers entries whose UTC date differs
+	records, err := client.GetTimeEntries(start.AddDate(0, 0, -1), start.AddDate(0, 0, 2))
+	if err != nil {
+		return fmt.Errorf("failed to list Clockify time entries: %w", err)
+	}
+
+	matched := make(map[string]bool)
+	for i, part := range parts {
+		want := partStart(start, parts, i).Truncate(time.Second)
+		found := false
+		for _, record := range records {
+			if matched[record.ID] || record.Description != part.description {
+				continue
+			}
+			recordStart, err := time.Parse(time.RFC3339, record.TimeInterval.Start)
+			if err != nil || !recordStart.Truncate(time.Second).Equal(want) {
+				continue
+			}
+			if record.Minutes()+verifyToleranceMinutes < part.minutes {
+				continue
+			}
+			matched[record.ID] = true
+			found = true
+			break
+		}
+		if !found {
+			return fmt.Errorf("Clockify has no %d minute entry %q starting %s", part.minutes, part.description, want.Local().Format("2006-01-02 15:04"))
+		}
+	}
+	return nil
+}
+
+// clockifyStart returns when the first of a day's Clockify entries starts:
+// the real start time or general.work_start, else midnight UTC (the
+// client's default)
+func clockifyStart(db *database.DB, config *models.Config, date string) time.Time {
+	start := entryStartTime(db, config, date)
+	if start.IsZero() {
+		start, _ = time.Parse("2006-01-02", date)
+	}
+	return start
+}
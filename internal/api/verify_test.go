@@ -0,0 +1,122 @@
+package api
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"timeclip/internal/models"
+)
+
+// fastVerify shortens verifyDelay for the test
+func fastVerify(t *testing.T) {
+	t.Helper()
+	delay := verifyDelay
+	verifyDelay = 10 * time.Millisecond
+	t.Cleanup(func() { verifyDelay = delay })
+}
+
+func TestVerifyFindsSubmittedParts(t *testing.T) {
+	fastVerify(t)
+	clockify, clockifyURL := startFakeProvider(t)
+	config := testConfig(clockifyURL, "")
+	config.API.VerifyAfterLog = true
+	config.API.OvertimeDescription = "Overtime"
+
+	db := newTestDB(t)
+	entry := addDay(t, db, &models.DailyTimeEntry{Date: "2024-04-01", ActiveMinutes: 540, GoalMinutes: 480})
+	if err := NewSimpleAutoLogger(db, config).logEntry(entry); err != nil {
+		t.Fatalf("logEntry: %v", err)
+	}
+
+	if clockify.count() != 2 {
+		t.Errorf("Clockify got %d posts, want 2", clockify.count())
+	}
+	stored, err := db.GetEntryForDate(entry.Date)
+	if err != nil {
+		t.Fatalf("GetEntryForDate: %v", err)
+	}
+	if !stored.AutoLogged {
+		t.Error("verified day not marked logged")
+	}
+	if _, sent, _ := db.LoggedParts(entry.Date); sent != 0 {
+		t.Errorf("logged parts = %d after verification, want cleared", sent)
+	}
+}
+
+func TestVerifyIgnoresUnrelatedEntries(t *testing.T) {
+	fastVerify(t)
+	clockify, clockifyURL := startFakeProvider(t)
+	clockify.unlisted = true
+	// A longer entry the user made by hand on the same day
+	clockify.addRecord("manual", "Workshop", "2024-04-02T08:00:00Z", "2024-04-02T18:00:00Z")
+
+	config := testConfig(clockifyURL, "")
+	config.API.VerifyAfterLog = true
+
+	db := newTestDB(t)
+	entry := addDay(t, db, &models.DailyTimeEntry{Date: "2024-04-02", ActiveMinutes: 480, GoalMinutes: 480})
+	if err := NewSimpleAutoLogger(db, config).logEntry(entry); err == nil {
+		t.Fatal("logEntry verified a day whose entry never showed up")
+	}
+
+	stored, err := db.GetEntryForDate(entry.Date)
+	if err != nil {
+		t.Fatalf("GetEntryForDate: %v", err)
+	}
+	if stored.AutoLogged {
+		t.Error("unverified day marked logged")
+	}
+	if stored.AutoLogFailures != 1 {
+		t.Errorf("failures = %d, want 1", stored.AutoLogFailures)
+	}
+	if _, sent, _ := db.LoggedParts(entry.Date); sent != 0 {
+		t.Errorf("logged parts = %d after a failed verification, want cleared so the next attempt resubmits", sent)
+	}
+}
+
+func TestVerifyDoesNotHoldTheDayLock(t *testing.T) {
+	delay := verifyDelay
+	verifyDelay = 300 * time.Millisecond
+	t.Cleanup(func() { verifyDelay = delay })
+
+	clockify, clockifyURL := startFakeProvider(t)
+	config := testConfig(clockifyURL, "")
+	config.API.VerifyAfterLog = true
+
+	db := newTestDB(t)
+	entry := addDay(t, db, &models.DailyTimeEntry{Date: "2024-04-03", ActiveMinutes: 480, GoalMinutes: 480})
+	sal := NewSimpleAutoLogger(db, config)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := sal.logEntry(entry); err != nil {
+			t.Errorf("first attempt: %v", err)
+		}
+	}()
+
+	// Wait for the first attempt to be waiting on Clockify
+	for clockify.count() == 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	started := time.Now()
+	unlock := sal.attempts.lock(entry.Date)
+	if waited := time.Since(started); waited > verifyDelay/2 {
+		t.Errorf("waited %v for the day's lock during verification", waited)
+	}
+	unlock()
+
+	// A second attempt meanwhile must not submit the day again
+	if err := sal.logEntry(entry); err != nil {
+		t.Errorf("second attempt: %v", err)
+	}
+	wg.Wait()
+
+	if clockify.count() != 1 {
+		t.Errorf("Clockify got %d posts, want 1", clockify.count())
+	}
+}
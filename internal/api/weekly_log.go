@@ -0,0 +1,59 @@
+package api
+
+import (
+	"log"
+	"time"
+
+	"timeclip/internal/models"
+)
+
+// weekFinished returns true once the week containing date (YYYY-MM-DD) has
+// ended at now, by general.week_start
+func weekFinished(config *models.Config, date string, now time.Time) bool {
+	day, err := time.ParseInLocation("2006-01-02", date, now.Location())
+	if err != nil {
+		return false
+	}
+	thisWeek := models.StartOfWeek(now, config.General.WeekStartDay())
+	return day.Before(thisWeek)
+}
+
+// sweepFinishedWeeks logs the days of weeks that have ended with api.log_basis
+// = weekly. It runs with the offline queue worker, at launch and every
+// minute after, so a week that ended while the Mac was off or asleep is
+// logged once Timeclip runs again. Each day is submitted as with daily
+// logging and marked logged once its entries are in, so a failure part way
+// through the week doesn't resend the days that made it; failed days go
+// through the offline queue.
+func (sal *SimpleAutoLogger) sweepFinishedWeeks() {
+	sal.mu.RLock()
+	config := sal.config
+	sal.mu.RUnlock()
+
+	if config.API.LogBasis != models.LogBasisWeekly || sal.db.IsTrackingDisabled() {
+		return
+	}
+
+	now := time.Now()
+	entries, err := sal.db.GetEntriesNeedingAutoLog(1, config.API.BacklogCutoff(now))
+	if err != nil {
+		log.Printf("Error reading days to log for finished weeks: %v", err)
+		return
+	}
+
+	var due []*models.DailyTimeEntry
+	for _, entry := range entries {
+		// Queued days are retried by the drain worker on its own backoff
+		if sal.ShouldAutoLog(entry) && !sal.isQueued(entry.Date) {
+			due = append(due, entry)
+		}
+	}
+	if len(due) == 0 {
+		return
+	}
+
+	log.Printf("📅 Logging %d days of finished weeks (%s to %s)", len(due), due[0].Date, due[len(due)-1].Date)
+	for _, entry := range due {
+		sal.logEntry(entry)
+	}
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"timeclip/internal/models"
+)
+
+func TestWeekFinished(t *testing.T) {
+	// Wednesday
+	now := time.Date(2024, 5, 8, 10, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		date      string
+		weekStart string
+		want      bool
+	}{
+		{"2024-05-08", "monday", false},
+		{"2024-05-06", "monday", false},
+		{"2024-05-05", "monday", true},
+		{"2024-04-29", "monday", true},
+		{"2024-05-05", "sunday", false},
+		{"2024-05-04", "sunday", true},
+		{"not a date", "monday", false},
+	}
+
+	for _, tc := range tests {
+		config := testConfig("", "")
+		config.General.WeekStart = tc.weekStart
+		if got := weekFinished(config, tc.date, now); got != tc.want {
+			t.Errorf("weekFinished(%s, week starting %s) = %v, want %v", tc.date, tc.weekStart, got, tc.want)
+		}
+	}
+}
+
+func TestSweepFinishedWeeks(t *testing.T) {
+	clockify, clockifyURL := startFakeProvider(t)
+	config := testConfig(clockifyURL, "")
+	config.API.LogBasis = models.LogBasisWeekly
+
+	thisWeek := models.StartOfWeek(time.Now(), config.General.WeekStartDay())
+	lastMonday := thisWeek.AddDate(0, 0, -7).Format("2006-01-02")
+	lastTuesday := thisWeek.AddDate(0, 0, -6).Format("2006-01-02")
+	thisMonday := thisWeek.Format("2006-01-02")
+
+	db := newTestDB(t)
+	addDay(t, db, &models.DailyTimeEntry{Date: lastMonday, ActiveMinutes: 300, GoalMinutes: 480})
+	addDay(t, db, &models.DailyTimeEntry{Date: lastTuesday, ActiveMinutes: 45, GoalMinutes: 480})
+	addDay(t, db, &models.DailyTimeEntry{Date: thisMonday, ActiveMinutes: 480, GoalMinutes: 480})
+
+	sal := NewSimpleAutoLogger(db, config)
+	if sal.ShouldAutoLog(&models.DailyTimeEntry{Date: thisMonday, ActiveMinutes: 480, GoalMinutes: 480}) {
+		t.Error("ShouldAutoLog = true for a day of the running week")
+	}
+
+	sal.sweepFinishedWeeks()
+
+	if clockify.count() != 2 {
+		t.Errorf("Clockify got %d posts, want one per day of last week", clockify.count())
+	}
+	for date, want := range map[string]bool{lastMonday: true, lastTuesday: true, thisMonday: false} {
+		stored, err := db.GetEntryForDate(date)
+		if err != nil {
+			t.Fatalf("GetEntryForDate: %v", err)
+		}
+		if stored.AutoLogged != want {
+			t.Errorf("%s logged = %v, want %v", date, stored.AutoLogged, want)
+		}
+	}
+
+	// Nothing left to send
+	sal.sweepFinishedWeeks()
+	if clockify.count() != 2 {
+		t.Errorf("Clockify got %d posts after a second sweep, want still 2", clockify.count())
+	}
+}
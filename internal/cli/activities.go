@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"timeclip/internal/api"
+	"timeclip/internal/models"
+)
+
+// ListActivities prints the activities (phases) of a Magnetic project, to
+// find the ID for api.magnetic.activity_id.
+//
+// Usage: timeclip --list-activities [--project ID]
+func ListActivities(config *models.Config, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("list-activities", flag.ContinueOnError)
+	flags.SetOutput(out)
+	projectID := flags.String("project", config.API.Magnetic.ProjectID, "Magnetic project ID, defaults to api.magnetic.project_id")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *projectID == "" {
+		return fmt.Errorf("no project: pass --project or set api.magnetic.project_id")
+	}
+
+	activities, err := api.MagneticActivities(config, *projectID)
+	if err != nil {
+		return err
+	}
+	if len(activities) == 0 {
+		fmt.Fprintf(out, "Project %s has no activities\n", *projectID)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ACTIVITY\tID")
+	for _, activity := range activities {
+		marker := ""
+		if activity.ID == config.API.Magnetic.ActivityID {
+			marker = " (configured)"
+		}
+		fmt.Fprintf(w, "%s%s\t%s\n", activity.Name, marker, activity.ID)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"timeclip/internal/launchagent"
+	"timeclip/internal/models"
+)
+
+// InstallAgent installs a launch agent that starts the current executable at login.
+//
+// Usage: timeclip --install-agent
+func InstallAgent(config *models.Config, out io.Writer) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get executable path: %w", err)
+	}
+
+	plistPath, err := launchagent.Install(executable, config.DataDir())
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "✅ Launch agent installed: %s\n", plistPath)
+	fmt.Fprintf(out, "🚀 Timeclip will now start automatically at login\n")
+	return nil
+}
+
+// UninstallAgent unloads and removes the launch agent.
+//
+// Usage: timeclip --uninstall-agent
+func UninstallAgent(out io.Writer) error {
+	plistPath, err := launchagent.Uninstall()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "✅ Launch agent removed: %s\n", plistPath)
+	return nil
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"timeclip/internal/models"
+)
+
+// ApprovePending approves a catch-up held because the offline queue grew past
+// api.catchup_confirm_threshold. The running app submits it on its next queue
+// check, within a minute.
+//
+// Usage: timeclip --approve-pending
+func ApprovePending(config *models.Config, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("approve-pending", flag.ContinueOnError)
+	flags.SetOutput(out)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	db, err := openDB(config)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	approved, err := db.ApprovePendingLogs()
+	if err != nil {
+		return err
+	}
+
+	if approved == 0 {
+		fmt.Fprintln(out, "Nothing waiting for approval")
+		return nil
+	}
+	fmt.Fprintf(out, "✅ Approved %d queued days; they will be submitted shortly\n", approved)
+	return nil
+}
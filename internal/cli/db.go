@@ -0,0 +1,17 @@
+package cli
+
+import (
+	"timeclip/internal/database"
+	"timeclip/internal/models"
+)
+
+// openDB opens the database for writing. Days it creates get their goal
+// from the config, the same as days created by the tracker.
+func openDB(config *models.Config) (*database.DB, error) {
+	db, err := database.NewDB(config.DatabasePath())
+	if err != nil {
+		return nil, err
+	}
+	db.SetGoalFunc(config.General.GoalMinutesFor)
+	return db, nil
+}
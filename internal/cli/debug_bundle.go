@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"timeclip/internal/models"
+	"timeclip/internal/snapshot"
+)
+
+// DebugBundle writes a zip with redacted diagnostics to attach to bug reports.
+//
+// Usage: timeclip --debug-bundle out.zip [--days N | --from YYYY-MM-DD [--to YYYY-MM-DD]]
+func DebugBundle(config *models.Config, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("debug-bundle", flag.ContinueOnError)
+	flags.SetOutput(out)
+	days := flags.Int("days", 7, "include the last N days")
+	from := flags.String("from", "", "start date (YYYY-MM-DD), instead of --days")
+	to := flags.String("to", "", "end date (YYYY-MM-DD), defaults to today")
+
+	bundlePath, err := parseArchiveArgs(flags, args)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	start := now.AddDate(0, 0, -(*days - 1)).Format("2006-01-02")
+	end := now.Format("2006-01-02")
+	if *from != "" || *to != "" {
+		if start, end, err = resolveRange(now, *from, *to, false, false, false, config.General.WeekStartDay()); err != nil {
+			return err
+		}
+	} else if *days < 1 {
+		return fmt.Errorf("--days must be at least 1")
+	}
+
+	if err := snapshot.DebugBundle(config, bundlePath, start, end); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "✅ Wrote debug bundle for %s to %s to %s\n", start, end, bundlePath)
+	fmt.Fprintf(out, "🔑 API keys were redacted\n")
+	return nil
+}
@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"timeclip/internal/database"
+	"timeclip/internal/models"
+)
+
+// exportedDay is one day in an --export
+type exportedDay struct {
+	Date          string `json:"date"`
+	ActiveMinutes int    `json:"active_minutes"`
+	GoalMinutes   int    `json:"goal_minutes"`
+	GoalReached   bool   `json:"goal_reached"`
+	PTO           bool   `json:"pto"`
+	Logged        bool   `json:"logged"`
+	Notes         string `json:"notes"`
+}
+
+// Export writes the daily totals and notes for a date range as CSV or JSON.
+//
+// Usage: timeclip --export [--from YYYY-MM-DD --to YYYY-MM-DD | --week | --month | --year] [--format csv|json] [--output FILE]
+//
+// The database is opened read-only, so this is safe to run while the tracker is running.
+func Export(config *models.Config, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.SetOutput(out)
+	from := flags.String("from", "", "start date (YYYY-MM-DD)")
+	to := flags.String("to", "", "end date (YYYY-MM-DD), defaults to today")
+	week := flags.Bool("week", false, "export the current week")
+	month := flags.Bool("month", false, "export the current month")
+	year := flags.Bool("year", false, "export the current year")
+	format := flags.String("format", "csv", "csv or json")
+	output := flags.String("output", "", "file to write, defaults to standard output")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *format != "csv" && *format != "json" {
+		return fmt.Errorf("unknown --format %q: use csv or json", *format)
+	}
+
+	start, end, err := resolveRange(time.Now(), *from, *to, *week, *month, *year, config.General.WeekStartDay())
+	if err != nil {
+		return err
+	}
+
+	db, err := database.OpenReadOnly(config.DatabasePath())
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	entries, err := db.GetEntriesForDateRange(start, end)
+	if err != nil {
+		return err
+	}
+
+	days := make([]exportedDay, 0, len(entries))
+	for _, entry := range entries {
+		days = append(days, exportedDay{
+			Date:          entry.Date,
+			ActiveMinutes: entry.ActiveMinutes,
+			GoalMinutes:   entry.GoalMinutes,
+			GoalReached:   entry.IsGoalReached(),
+			PTO:           entry.IsPTO,
+			Logged:        entry.AutoLogged,
+			Notes:         entry.Notes,
+		})
+	}
+
+	writer := out
+	if *output != "" {
+		file, err := os.OpenFile(*output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", *output, err)
+		}
+		defer file.Close()
+		writer = file
+	}
+
+	if *format == "json" {
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(days); err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
+	} else if err := writeExportCSV(writer, days); err != nil {
+		return err
+	}
+
+	if *output != "" {
+		fmt.Fprintf(out, "✅ Exported %d days (%s to %s) to %s\n", len(days), start, end, *output)
+	}
+	return nil
+}
+
+// writeExportCSV writes exported days as CSV with a header row
+func writeExportCSV(out io.Writer, days []exportedDay) error {
+	writer := csv.NewWriter(out)
+	writer.Write([]string{"date", "active_minutes", "goal_minutes", "goal_reached", "pto", "logged", "notes"})
+	for _, day := range days {
+		writer.Write([]string{
+			day.Date,
+			strconv.Itoa(day.ActiveMinutes),
+			strconv.Itoa(day.GoalMinutes),
+			strconv.FormatBool(day.GoalReached),
+			strconv.FormatBool(day.PTO),
+			strconv.FormatBool(day.Logged),
+			day.Notes,
+		})
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	return nil
+}
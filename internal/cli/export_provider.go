@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"timeclip/internal/api"
+	"timeclip/internal/database"
+	"timeclip/internal/models"
+)
+
+// ExportProvider writes unlogged days in the bulk import format of a
+// provider, for a manual import when the API can't be used.
+//
+// Usage: timeclip --export-provider clockify|magnetic [--from YYYY-MM-DD --to YYYY-MM-DD | --week | --month | --year] [--include-logged] [--email ADDRESS] [--output FILE]
+//
+// Clockify gets its CSV import layout and Magnetic a JSON array of time
+// entries. The database is opened read-only, so this is safe to run while
+// the tracker is running.
+func ExportProvider(config *models.Config, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("export-provider", flag.ContinueOnError)
+	flags.SetOutput(out)
+	from := flags.String("from", "", "start date (YYYY-MM-DD)")
+	to := flags.String("to", "", "end date (YYYY-MM-DD), defaults to today")
+	week := flags.Bool("week", false, "export the current week")
+	month := flags.Bool("month", false, "export the current month")
+	year := flags.Bool("year", false, "export the current year")
+	includeLogged := flags.Bool("include-logged", false, "also export days already logged")
+	email := flags.String("email", "", "Clockify user email, looked up from the API key if omitted")
+	output := flags.String("output", "", "file to write, defaults to standard output")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: timeclip --export-provider clockify|magnetic [options]")
+	}
+
+	provider := flags.Arg(0)
+	var export func(*database.DB, *models.Config, api.BulkExportOptions, io.Writer) (int, error)
+	switch provider {
+	case "clockify":
+		export = api.ExportClockifyCSV
+	case "magnetic":
+		export = api.ExportMagneticJSON
+	default:
+		return fmt.Errorf("unknown provider %q: use clockify or magnetic", provider)
+	}
+
+	start, end, err := resolveRange(time.Now(), *from, *to, *week, *month, *year, config.General.WeekStartDay())
+	if err != nil {
+		return err
+	}
+
+	db, err := database.OpenReadOnly(config.DatabasePath())
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	writer := out
+	if *output != "" {
+		file, err := os.OpenFile(*output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", *output, err)
+		}
+		defer file.Close()
+		writer = file
+	}
+
+	days, err := export(db, config, api.BulkExportOptions{
+		Start:         start,
+		End:           end,
+		IncludeLogged: *includeLogged,
+		Email:         *email,
+	}, writer)
+	if err != nil {
+		return err
+	}
+
+	if *output != "" {
+		fmt.Fprintf(out, "✅ Exported %d days (%s to %s) for %s import to %s\n", days, start, end, provider, *output)
+	}
+	return nil
+}
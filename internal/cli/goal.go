@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"timeclip/internal/models"
+)
+
+// SetGoal overrides the goal for a single date, e.g. a planned half-day.
+//
+// Usage: timeclip --set-goal [--date YYYY-MM-DD] (--hours H | --minutes M)
+func SetGoal(config *models.Config, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("set-goal", flag.ContinueOnError)
+	flags.SetOutput(out)
+	date := flags.String("date", "", "date to change (YYYY-MM-DD), defaults to today")
+	hours := flags.Float64("hours", 0, "goal in hours")
+	minutes := flags.Int("minutes", 0, "goal in minutes")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if (*hours != 0) == (*minutes != 0) {
+		return fmt.Errorf("exactly one of --hours or --minutes is required")
+	}
+	goalMinutes := *minutes
+	if *hours != 0 {
+		goalMinutes = int(math.Round(*hours * 60))
+	}
+	if goalMinutes <= 0 {
+		return fmt.Errorf("goal must be positive")
+	}
+
+	if *date == "" {
+		*date = time.Now().Format("2006-01-02")
+	} else if _, err := time.Parse("2006-01-02", *date); err != nil {
+		return fmt.Errorf("invalid --date %q: expected YYYY-MM-DD", *date)
+	}
+
+	db, err := openDB(config)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	if err := db.SetGoalForDate(*date, goalMinutes); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "🎯 Goal for %s set to %.1f hours\n", *date, float64(goalMinutes)/60.0)
+	return nil
+}
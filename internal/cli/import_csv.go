@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"timeclip/internal/database"
+	"timeclip/internal/models"
+)
+
+// ImportCSV imports daily totals from a CSV file, e.g. an --export or a
+// history from another time tracker.
+//
+// Usage: timeclip --import-csv FILE [--batch-size N] [--overwrite]
+//
+// The first row names the columns. date (YYYY-MM-DD) and active_minutes are
+// required; goal_minutes, pto, logged and notes are optional, and other
+// columns are ignored. Without goal_minutes each day gets the configured
+// goal. Without a logged column days are marked as logged, so old history
+// isn't submitted again by the catch-up sweep. Existing days are kept
+// unless --overwrite is given.
+func ImportCSV(config *models.Config, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("import-csv", flag.ContinueOnError)
+	flags.SetOutput(out)
+	batchSize := flags.Int("batch-size", database.DefaultImportBatchSize, "days written per transaction")
+	overwrite := flags.Bool("overwrite", false, "replace days that already exist")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: timeclip --import-csv FILE [--batch-size N] [--overwrite]")
+	}
+	if *batchSize <= 0 {
+		return fmt.Errorf("--batch-size must be greater than 0")
+	}
+
+	path := flags.Arg(0)
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	entries, err := readImportCSV(file, &config.General)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	db, err := openDB(config)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	started := time.Now()
+	written, err := db.ImportEntries(entries, *batchSize, *overwrite)
+	if err != nil {
+		return fmt.Errorf("import stopped after %d days: %w", written, err)
+	}
+
+	fmt.Fprintf(out, "✅ Imported %d of %d days from %s in %s\n", written, len(entries), path, time.Since(started).Round(time.Millisecond))
+	if skipped := len(entries) - written; skipped > 0 {
+		fmt.Fprintf(out, "   %d days already existed and were kept (use --overwrite to replace them)\n", skipped)
+	}
+	return nil
+}
+
+// readImportCSV parses an import file into entries, checking every row
+// before anything is written
+func readImportCSV(r io.Reader, general *models.GeneralConfig) ([]*models.DailyTimeEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"date", "active_minutes"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing %s column", required)
+		}
+	}
+
+	seen := make(map[string]int)
+	var entries []*models.DailyTimeEntry
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		field := func(name string) (string, bool) {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return "", false
+			}
+			return strings.TrimSpace(record[i]), true
+		}
+
+		date, _ := field("date")
+		day, err := time.ParseInLocation("2006-01-02", date, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid date %q: expected YYYY-MM-DD", line, date)
+		}
+		if first, ok := seen[date]; ok {
+			return nil, fmt.Errorf("line %d: %s already appears on line %d", line, date, first)
+		}
+		seen[date] = line
+
+		entry := &models.DailyTimeEntry{
+			Date:        date,
+			GoalMinutes: general.GoalMinutesFor(day),
+			AutoLogged:  true,
+		}
+
+		active, _ := field("active_minutes")
+		if entry.ActiveMinutes, err = parseImportMinutes(active); err != nil {
+			return nil, fmt.Errorf("line %d: active_minutes: %w", line, err)
+		}
+		if value, ok := field("goal_minutes"); ok && value != "" {
+			if entry.GoalMinutes, err = parseImportMinutes(value); err != nil {
+				return nil, fmt.Errorf("line %d: goal_minutes: %w", line, err)
+			}
+		}
+		if value, ok := field("pto"); ok && value != "" {
+			if entry.IsPTO, err = strconv.ParseBool(value); err != nil {
+				return nil, fmt.Errorf("line %d: pto: expected true or false, got %q", line, value)
+			}
+		}
+		if value, ok := field("logged"); ok && value != "" {
+			if entry.AutoLogged, err = strconv.ParseBool(value); err != nil {
+				return nil, fmt.Errorf("line %d: logged: expected true or false, got %q", line, value)
+			}
+		}
+		entry.Notes, _ = field("notes")
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// parseImportMinutes parses a minute count between 0 and a full day
+func parseImportMinutes(value string) (int, error) {
+	minutes, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("expected a whole number of minutes, got %q", value)
+	}
+	if minutes < 0 || minutes > 24*60 {
+		return 0, fmt.Errorf("%d is not between 0 and %d", minutes, 24*60)
+	}
+	return minutes, nil
+}
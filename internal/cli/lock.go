@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"timeclip/internal/instance"
+	"timeclip/internal/models"
+)
+
+// LockStatus prints who holds the single instance lock, and optionally
+// removes it when it is stale.
+//
+// Usage: timeclip --lock-status [--force-unlock]
+func LockStatus(config *models.Config, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("lock-status", flag.ContinueOnError)
+	flags.SetOutput(out)
+	forceUnlock := flags.Bool("force-unlock", false, "remove the lock file if its process is dead or a zombie")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	lock, err := instance.NewLock(config.DataDir())
+	if err != nil {
+		return fmt.Errorf("failed to create instance lock: %w", err)
+	}
+
+	status, err := lock.Status()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Lock file: %s\n", status.Path)
+	if !status.Exists {
+		fmt.Fprintln(out, "✅ Not locked - no instance is running")
+		return nil
+	}
+
+	fmt.Fprintf(out, "Holder:    %s\n", status.Holder())
+	fmt.Fprintf(out, "Since:     %s\n", status.Since.Format("2006-01-02 15:04:05"))
+
+	switch {
+	case status.IsOrphaned():
+		fmt.Fprintln(out, "⚠️  Locked, but the process that wrote it is gone or a zombie - another process inherited the lock")
+	case status.Held:
+		fmt.Fprintln(out, "🔒 Locked by a running instance")
+	case status.IsZombie():
+		fmt.Fprintf(out, "⚠️  Stale lock - PID %d is a zombie; it exited but its parent hasn't reaped it\n", status.PID)
+	case status.IsStale():
+		fmt.Fprintln(out, "⚠️  Stale lock - the process that wrote it is gone")
+	default:
+		fmt.Fprintln(out, "⚠️  Lock is not held, but the PID now belongs to another process")
+	}
+
+	if !*forceUnlock {
+		if status.IsStale() || status.IsOrphaned() {
+			fmt.Fprintln(out, "Run with --force-unlock to remove it")
+		}
+		return nil
+	}
+
+	if err := lock.ForceUnlock(); err != nil {
+		return err
+	}
+	fmt.Fprintln(out, "🔓 Removed stale lock file")
+	return nil
+}
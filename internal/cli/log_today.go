@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"timeclip/internal/api"
+	"timeclip/internal/models"
+)
+
+// LogToday logs today's time right away, regardless of the auto-log threshold.
+//
+// Usage: timeclip --log-today [--provider magnetic|clockify]
+//
+// Without --provider the preferred provider and fallbacks are tried as usual.
+// With it only that provider is used, which must be enabled and configured;
+// the config is left unchanged.
+func LogToday(config *models.Config, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("log-today", flag.ContinueOnError)
+	flags.SetOutput(out)
+	provider := flags.String("provider", "", "log to this provider only (magnetic or clockify)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	db, err := openDB(config)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	logger := api.NewSimpleAutoLogger(db, config)
+	if *provider == "" {
+		err = logger.LogToday()
+	} else {
+		err = logger.LogTodayTo(*provider)
+	}
+	if err != nil {
+		return err
+	}
+
+	entry, err := db.GetTodayEntry()
+	if err != nil {
+		return fmt.Errorf("failed to load today's entry: %w", err)
+	}
+	if !entry.AutoLogged {
+		fmt.Fprintf(out, "%s was not logged; see the log for details\n", entry.Date)
+		return nil
+	}
+	fmt.Fprintf(out, "✅ Logged %s (%.1fh)\n", entry.Date, float64(entry.ActiveMinutes)/60.0)
+	return nil
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"timeclip/internal/database"
+	"timeclip/internal/models"
+)
+
+// Notes prints or replaces the private notes for a day (today by default).
+// Notes are kept in the database and exports; they are only sent with logged
+// time when api.include_notes is set.
+//
+// Usage: timeclip --notes [--date YYYY-MM-DD] [--clear] [text...]
+func Notes(config *models.Config, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("notes", flag.ContinueOnError)
+	flags.SetOutput(out)
+	date := flags.String("date", time.Now().Format("2006-01-02"), "day to show or edit (YYYY-MM-DD)")
+	clear := flags.Bool("clear", false, "remove the day's notes")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if _, err := time.Parse("2006-01-02", *date); err != nil {
+		return fmt.Errorf("invalid --date %q: expected YYYY-MM-DD", *date)
+	}
+	text := strings.Join(flags.Args(), " ")
+	if *clear && text != "" {
+		return fmt.Errorf("use either --clear or note text, not both")
+	}
+
+	// Only printing doesn't need write access, so it works next to the tracker
+	if !*clear && text == "" {
+		db, err := database.OpenReadOnly(config.DatabasePath())
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		defer db.Close()
+
+		notes, err := db.GetNotes(*date)
+		if err != nil {
+			return err
+		}
+		if notes == "" {
+			fmt.Fprintf(out, "No notes for %s\n", *date)
+			return nil
+		}
+		fmt.Fprintf(out, "📝 %s: %s\n", *date, notes)
+		return nil
+	}
+
+	db, err := openDB(config)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	if err := db.SetNotes(*date, text); err != nil {
+		return err
+	}
+	if *clear {
+		fmt.Fprintf(out, "🗑️  Notes for %s cleared\n", *date)
+		return nil
+	}
+	fmt.Fprintf(out, "📝 Notes for %s saved\n", *date)
+	return nil
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"timeclip/internal/api"
+	"timeclip/internal/models"
+)
+
+// RefreshProjects drops the cached workspace/project listings, fetches them
+// again from every enabled provider and prints them.
+//
+// Usage: timeclip --refresh-projects
+func RefreshProjects(config *models.Config, out io.Writer) error {
+	cache, err := api.NewProjectCache(config)
+	if err != nil {
+		return err
+	}
+	if err := cache.Invalidate(); err != nil {
+		return err
+	}
+
+	clients, err := api.NewFactory().CreateAllEnabledAPIs(config)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(clients))
+	for name := range clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROVIDER\tWORKSPACE\tPROJECT\tID")
+	for _, name := range names {
+		client := clients[name]
+
+		workspaces, err := cache.Workspaces(client)
+		if err != nil {
+			return fmt.Errorf("failed to list %s workspaces: %w", client.Name(), err)
+		}
+
+		for _, workspace := range workspaces {
+			projects, err := cache.Projects(client, workspace.ID)
+			if err != nil {
+				return fmt.Errorf("failed to list %s projects in %s: %w", client.Name(), workspace.Name, err)
+			}
+			for _, project := range projects {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", client.Name(), workspace.Name, project.Name, project.ID)
+			}
+		}
+	}
+	return w.Flush()
+}
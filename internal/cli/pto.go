@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"timeclip/internal/models"
+)
+
+// PTO marks a range of days as PTO, so nothing is tracked or logged on them.
+//
+// Usage: timeclip --pto --from YYYY-MM-DD [--to YYYY-MM-DD] [--clear]
+func PTO(config *models.Config, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("pto", flag.ContinueOnError)
+	flags.SetOutput(out)
+	from := flags.String("from", "", "first day off (YYYY-MM-DD)")
+	to := flags.String("to", "", "last day off (YYYY-MM-DD), defaults to --from")
+	clear := flags.Bool("clear", false, "remove the PTO mark instead of setting it")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *from == "" {
+		return fmt.Errorf("--from is required")
+	}
+	if *to == "" {
+		*to = *from
+	}
+
+	db, err := openDB(config)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	if *clear {
+		if err := db.ClearDateRangePTO(*from, *to); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "🗓️  %s to %s are no longer PTO\n", *from, *to)
+		return nil
+	}
+
+	if err := db.MarkDateRangePTO(*from, *to); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "🌴 %s to %s marked as PTO\n", *from, *to)
+	return nil
+}
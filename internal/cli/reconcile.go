@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"timeclip/internal/api"
+	"timeclip/internal/models"
+)
+
+// Reconcile compares locally logged days with the provider and prints mismatches.
+//
+// Usage: timeclip --reconcile --from YYYY-MM-DD [--to YYYY-MM-DD] [--fix]
+func Reconcile(config *models.Config, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+	flags.SetOutput(out)
+	from := flags.String("from", "", "start date (YYYY-MM-DD)")
+	to := flags.String("to", "", "end date (YYYY-MM-DD), defaults to today")
+	fix := flags.Bool("fix", false, "re-submit days that are logged locally but missing remotely")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *from == "" {
+		return fmt.Errorf("--from is required")
+	}
+	start, end, err := resolveRange(time.Now(), *from, *to, false, false, false, config.General.WeekStartDay())
+	if err != nil {
+		return err
+	}
+
+	db, err := openDB(config)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	discrepancies, err := api.Reconcile(db, config, start, end, *fix)
+	if err != nil {
+		return err
+	}
+
+	if len(discrepancies) == 0 {
+		fmt.Fprintf(out, "✅ No discrepancies between %s and %s\n", start, end)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DATE\tLOCAL\tREMOTE\tISSUE\tFIXED")
+	for _, d := range discrepancies {
+		issue := "logged remotely but not locally"
+		if d.MissingRemotely() {
+			issue = "logged locally but missing remotely"
+		}
+		fmt.Fprintf(w, "%s\t%.1fh\t%.1fh\t%s\t%v\n", d.Date,
+			float64(d.ActiveMinutes)/60.0, float64(d.RemoteMinutes)/60.0, issue, d.Fixed)
+	}
+	return w.Flush()
+}
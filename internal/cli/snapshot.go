@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"timeclip/internal/instance"
+	"timeclip/internal/models"
+	"timeclip/internal/snapshot"
+)
+
+// ExportAll archives the configuration and a database snapshot for moving to another machine.
+//
+// Usage: timeclip --export-all out.tar.gz [--include-keys]
+func ExportAll(config *models.Config, configPath string, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("export-all", flag.ContinueOnError)
+	flags.SetOutput(out)
+	includeKeys := flags.Bool("include-keys", false, "include API keys in the archived config")
+
+	archivePath, err := parseArchiveArgs(flags, args)
+	if err != nil {
+		return err
+	}
+
+	if err := snapshot.Export(config, configPath, archivePath, *includeKeys); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "✅ Exported config and database to %s\n", archivePath)
+	if !*includeKeys {
+		fmt.Fprintf(out, "🔑 API keys were left out; re-enter them after importing (or export with --include-keys)\n")
+	}
+	return nil
+}
+
+// ImportAll restores the configuration and database from an --export-all archive.
+// Timeclip must not be running.
+//
+// Usage: timeclip --import-all in.tar.gz
+func ImportAll(config *models.Config, configPath string, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("import-all", flag.ContinueOnError)
+	flags.SetOutput(out)
+
+	archivePath, err := parseArchiveArgs(flags, args)
+	if err != nil {
+		return err
+	}
+
+	// Refuse to swap the database underneath a running tracker
+	lock, err := instance.NewLock(config.DataDir())
+	if err != nil {
+		return fmt.Errorf("failed to create instance lock: %w", err)
+	}
+	if err := lock.TryLock(); err != nil {
+		return fmt.Errorf("quit Timeclip before importing: %w", err)
+	}
+	defer lock.Release()
+
+	if err := snapshot.Import(archivePath, configPath, config.DatabasePath()); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "✅ Imported config and database from %s (integrity check passed)\n", archivePath)
+	fmt.Fprintf(out, "📁 Previous files were kept with a .before-import suffix\n")
+	return nil
+}
+
+// parseArchiveArgs parses flags that may appear before or after the archive path
+func parseArchiveArgs(flags *flag.FlagSet, args []string) (string, error) {
+	if err := flags.Parse(args); err != nil {
+		return "", err
+	}
+	if flags.NArg() == 0 {
+		return "", fmt.Errorf("an archive path is required")
+	}
+
+	archivePath := flags.Arg(0)
+	if err := flags.Parse(flags.Args()[1:]); err != nil {
+		return "", err
+	}
+	if flags.NArg() > 0 {
+		return "", fmt.Errorf("unexpected arguments: %v", flags.Args())
+	}
+
+	return archivePath, nil
+}
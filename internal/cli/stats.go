@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"timeclip/internal/database"
+	"timeclip/internal/models"
+)
+
+// Stats prints aggregated statistics for a date range.
+//
+// Usage: timeclip --stats [--from YYYY-MM-DD --to YYYY-MM-DD | --week | --month | --year] [--json]
+//
+// The database is opened read-only, so this is safe to run while the tracker is running.
+func Stats(config *models.Config, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("stats", flag.ContinueOnError)
+	flags.SetOutput(out)
+	from := flags.String("from", "", "start date (YYYY-MM-DD)")
+	to := flags.String("to", "", "end date (YYYY-MM-DD), defaults to today")
+	week := flags.Bool("week", false, "show the current week")
+	month := flags.Bool("month", false, "show the current month")
+	year := flags.Bool("year", false, "show the current year")
+	asJSON := flags.Bool("json", false, "print JSON instead of a table")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	start, end, err := resolveRange(time.Now(), *from, *to, *week, *month, *year, config.General.WeekStartDay())
+	if err != nil {
+		return err
+	}
+
+	db, err := database.OpenReadOnly(config.DatabasePath())
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	stats, err := db.GetStatsForDateRange(start, end, config.General.MinTrackedMinutes, config.General.ExcludePTOFromStats)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(stats)
+	}
+
+	return printStatsTable(out, stats)
+}
+
+// resolveRange determines the start and end dates from the stats flags
+func resolveRange(now time.Time, from, to string, week, month, year bool, weekStart time.Weekday) (string, string, error) {
+	const layout = "2006-01-02"
+
+	shorthands := 0
+	for _, set := range []bool{week, month, year} {
+		if set {
+			shorthands++
+		}
+	}
+	if shorthands > 1 || (shorthands == 1 && (from != "" || to != "")) {
+		return "", "", fmt.Errorf("use only one of --from/--to, --week, --month or --year")
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch {
+	case month:
+		start := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location())
+		return start.Format(layout), start.AddDate(0, 1, -1).Format(layout), nil
+	case year:
+		start := time.Date(today.Year(), time.January, 1, 0, 0, 0, 0, today.Location())
+		return start.Format(layout), start.AddDate(1, 0, -1).Format(layout), nil
+	case from != "":
+		if _, err := time.Parse(layout, from); err != nil {
+			return "", "", fmt.Errorf("invalid --from date %q: expected YYYY-MM-DD", from)
+		}
+		end := today.Format(layout)
+		if to != "" {
+			if _, err := time.Parse(layout, to); err != nil {
+				return "", "", fmt.Errorf("invalid --to date %q: expected YYYY-MM-DD", to)
+			}
+			end = to
+		}
+		if end < from {
+			return "", "", fmt.Errorf("--to date must not be before --from date")
+		}
+		return from, end, nil
+	case to != "":
+		return "", "", fmt.Errorf("--to requires --from")
+	default:
+		// Current week, starting on the configured day
+		start := models.StartOfWeek(today, weekStart)
+		return start.Format(layout), start.AddDate(0, 0, 6).Format(layout), nil
+	}
+}
+
+// printStatsTable prints range statistics as an aligned table
+func printStatsTable(out io.Writer, stats *database.RangeStats) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Range:\t%s to %s\n", stats.RangeStart, stats.RangeEnd)
+	fmt.Fprintf(w, "Days tracked:\t%d\n", stats.DaysTracked)
+	fmt.Fprintf(w, "Goal days:\t%d\n", stats.GoalDays)
+	fmt.Fprintf(w, "Total:\t%.1fh\n", stats.TotalHours())
+	fmt.Fprintf(w, "Average:\t%.1fh/day\n", stats.AvgHoursPerDay())
+	if stats.BestDay != nil {
+		fmt.Fprintf(w, "Best day:\t%s (%.1fh)\n", stats.BestDay.Date, float64(stats.BestDay.ActiveMinutes)/60.0)
+	}
+	if stats.WorstDay != nil {
+		fmt.Fprintf(w, "Worst day:\t%s (%.1fh)\n", stats.WorstDay.Date, float64(stats.WorstDay.ActiveMinutes)/60.0)
+	}
+	if stats.MedianGoalTime != "" {
+		fmt.Fprintf(w, "Goal reached:\t%d before noon, %d after\n", stats.GoalsBeforeNoon, stats.GoalsAfterNoon)
+		if median, err := time.Parse("15:04", stats.MedianGoalTime); err == nil {
+			fmt.Fprintf(w, "Insight:\tYou usually hit goal by %s\n", median.Format("3:04 PM"))
+		}
+	}
+
+	return w.Flush()
+}
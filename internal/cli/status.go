@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"timeclip/internal/database"
+	"timeclip/internal/models"
+	"timeclip/internal/version"
+)
+
+// Status prints today's progress and any submissions waiting in the offline queue.
+// It opens the database read-only, so it is safe while Timeclip is running.
+//
+// Usage: timeclip --status
+func Status(config *models.Config, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("status", flag.ContinueOnError)
+	flags.SetOutput(out)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	db, err := database.OpenReadOnly(config.DatabasePath())
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	today := time.Now().Format("2006-01-02")
+	entries, err := db.GetEntriesForDateRange(today, today)
+	if err != nil {
+		return err
+	}
+
+	pending, err := db.GetPendingLogs()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Version:\t%s\n", version.Get())
+	fmt.Fprintf(w, "Today:\t%s\n", today)
+	if len(entries) == 0 {
+		fmt.Fprintf(w, "Active:\tnothing tracked yet\n")
+	} else {
+		entry := entries[0]
+		fmt.Fprintf(w, "Active:\t%.1fh of %.1fh\n", entry.ActiveTime().Hours(), entry.GoalTime().Hours())
+		fmt.Fprintf(w, "Paused:\t%t\n", entry.IsPaused)
+		fmt.Fprintf(w, "Logged:\t%t\n", entry.AutoLogged)
+		if entry.Notes != "" {
+			fmt.Fprintf(w, "Notes:\t%s\n", entry.Notes)
+		}
+	}
+	fmt.Fprintf(w, "Pending logs:\t%d\n", len(pending))
+	if waiting := models.AwaitingApproval(pending, config.API.CatchupConfirmThreshold); waiting > 0 {
+		fmt.Fprintf(w, "Awaiting approval:\t%d days (timeclip --approve-pending)\n", waiting)
+	}
+	for _, item := range pending {
+		fmt.Fprintf(w, "  %s\t%d attempts, next retry %s: %s\n",
+			item.Date, item.Attempts, item.NextAttemptAt.Format("Jan 2 15:04"), item.LastError)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"timeclip/internal/database"
+	"timeclip/internal/models"
+	"timeclip/internal/version"
+)
+
+// Version prints the build information and the database schema version,
+// for including in bug reports.
+//
+// Usage: timeclip --version
+func Version(config *models.Config, out io.Writer) error {
+	fmt.Fprintln(out, version.Get())
+	fmt.Fprintf(out, "Database schema: %s\n", schemaVersion(config))
+	return nil
+}
+
+// schemaVersion describes the schema version of the configured database
+func schemaVersion(config *models.Config) string {
+	db, err := database.OpenReadOnly(config.DatabasePath())
+	if err != nil {
+		return fmt.Sprintf("unknown (%v)", err)
+	}
+	defer db.Close()
+
+	schema, err := db.SchemaVersion()
+	if err != nil {
+		return fmt.Sprintf("unknown (%v)", err)
+	}
+	return fmt.Sprintf("%d", schema)
+}
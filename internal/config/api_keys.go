@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"timeclip/internal/models"
+)
+
+// clockifyKeyPattern matches the usual shape of a Clockify API key
+var clockifyKeyPattern = regexp.MustCompile(`^[A-Za-z0-9+/=_-]{40,64}$`)
+
+// placeholderMarkers are fragments of example values that were never replaced
+var placeholderMarkers = []string{"your-", "your_", "api-key", "api_key", "placeholder", "changeme", "<", ">"}
+
+// checkAPIKeys catches common copy/paste mistakes in API keys before the first
+// request fails. Surrounding whitespace is trimmed with a warning, a Clockify
+// key with an unusual shape is warned about, and leftover placeholder text is
+// returned as an error.
+func checkAPIKeys(config *models.Config) []string {
+	var errors []string
+
+	keys := []struct {
+		section string
+		key     *string
+	}{
+		{"api.magnetic", &config.API.Magnetic.APIKey},
+		{"api.clockify", &config.API.Clockify.APIKey},
+	}
+
+	for _, k := range keys {
+		if trimmed := strings.TrimSpace(*k.key); trimmed != *k.key {
+			log.Printf("⚠️  %s.api_key has leading or trailing whitespace; ignoring it", k.section)
+			*k.key = trimmed
+		}
+		if *k.key == "" {
+			continue
+		}
+
+		if strings.ContainsAny(*k.key, " \t\r\n") {
+			log.Printf("⚠️  %s.api_key contains whitespace; API keys normally don't", k.section)
+		}
+		if isPlaceholderKey(*k.key) {
+			errors = append(errors, fmt.Sprintf("%s.api_key still contains placeholder text (%q); paste your real API key", k.section, *k.key))
+		}
+	}
+
+	clockifyKey := config.API.Clockify.APIKey
+	if clockifyKey != "" && !isPlaceholderKey(clockifyKey) && !clockifyKeyPattern.MatchString(clockifyKey) {
+		log.Printf("⚠️  api.clockify.api_key doesn't look like a Clockify API key (%d characters); check it isn't another provider's key", len(clockifyKey))
+	}
+
+	return errors
+}
+
+// isPlaceholderKey returns true if key looks like an unreplaced example value
+func isPlaceholderKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, marker := range placeholderMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return strings.Trim(lower, "x") == ""
+}
@@ -1,19 +1,26 @@
 package config
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/pelletier/go-toml/v2"
+	"timeclip/internal/database"
 	"timeclip/internal/models"
+	"timeclip/internal/notify"
 )
 
 // Manager handles configuration loading, validation, and generation
 type Manager struct {
 	config     *models.Config
 	configPath string
+	strict     bool // Treat unknown config keys as errors instead of warnings
 }
 
 // NewManager creates a new configuration manager
@@ -21,6 +28,12 @@ func NewManager() *Manager {
 	return &Manager{}
 }
 
+// SetStrict controls whether unknown keys in the config file are fatal.
+// When false (the default) they are logged as warnings.
+func (m *Manager) SetStrict(strict bool) {
+	m.strict = strict
+}
+
 // Load loads the configuration from the default or specified path
 func (m *Manager) Load(configPath ...string) (*models.Config, error) {
 	var path string
@@ -53,9 +66,24 @@ func (m *Manager) loadFromFile(path string) (*models.Config, error) {
 		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
 	}
 
+	// Decode over the defaults: keys and whole sections missing from the file
+	// keep their default values, while arrays and maps in the file replace them
 	config := models.DefaultConfig()
-	if err := toml.Unmarshal(data, config); err != nil {
-		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	decoder := toml.NewDecoder(bytes.NewReader(data)).DisallowUnknownFields()
+	if err := decoder.Decode(config); err != nil {
+		var strictErr *toml.StrictMissingError
+		if !errors.As(err, &strictErr) {
+			return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+
+		// Known keys are still decoded; only the unknown ones are reported
+		unknownKeys := describeUnknownKeys(strictErr)
+		if m.strict {
+			return nil, fmt.Errorf("unknown keys in config file %s:\n  - %s", path, strings.Join(unknownKeys, "\n  - "))
+		}
+		for _, key := range unknownKeys {
+			log.Printf("⚠️  Ignoring unknown config key in %s: %s", path, key)
+		}
 	}
 
 	// Validate the loaded configuration
@@ -67,6 +95,16 @@ func (m *Manager) loadFromFile(path string) (*models.Config, error) {
 	return config, nil
 }
 
+// describeUnknownKeys formats each unknown key with its location in the file
+func describeUnknownKeys(strictErr *toml.StrictMissingError) []string {
+	keys := make([]string, 0, len(strictErr.Errors))
+	for _, decodeErr := range strictErr.Errors {
+		row, column := decodeErr.Position()
+		keys = append(keys, fmt.Sprintf("%s (line %d, column %d)", strings.Join(decodeErr.Key(), "."), row, column))
+	}
+	return keys
+}
+
 // generateDefaultConfig creates a default configuration file and prompts user
 func (m *Manager) generateDefaultConfig(path string) (*models.Config, error) {
 	// Create config directory if it doesn't exist
@@ -117,13 +155,70 @@ func (m *Manager) validateConfig(config *models.Config) error {
 
 	// Validate general settings
 	if config.General.GoalTimeHours <= 0 {
-		errors = append(errors, "goal_time_hours must be greater than 0")
+		errors = append(errors, "general.goal_time_hours must be greater than 0")
+	}
+	if config.General.WeeklyGoalHours < 0 || config.General.WeeklyGoalHours > 168 {
+		errors = append(errors, "general.weekly_goal_hours must be between 0 and 168")
+	}
+	if config.General.MinGoalHours < 0 {
+		errors = append(errors, "general.min_goal_hours cannot be negative")
+	} else if config.General.MinGoalHours > float64(config.General.GoalTimeHours) {
+		errors = append(errors, "general.min_goal_hours cannot exceed general.goal_time_hours")
 	}
 	if config.General.AutoLogThresholdHours <= 0 {
-		errors = append(errors, "auto_log_threshold_hours must be greater than 0")
+		errors = append(errors, "general.auto_log_threshold_hours must be greater than 0")
 	}
 	if config.General.CheckIntervalSeconds < 10 {
-		errors = append(errors, "check_interval_seconds must be at least 10 seconds")
+		errors = append(errors, "general.check_interval_seconds must be at least 10 seconds")
+	}
+	if config.General.IdleThresholdSeconds < 0 {
+		errors = append(errors, "general.idle_threshold_seconds cannot be negative")
+	}
+	if config.General.PaceAlertRatio < 0 || config.General.PaceAlertRatio > 1 {
+		errors = append(errors, "general.pace_alert_ratio must be between 0 and 1")
+	} else if config.General.PaceAlertRatio > 0 {
+		if _, ok := config.General.WorkWindow(); !ok {
+			errors = append(errors, "general.pace_alert_ratio requires general.work_start and general.work_end")
+		}
+	}
+	switch config.General.OnUnknownState {
+	case "", "active", "inactive", "last_known":
+	default:
+		errors = append(errors, fmt.Sprintf("general.on_unknown_state must be active, inactive or last_known, got %q", config.General.OnUnknownState))
+	}
+	if config.General.MinTrackedMinutes < 0 {
+		errors = append(errors, "general.min_tracked_minutes cannot be negative")
+	}
+	switch strings.ToLower(config.General.WeekStart) {
+	case "", "monday", "sunday":
+	default:
+		errors = append(errors, fmt.Sprintf("general.week_start must be monday or sunday, got %q", config.General.WeekStart))
+	}
+	if config.General.StartupLockWaitSeconds < 0 {
+		errors = append(errors, "general.startup_lock_wait_seconds cannot be negative")
+	}
+	switch config.General.ActivityMode {
+	case "", "presence", "input":
+	default:
+		errors = append(errors, fmt.Sprintf("general.activity_mode must be presence or input, got %q", config.General.ActivityMode))
+	}
+	if config.General.PauseOnNetworkLoss && config.General.NetworkProbeHost == "" {
+		errors = append(errors, "general.pause_on_network_loss requires general.network_probe_host")
+	}
+	if config.General.PostWakeWarmupSeconds < 0 {
+		errors = append(errors, "general.post_wake_warmup_seconds cannot be negative")
+	}
+	if config.General.LongPauseMinutes < 0 {
+		errors = append(errors, "general.long_pause_minutes cannot be negative")
+	}
+	if config.General.HeartbeatMinutes < 0 {
+		errors = append(errors, "general.heartbeat_minutes cannot be negative")
+	}
+	if config.General.IdleIsPrimary && config.General.IdleThresholdSeconds <= 0 {
+		errors = append(errors, "general.idle_is_primary requires general.idle_threshold_seconds to be greater than 0")
+	}
+	if config.General.EventMinIntervalSeconds < 0 {
+		errors = append(errors, "general.event_min_interval_seconds cannot be negative")
 	}
 
 	// Validate track days
@@ -133,34 +228,151 @@ func (m *Manager) validateConfig(config *models.Config) error {
 	}
 	for _, day := range config.General.TrackDays {
 		if !validDays[strings.ToLower(day)] {
-			errors = append(errors, fmt.Sprintf("invalid track day: %s", day))
+			errors = append(errors, fmt.Sprintf("general.track_days: invalid track day: %s", day))
 		}
 	}
 
+	// Validate the weekly goal distribution
+	switch strings.ToLower(config.General.Distribution) {
+	case "", models.DistributionEven, models.DistributionFrontloaded:
+	case models.DistributionCustom:
+		errors = append(errors, validateDistributionWeights(&config.General, validDays)...)
+	default:
+		errors = append(errors, fmt.Sprintf("general.distribution must be even, frontloaded or custom, got %q", config.General.Distribution))
+	}
+
+	// Validate work and break windows
+	if workWindow, ok := config.General.WorkWindow(); ok {
+		if _, _, err := workWindow.Bounds(); err != nil {
+			errors = append(errors, fmt.Sprintf("general.work_start/general.work_end: %v", err))
+		}
+	}
+	for i, breakWindow := range config.General.Breaks {
+		if _, _, err := breakWindow.Bounds(); err != nil {
+			errors = append(errors, fmt.Sprintf("general.breaks[%d]: %v", i, err))
+		}
+	}
+	switch config.API.LogBasis {
+	case "", models.LogBasisDaily:
+	case models.LogBasisWeekly:
+		if config.API.LogOnQuit {
+			errors = append(errors, "api.log_on_quit cannot be used with api.log_basis = weekly")
+		}
+	default:
+		errors = append(errors, fmt.Sprintf("api.log_basis must be daily or weekly, got %q", config.API.LogBasis))
+	}
+	if config.API.SplitAtLunch && len(config.General.Breaks) == 0 {
+		errors = append(errors, "api.split_at_lunch requires at least one general.breaks window")
+	}
+
 	// Validate API configuration
 	if config.API.PreferredProvider != "magnetic" && config.API.PreferredProvider != "clockify" {
-		errors = append(errors, "preferred_provider must be either 'magnetic' or 'clockify'")
+		errors = append(errors, "api.preferred_provider must be either 'magnetic' or 'clockify'")
 	}
 
+	if config.API.Magnetic.TimeoutSeconds < 0 {
+		errors = append(errors, "api.magnetic.timeout_seconds cannot be negative")
+	}
+	if config.API.Magnetic.RetryAttempts < 0 {
+		errors = append(errors, "api.magnetic.retry_attempts cannot be negative")
+	}
+	if config.API.Clockify.TimeoutSeconds < 0 {
+		errors = append(errors, "api.clockify.timeout_seconds cannot be negative")
+	}
+	if config.API.Clockify.RetryAttempts < 0 {
+		errors = append(errors, "api.clockify.retry_attempts cannot be negative")
+	}
+	if config.API.MaxDailyAttempts < 0 {
+		errors = append(errors, "api.max_daily_attempts cannot be negative")
+	}
+	if config.API.CacheTTLMinutes < 0 {
+		errors = append(errors, "api.cache_ttl_minutes cannot be negative")
+	}
+	if config.API.QueueRetryMinutes < 0 {
+		errors = append(errors, "api.queue_retry_minutes cannot be negative")
+	}
+	if config.API.MaxBacklogDays < 0 {
+		errors = append(errors, "api.max_backlog_days cannot be negative")
+	}
+	if config.API.CatchupConfirmThreshold < 0 {
+		errors = append(errors, "api.catchup_confirm_threshold cannot be negative")
+	}
+	if config.API.MinAttemptIntervalSeconds < 0 {
+		errors = append(errors, "api.min_attempt_interval_seconds cannot be negative")
+	}
+	if config.API.AutoLogThresholdRatio < 0 || config.API.AutoLogThresholdRatio > 1 {
+		errors = append(errors, "api.auto_log_threshold_ratio must be between 0 and 1")
+	}
+	if config.API.StartupCheckTimeoutSeconds < 0 {
+		errors = append(errors, "api.startup_check_timeout_seconds cannot be negative")
+	}
+	if config.API.MinGoalRatioToLog < 0 || config.API.MinGoalRatioToLog > 1 {
+		errors = append(errors, "api.min_goal_ratio_to_log must be between 0 and 1")
+	}
+	if config.API.LogOnQuitMinMinutes < 0 {
+		errors = append(errors, "api.log_on_quit_min_minutes cannot be negative")
+	}
+	if config.API.RoundLoggedMinutes < 0 || config.API.RoundLoggedMinutes > 60 {
+		errors = append(errors, "api.round_logged_minutes must be between 0 and 60")
+	}
+
+	errors = append(errors, checkAPIKeys(config)...)
+
 	// Check that at least one API is enabled and configured
 	magneticEnabled := config.API.Magnetic.Enabled && config.API.Magnetic.APIKey != ""
 	clockifyEnabled := config.API.Clockify.Enabled && config.API.Clockify.APIKey != ""
 
 	if !magneticEnabled && !clockifyEnabled {
-		errors = append(errors, "at least one API must be enabled with a valid API key")
+		errors = append(errors, "api: at least one of api.magnetic or api.clockify must be enabled with an api_key")
 	}
 
 	// Validate preferred provider is actually enabled
 	if config.API.PreferredProvider == "magnetic" && !magneticEnabled {
-		errors = append(errors, "magnetic is set as preferred provider but is not properly configured")
+		errors = append(errors, "api.preferred_provider is magnetic but api.magnetic is not enabled with an api_key")
 	}
 	if config.API.PreferredProvider == "clockify" && !clockifyEnabled {
-		errors = append(errors, "clockify is set as preferred provider but is not properly configured")
+		errors = append(errors, "api.preferred_provider is clockify but api.clockify is not enabled with an api_key")
+	}
+
+	// Validate Clockify custom fields
+	for fieldID := range config.API.Clockify.CustomFields {
+		if strings.TrimSpace(fieldID) == "" {
+			errors = append(errors, "api.clockify.custom_fields cannot contain an empty field ID")
+		}
+	}
+
+	// Validate 2xx error body fields
+	if slices.Contains(config.API.Magnetic.ErrorFields, "") {
+		errors = append(errors, "api.magnetic.error_fields cannot contain an empty name")
+	}
+	if slices.Contains(config.API.Clockify.ErrorFields, "") {
+		errors = append(errors, "api.clockify.error_fields cannot contain an empty name")
+	}
+
+	// Validate notification sound
+	if sound := config.UI.NotificationSound; sound != "" && sound != notify.NoSound && !slices.Contains(notify.Sounds(), sound) {
+		errors = append(errors, fmt.Sprintf("ui.notification_sound must be \"none\" or one of: %s", strings.Join(notify.Sounds(), ", ")))
+	}
+
+	switch config.UI.GoalBasis {
+	case "", models.GoalBasisDaily, models.GoalBasisWeekly:
+	default:
+		errors = append(errors, fmt.Sprintf("ui.goal_basis must be daily or weekly, got %q", config.UI.GoalBasis))
+	}
+
+	if config.Database.OpenRetries < 0 {
+		errors = append(errors, "database.open_retries cannot be negative")
+	}
+	if config.Database.OpenRetryDelaySeconds < 0 {
+		errors = append(errors, "database.open_retry_delay_seconds cannot be negative")
+	}
+	if config.Database.CommitIntervalMinutes < 0 {
+		errors = append(errors, "database.commit_interval_minutes cannot be negative")
 	}
 
 	// Validate database path
-	if config.Database.Path == "" {
-		errors = append(errors, "database path cannot be empty")
+	if location, synced := database.SyncedLocation(config.DatabasePath()); synced && !config.Database.AllowNetworkPath {
+		errors = append(errors, fmt.Sprintf("database.path is synced by %s, which can corrupt the database; move it or set database.allow_network_path = true", location))
 	}
 
 	if len(errors) > 0 {
@@ -170,8 +382,50 @@ func (m *Manager) validateConfig(config *models.Config) error {
 	return nil
 }
 
-// getDefaultConfigPath returns the default configuration file path
+// validateDistributionWeights checks general.distribution_weights for the
+// custom distribution: known day names, no negative weights, and some weight
+// on the track days so the weekly goal can be split at all
+func validateDistributionWeights(general *models.GeneralConfig, validDays map[string]bool) []string {
+	var errors []string
+
+	trackDays := make(map[string]bool)
+	for _, day := range general.TrackDays {
+		trackDays[strings.ToLower(day)] = true
+	}
+
+	total := 0.0
+	for day, weight := range general.DistributionWeights {
+		if !validDays[strings.ToLower(day)] {
+			errors = append(errors, fmt.Sprintf("general.distribution_weights: invalid day: %s", day))
+			continue
+		}
+		if weight < 0 {
+			errors = append(errors, fmt.Sprintf("general.distribution_weights.%s cannot be negative", day))
+			continue
+		}
+		if trackDays[strings.ToLower(day)] {
+			total += weight
+		}
+	}
+	if total <= 0 {
+		errors = append(errors, "general.distribution = \"custom\" requires general.distribution_weights greater than 0 for at least one track day")
+	}
+
+	return errors
+}
+
+// getDefaultConfigPath returns the default configuration file path. It is
+// in $TIMECLIP_HOME when set; general.data_dir can't move it, since it is
+// read from this file.
 func (m *Manager) getDefaultConfigPath() (string, error) {
+	if dir := os.Getenv(models.DataDirEnv); dir != "" {
+		dir, err := database.ExpandPath(dir)
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, "config.toml"), nil
+	}
+
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", fmt.Errorf("failed to get user home directory: %w", err)
@@ -0,0 +1,215 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"timeclip/internal/models"
+)
+
+// providerTOML enables Magnetic, the least a config needs to validate
+const providerTOML = "\n[api.magnetic]\nenabled = true\napi_key = \"key\"\n"
+
+// withProvider returns the defaults with Magnetic enabled as in providerTOML
+func withProvider() *models.Config {
+	config := models.DefaultConfig()
+	config.API.Magnetic.Enabled = true
+	config.API.Magnetic.APIKey = "key"
+	return config
+}
+
+// loadTOML writes data plus providerTOML to a temp config file and loads it
+func loadTOML(t *testing.T, data string) *models.Config {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(data+providerTOML), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	config, err := NewManager().Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return config
+}
+
+func TestLoadPartialConfigKeepsDefaults(t *testing.T) {
+	tests := []struct {
+		name string
+		toml string
+		want func(*models.Config)
+	}{
+		{
+			name: "provider only",
+			toml: "",
+			want: func(*models.Config) {},
+		},
+		{
+			name: "no ui section",
+			toml: "[general]\ngoal_time_hours = 7\n",
+			want: func(c *models.Config) { c.General.GoalTimeHours = 7 },
+		},
+		{
+			name: "one nested provider key",
+			toml: "[api.clockify]\nworkspace_id = \"ws-1\"\n",
+			want: func(c *models.Config) { c.API.Clockify.WorkspaceID = "ws-1" },
+		},
+		{
+			name: "ui key only",
+			toml: "[ui]\nshow_net_active = false\n",
+			want: func(c *models.Config) { c.UI.ShowNetActive = false },
+		},
+		{
+			name: "array replaces the default",
+			toml: "[general]\ntrack_days = [\"monday\", \"wednesday\"]\n",
+			want: func(c *models.Config) { c.General.TrackDays = []string{"monday", "wednesday"} },
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			want := withProvider()
+			tc.want(want)
+
+			got := loadTOML(t, tc.toml)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("loaded config differs from the defaults:\n got  %+v\n want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	manager := NewManager()
+	if err := manager.saveToFile(withProvider(), path); err != nil {
+		t.Fatalf("saveToFile: %v", err)
+	}
+
+	loaded, err := manager.Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, withProvider()) {
+		t.Errorf("round trip changed the config:\n got  %+v\n want %+v", loaded, withProvider())
+	}
+
+	// Saving what was loaded must give the same file again
+	first, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if err := manager.SaveConfig(loaded); err != nil {
+		t.Fatalf("SaveConfig: %v", err)
+	}
+	second, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(first) != string(second) {
+		t.Errorf("second save differs from the first:\n%s\n---\n%s", first, second)
+	}
+}
+
+func TestGetDefaultConfigMatchesModels(t *testing.T) {
+	if !reflect.DeepEqual(GetDefaultConfig(), models.DefaultConfig()) {
+		t.Error("GetDefaultConfig differs from models.DefaultConfig")
+	}
+}
+
+func TestLoadStrictRejectsUnknownKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte("[ui]\nshow_net_actve = false\n"+providerTOML), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if _, err := NewManager().Load(path); err != nil {
+		t.Errorf("non-strict Load: %v, want the unknown key ignored", err)
+	}
+
+	manager := NewManager()
+	manager.SetStrict(true)
+	if _, err := manager.Load(path); err == nil {
+		t.Error("strict Load succeeded, want an unknown key error")
+	}
+}
+
+// validate applies change to withProvider and validates the result
+func validate(change func(*models.Config)) error {
+	config := withProvider()
+	change(config)
+	return NewManager().validateConfig(config)
+}
+
+func TestValidateMinGoalHours(t *testing.T) {
+	tests := []struct {
+		hours   float64
+		wantErr string
+	}{
+		{0, ""},
+		{6, ""},
+		{8, ""},
+		{-1, "general.min_goal_hours cannot be negative"},
+		{8.5, "general.min_goal_hours cannot exceed general.goal_time_hours"},
+	}
+
+	for _, tc := range tests {
+		err := validate(func(c *models.Config) {
+			c.General.GoalTimeHours = 8
+			c.General.MinGoalHours = tc.hours
+		})
+		switch {
+		case tc.wantErr == "" && err != nil:
+			t.Errorf("min_goal_hours = %v: %v, want valid", tc.hours, err)
+		case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+			t.Errorf("min_goal_hours = %v: %v, want %q", tc.hours, err, tc.wantErr)
+		}
+	}
+}
+
+func TestValidateAutoLogThresholdRatio(t *testing.T) {
+	for _, ratio := range []float64{0, 0.5, 1} {
+		if err := validate(func(c *models.Config) { c.API.AutoLogThresholdRatio = ratio }); err != nil {
+			t.Errorf("auto_log_threshold_ratio = %v: %v, want valid", ratio, err)
+		}
+	}
+	for _, ratio := range []float64{-0.1, 1.5} {
+		err := validate(func(c *models.Config) { c.API.AutoLogThresholdRatio = ratio })
+		if err == nil || !strings.Contains(err.Error(), "api.auto_log_threshold_ratio must be between 0 and 1") {
+			t.Errorf("auto_log_threshold_ratio = %v: %v, want a range error", ratio, err)
+		}
+	}
+}
+
+func TestValidateWeekStart(t *testing.T) {
+	for _, value := range []string{"", "monday", "Sunday"} {
+		if err := validate(func(c *models.Config) { c.General.WeekStart = value }); err != nil {
+			t.Errorf("week_start = %q: %v, want valid", value, err)
+		}
+	}
+	err := validate(func(c *models.Config) { c.General.WeekStart = "saturday" })
+	if err == nil || !strings.Contains(err.Error(), "general.week_start must be monday or sunday") {
+		t.Errorf("week_start = saturday: %v, want an error", err)
+	}
+}
+
+func TestValidateWeeklyLogBasis(t *testing.T) {
+	if err := validate(func(c *models.Config) { c.API.LogBasis = models.LogBasisWeekly }); err != nil {
+		t.Errorf("log_basis = weekly: %v, want valid", err)
+	}
+
+	err := validate(func(c *models.Config) {
+		c.API.LogBasis = models.LogBasisWeekly
+		c.API.LogOnQuit = true
+	})
+	if err == nil || !strings.Contains(err.Error(), "api.log_on_quit cannot be used with api.log_basis = weekly") {
+		t.Errorf("weekly basis with log_on_quit: %v, want an error", err)
+	}
+
+	err = validate(func(c *models.Config) { c.API.LogBasis = "monthly" })
+	if err == nil || !strings.Contains(err.Error(), "api.log_basis must be daily or weekly") {
+		t.Errorf("log_basis = monthly: %v, want an error", err)
+	}
+}
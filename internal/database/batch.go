@@ -0,0 +1,194 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"timeclip/internal/models"
+)
+
+// pendingMinutes holds credited minutes that haven't been written yet when
+// database.commit_interval_minutes is set
+type pendingMinutes struct {
+	mu        sync.Mutex
+	interval  time.Duration
+	minutes   map[string]int       // Minutes per date not yet in daily_time
+	reachedAt map[string]time.Time // When the running total crossed the goal, per date
+	lastFlush time.Time
+}
+
+// SetCommitInterval switches increments to batch mode: minutes are kept in
+// memory and written every interval, or earlier by Flush. Reads of single
+// days and the weekly/monthly stats include the pending minutes. A crash
+// loses at most one interval. Zero or less writes every minute at once.
+func (db *DB) SetCommitInterval(interval time.Duration) error {
+	if interval <= 0 {
+		if err := db.Flush(); err != nil {
+			return err
+		}
+		db.batch = nil
+		return nil
+	}
+
+	db.batch = &pendingMinutes{
+		interval:  interval,
+		minutes:   make(map[string]int),
+		reachedAt: make(map[string]time.Time),
+		lastFlush: time.Now(),
+	}
+	log.Printf("Committing active time every %v", interval)
+	return nil
+}
+
+// queueMinute adds a minute for entry's date to the pending minutes and
+// flushes once the commit interval has passed. entry must include the
+// minutes already pending.
+func (db *DB) queueMinute(entry *models.DailyTimeEntry) error {
+	b := db.batch
+	b.mu.Lock()
+	b.minutes[entry.Date]++
+	if entry.HasGoal() && entry.ActiveMinutes < entry.GoalMinutes && entry.ActiveMinutes+1 >= entry.GoalMinutes {
+		b.reachedAt[entry.Date] = time.Now()
+	}
+	due := time.Since(b.lastFlush) >= b.interval
+	b.mu.Unlock()
+
+	if due {
+		return db.Flush()
+	}
+	return nil
+}
+
+// Flush writes the pending minutes in one transaction. It does nothing when
+// batch mode is off or nothing is pending. On error the minutes stay pending
+// and are retried with the next flush.
+func (db *DB) Flush() error {
+	b := db.batch
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.minutes) == 0 {
+		b.lastFlush = time.Now()
+		return nil
+	}
+
+	err := db.withTx(func(tx *sql.Tx) error {
+		for date, minutes := range b.minutes {
+			// The minutes were credited while unpaused, so they are kept even
+			// if the day was paused since
+			result, err := tx.Exec(`
+			UPDATE daily_time
+			SET active_minutes = active_minutes + ?,
+			    updated_at = CURRENT_TIMESTAMP
+			WHERE date = ?`, minutes, date)
+			if err != nil {
+				return fmt.Errorf("failed to write pending minutes for %s: %w", date, err)
+			}
+			if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+				log.Printf("⚠️  Dropping %d pending minutes for %s: the day no longer exists", minutes, date)
+				continue
+			}
+
+			// Without a recorded crossing (e.g. the goal was lowered) the
+			// flush time is used, as an unbatched increment would
+			var reachedAt interface{}
+			if at, ok := b.reachedAt[date]; ok {
+				reachedAt = at.UTC().Format("2006-01-02 15:04:05")
+			}
+			if _, err := tx.Exec(`
+			UPDATE daily_time
+			SET goal_reached_at = COALESCE(?, CURRENT_TIMESTAMP)
+			WHERE date = ? AND goal_reached_at IS NULL AND goal_minutes > 0 AND active_minutes >= goal_minutes`,
+				reachedAt, date); err != nil {
+				return fmt.Errorf("failed to record goal reached time for %s: %w", date, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to flush active time: %w", err)
+	}
+
+	b.minutes = make(map[string]int)
+	b.reachedAt = make(map[string]time.Time)
+	b.lastFlush = time.Now()
+	return nil
+}
+
+// pendingFor returns the minutes pending for date
+func (db *DB) pendingFor(date string) int {
+	b := db.batch
+	if b == nil {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.minutes[date]
+}
+
+// withPending adds the pending minutes to entries read from daily_time
+func (db *DB) withPending(entries ...*models.DailyTimeEntry) {
+	if db.batch == nil {
+		return
+	}
+	for _, entry := range entries {
+		entry.ActiveMinutes += db.pendingFor(entry.Date)
+	}
+}
+
+// addPendingToStats folds the minutes pending for dates between start and
+// end into stats aggregated from the stored rows, the same way the weekly
+// and monthly queries count them
+func (db *DB) addPendingToStats(start, end string, minTrackedMinutes int, excludePTO bool,
+	daysTracked, totalMinutes *int, avgMinutesPerDay *float64, goalDays *int) error {
+	if db.batch == nil {
+		return nil
+	}
+
+	db.batch.mu.Lock()
+	pending := make(map[string]int, len(db.batch.minutes))
+	for date, minutes := range db.batch.minutes {
+		if date >= start && date <= end {
+			pending[date] = minutes
+		}
+	}
+	db.batch.mu.Unlock()
+
+	trackedMinutes := *avgMinutesPerDay * float64(*daysTracked)
+	for date, minutes := range pending {
+		var stored, goal int
+		var pto bool
+		err := db.conn.QueryRow(`SELECT active_minutes, goal_minutes, pto FROM daily_time WHERE date = ?`, date).
+			Scan(&stored, &goal, &pto)
+		if err == sql.ErrNoRows || (excludePTO && pto) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", date, err)
+		}
+
+		*totalMinutes += minutes
+		switch {
+		case stored >= minTrackedMinutes:
+			trackedMinutes += float64(minutes)
+		case stored+minutes >= minTrackedMinutes:
+			*daysTracked++
+			trackedMinutes += float64(stored + minutes)
+		}
+		if goal > 0 && stored < goal && stored+minutes >= goal {
+			*goalDays++
+		}
+	}
+
+	if *daysTracked > 0 {
+		*avgMinutesPerDay = trackedMinutes / float64(*daysTracked)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBatchedMinutesAreReadBeforeFlush(t *testing.T) {
+	db := newTestDB(t)
+	db.SetGoalFunc(func(time.Time) int { return 3 })
+	if err := db.SetCommitInterval(time.Hour); err != nil {
+		t.Fatalf("SetCommitInterval: %v", err)
+	}
+
+	const date = "2024-05-06"
+	creditMinutes(t, db, date, 4)
+
+	if got := storedMinutes(t, db, date); got != 0 {
+		t.Errorf("stored minutes = %d before a flush, want 0", got)
+	}
+	entry, err := db.GetEntryForDate(date)
+	if err != nil {
+		t.Fatalf("GetEntryForDate: %v", err)
+	}
+	if entry.ActiveMinutes != 4 {
+		t.Errorf("GetEntryForDate = %d minutes, want the 4 pending", entry.ActiveMinutes)
+	}
+	entries, err := db.GetEntriesForDateRange(date, date)
+	if err != nil {
+		t.Fatalf("GetEntriesForDateRange: %v", err)
+	}
+	if len(entries) != 1 || entries[0].ActiveMinutes != 4 {
+		t.Errorf("GetEntriesForDateRange = %+v, want 4 pending minutes", entries)
+	}
+
+	if err := db.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if got := storedMinutes(t, db, date); got != 4 {
+		t.Errorf("stored minutes = %d after a flush, want 4", got)
+	}
+	if got := db.pendingFor(date); got != 0 {
+		t.Errorf("pending = %d after a flush, want 0", got)
+	}
+	times, err := db.GetGoalReachedTimes(date, date, false)
+	if err != nil {
+		t.Fatalf("GetGoalReachedTimes: %v", err)
+	}
+	if len(times) != 1 {
+		t.Errorf("goal reached times = %v, want the crossing recorded", times)
+	}
+}
+
+func TestBatchedWeeklyStatsIncludePending(t *testing.T) {
+	db := newTestDB(t)
+	db.SetGoalFunc(func(time.Time) int { return 480 })
+	if err := db.SetCommitInterval(time.Hour); err != nil {
+		t.Fatalf("SetCommitInterval: %v", err)
+	}
+
+	today := time.Now().Format("2006-01-02")
+	creditMinutes(t, db, today, 3)
+
+	stats, err := db.GetWeeklyStats(2, false, time.Monday)
+	if err != nil {
+		t.Fatalf("GetWeeklyStats: %v", err)
+	}
+	if stats.TotalMinutes != 3 || stats.DaysTracked != 1 || stats.AvgMinutesPerDay != 3 {
+		t.Errorf("weekly stats = %+v, want the 3 pending minutes as one tracked day", stats)
+	}
+}
+
+func TestAutoLogCandidatesFlushFirst(t *testing.T) {
+	db := newTestDB(t)
+	if err := db.SetCommitInterval(time.Hour); err != nil {
+		t.Fatalf("SetCommitInterval: %v", err)
+	}
+
+	const date = "2024-05-06"
+	creditMinutes(t, db, date, 5)
+
+	entries, err := db.GetEntriesNeedingAutoLog(5, "")
+	if err != nil {
+		t.Fatalf("GetEntriesNeedingAutoLog: %v", err)
+	}
+	if len(entries) != 1 || entries[0].ActiveMinutes != 5 {
+		t.Errorf("GetEntriesNeedingAutoLog = %+v, want the day with all 5 minutes", entries)
+	}
+	if got := storedMinutes(t, db, date); got != 5 {
+		t.Errorf("stored minutes = %d, want the pending minutes flushed", got)
+	}
+}
+
+func TestSetCommitIntervalOffFlushes(t *testing.T) {
+	db := newTestDB(t)
+	if err := db.SetCommitInterval(time.Hour); err != nil {
+		t.Fatalf("SetCommitInterval: %v", err)
+	}
+
+	const date = "2024-05-06"
+	creditMinutes(t, db, date, 2)
+	if err := db.SetCommitInterval(0); err != nil {
+		t.Fatalf("SetCommitInterval(0): %v", err)
+	}
+	if got := storedMinutes(t, db, date); got != 2 {
+		t.Errorf("stored minutes = %d, want the pending minutes written", got)
+	}
+
+	// Back to writing every minute
+	creditMinutes(t, db, date, 1)
+	if got := storedMinutes(t, db, date); got != 3 {
+		t.Errorf("stored minutes = %d, want 3 without batching", got)
+	}
+}
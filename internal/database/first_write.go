@@ -0,0 +1,34 @@
+package database
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// firstWriteConfirmedFile is created next to the database once the first
+// live submission has been approved (api.confirm_first_write). Deleting it
+// asks again.
+const firstWriteConfirmedFile = "first_write_confirmed"
+
+// firstWriteConfirmedPath returns the location of the approval sentinel file
+func (db *DB) firstWriteConfirmedPath() string {
+	return filepath.Join(filepath.Dir(db.dbPath), firstWriteConfirmedFile)
+}
+
+// IsFirstWriteConfirmed returns true if the first live submission has been approved
+func (db *DB) IsFirstWriteConfirmed() bool {
+	_, err := os.Stat(db.firstWriteConfirmedPath())
+	return err == nil
+}
+
+// SetFirstWriteConfirmed records that the first live submission was approved
+func (db *DB) SetFirstWriteConfirmed() error {
+	path := db.firstWriteConfirmedPath()
+	if err := os.WriteFile(path, []byte("First write to a provider approved from Timeclip\n"), 0644); err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+
+	db.LogSystemEvent("first_write_confirmed", "")
+	return nil
+}
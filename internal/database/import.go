@@ -0,0 +1,75 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"timeclip/internal/models"
+)
+
+// DefaultImportBatchSize is how many days ImportEntries writes per transaction
+// when no batch size is given
+const DefaultImportBatchSize = 500
+
+// ImportEntries writes days from another tool or an export. Rows go through one
+// prepared statement and are committed every batchSize rows, which is much
+// faster than committing each row on its own. Dates that already exist are
+// skipped unless overwrite is set. It returns how many days were written; after
+// an error that is the days in the batches committed before it.
+//
+// Only the date, active and goal minutes, PTO, logged and notes fields of
+// each entry are used.
+func (db *DB) ImportEntries(entries []*models.DailyTimeEntry, batchSize int, overwrite bool) (int, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultImportBatchSize
+	}
+
+	query := `
+	INSERT INTO daily_time (date, active_minutes, goal_minutes, pto, auto_logged, notes)
+	VALUES (?, ?, ?, ?, ?, ?)
+	ON CONFLICT(date) DO NOTHING`
+	if overwrite {
+		query = `
+	INSERT INTO daily_time (date, active_minutes, goal_minutes, pto, auto_logged, notes)
+	VALUES (?, ?, ?, ?, ?, ?)
+	ON CONFLICT(date) DO UPDATE SET
+		active_minutes = excluded.active_minutes,
+		goal_minutes = excluded.goal_minutes,
+		pto = excluded.pto,
+		auto_logged = excluded.auto_logged,
+		notes = excluded.notes,
+		updated_at = CURRENT_TIMESTAMP`
+	}
+
+	written := 0
+	for start := 0; start < len(entries); start += batchSize {
+		end := min(start+batchSize, len(entries))
+
+		batchWritten := 0
+		err := db.withTx(func(tx *sql.Tx) error {
+			stmt, err := tx.Prepare(query)
+			if err != nil {
+				return fmt.Errorf("failed to prepare import: %w", err)
+			}
+			defer stmt.Close()
+
+			for _, entry := range entries[start:end] {
+				result, err := stmt.Exec(entry.Date, entry.ActiveMinutes, entry.GoalMinutes,
+					entry.IsPTO, entry.AutoLogged, entry.Notes)
+				if err != nil {
+					return fmt.Errorf("failed to import %s: %w", entry.Date, err)
+				}
+				if rows, err := result.RowsAffected(); err == nil {
+					batchWritten += int(rows)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return written, err
+		}
+		written += batchWritten
+	}
+
+	return written, nil
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"timeclip/internal/models"
+)
+
+// historyEntries returns count consecutive days starting 2000-01-01, the
+// way an import from another tool looks
+func historyEntries(count int) []*models.DailyTimeEntry {
+	first := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	entries := make([]*models.DailyTimeEntry, count)
+	for i := range entries {
+		entries[i] = &models.DailyTimeEntry{
+			Date:          first.AddDate(0, 0, i).Format("2006-01-02"),
+			ActiveMinutes: 300 + i%240,
+			GoalMinutes:   480,
+			AutoLogged:    true,
+		}
+	}
+	return entries
+}
+
+func TestImportEntries(t *testing.T) {
+	db := newTestDB(t)
+	entries := historyEntries(1234)
+
+	written, err := db.ImportEntries(entries, 100, false)
+	if err != nil {
+		t.Fatalf("ImportEntries: %v", err)
+	}
+	if written != len(entries) {
+		t.Errorf("written = %d, want %d", written, len(entries))
+	}
+
+	last, err := db.GetEntryForDate(entries[len(entries)-1].Date)
+	if err != nil {
+		t.Fatalf("GetEntryForDate: %v", err)
+	}
+	if last.ActiveMinutes != entries[len(entries)-1].ActiveMinutes || !last.AutoLogged {
+		t.Errorf("last day = %d minutes, logged %v; want the imported values", last.ActiveMinutes, last.AutoLogged)
+	}
+
+	// Existing days are kept unless overwrite is set
+	changed := []*models.DailyTimeEntry{{Date: entries[0].Date, ActiveMinutes: 1, GoalMinutes: 480}}
+	if written, err := db.ImportEntries(changed, 0, false); err != nil || written != 0 {
+		t.Errorf("import over an existing day = %d, %v; want it skipped", written, err)
+	}
+	if written, err := db.ImportEntries(changed, 0, true); err != nil || written != 1 {
+		t.Errorf("overwriting import = %d, %v; want 1 day written", written, err)
+	}
+	first, err := db.GetEntryForDate(entries[0].Date)
+	if err != nil {
+		t.Fatalf("GetEntryForDate: %v", err)
+	}
+	if first.ActiveMinutes != 1 {
+		t.Errorf("overwritten day = %d minutes, want 1", first.ActiveMinutes)
+	}
+}
+
+// BenchmarkImport10k compares importing 10,000 days in batches with
+// committing each row on its own
+func BenchmarkImport10k(b *testing.B) {
+	entries := historyEntries(10000)
+
+	for _, bench := range []struct {
+		name      string
+		batchSize int
+	}{
+		{"batched", DefaultImportBatchSize},
+		{"per_row", 1},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				db := newTestDB(b)
+				b.StartTimer()
+
+				written, err := db.ImportEntries(entries, bench.batchSize, false)
+				if err != nil {
+					b.Fatalf("ImportEntries: %v", err)
+				}
+				if written != len(entries) {
+					b.Fatalf("written = %d, want %d", written, len(entries))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package database
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// trackingDisabledFile is created next to the database while tracking is
+// globally disabled, so the setting survives restarts
+const trackingDisabledFile = "tracking_disabled"
+
+// trackingDisabledPath returns the location of the kill switch sentinel file
+func (db *DB) trackingDisabledPath() string {
+	return filepath.Join(filepath.Dir(db.dbPath), trackingDisabledFile)
+}
+
+// IsTrackingDisabled returns true if tracking has been globally disabled
+func (db *DB) IsTrackingDisabled() bool {
+	_, err := os.Stat(db.trackingDisabledPath())
+	return err == nil
+}
+
+// SetTrackingDisabled turns the global tracking kill switch on or off
+func (db *DB) SetTrackingDisabled(disabled bool) error {
+	path := db.trackingDisabledPath()
+
+	if disabled {
+		if err := os.WriteFile(path, []byte("Tracking disabled from Timeclip\n"), 0644); err != nil {
+			return fmt.Errorf("failed to create %s: %w", path, err)
+		}
+	} else {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+	}
+
+	eventType := "tracking_enabled"
+	if disabled {
+		eventType = "tracking_disabled"
+	}
+	db.LogSystemEvent(eventType, "")
+
+	return nil
+}
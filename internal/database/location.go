@@ -0,0 +1,72 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// syncedFolders maps folders (relative to the home directory) that are
+// synced by a file sync service to the name of that service
+var syncedFolders = []struct {
+	dir     string
+	service string
+}{
+	{"Library/Mobile Documents", "iCloud Drive"},
+	{"Library/CloudStorage", "a cloud storage provider"},
+	{"Dropbox", "Dropbox"},
+	{"Google Drive", "Google Drive"},
+	{"OneDrive", "OneDrive"},
+	{"Box", "Box"},
+}
+
+// networkPrefixes are mount points used for network file systems on macOS
+var networkPrefixes = []string{"/net/", "/Network/"}
+
+// SyncedLocation reports whether dbPath lives in a folder managed by a file
+// sync service or on a network mount, where SQLite files can be corrupted by
+// sync conflicts. It returns a description of the location when it does.
+// Symlinks (e.g. ~/.timeclip linked into Dropbox) are followed.
+func SyncedLocation(dbPath string) (string, bool) {
+	dbPath, err := ExpandPath(dbPath)
+	if err != nil {
+		return "", false
+	}
+
+	resolved := resolveExistingPath(dbPath)
+
+	homeDir, err := os.UserHomeDir()
+	if err == nil {
+		for _, folder := range syncedFolders {
+			root := filepath.Join(homeDir, folder.dir) + string(filepath.Separator)
+			if strings.HasPrefix(resolved, root) {
+				return folder.service, true
+			}
+		}
+	}
+
+	for _, prefix := range networkPrefixes {
+		if strings.HasPrefix(resolved, prefix) {
+			return "a network volume", true
+		}
+	}
+
+	return "", false
+}
+
+// resolveExistingPath resolves symlinks in the longest existing prefix of path
+func resolveExistingPath(path string) string {
+	dir, rest := filepath.Dir(path), filepath.Base(path)
+	for {
+		if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+			return filepath.Join(resolved, rest)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return path
+		}
+		rest = filepath.Join(filepath.Base(dir), rest)
+		dir = parent
+	}
+}
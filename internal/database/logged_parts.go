@@ -0,0 +1,37 @@
+package database
+
+import (
+	"fmt"
+)
+
+// LoggedParts returns how many of a day's time entries were already
+// submitted, and to which provider, when logging it failed part way. It
+// returns 0 when no partial submission is recorded.
+func (db *DB) LoggedParts(date string) (string, int, error) {
+	var provider string
+	var parts int
+	err := db.conn.QueryRow(`SELECT logged_parts_provider, logged_parts FROM daily_time WHERE date = ?`, date).
+		Scan(&provider, &parts)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read logged parts for %s: %w", date, err)
+	}
+	return provider, parts, nil
+}
+
+// SetLoggedParts records that the first parts of a day's time entries were
+// submitted to provider, so a retry resumes after them instead of submitting
+// them again. The day stays unlogged until MarkAsAutoLogged.
+func (db *DB) SetLoggedParts(date, provider string, parts int) error {
+	_, err := db.conn.Exec(`
+	UPDATE daily_time
+	SET logged_parts = ?,
+	    logged_parts_provider = ?,
+	    updated_at = CURRENT_TIMESTAMP
+	WHERE date = ?`, parts, provider, date)
+	if err != nil {
+		return fmt.Errorf("failed to record logged parts: %w", err)
+	}
+
+	db.LogSystemEvent("logged_parts", fmt.Sprintf("Date: %s, Provider: %s, Parts: %d", date, provider, parts))
+	return nil
+}
@@ -0,0 +1,35 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// SetNotes replaces the free-form notes for date (YYYY-MM-DD), creating an
+// entry for the day if it doesn't have one yet. Empty text clears the notes.
+func (db *DB) SetNotes(date, text string) error {
+	query := `
+	INSERT INTO daily_time (date, notes)
+	VALUES (?, ?)
+	ON CONFLICT(date) DO UPDATE SET notes = excluded.notes, updated_at = CURRENT_TIMESTAMP`
+
+	if _, err := db.conn.Exec(query, date, strings.TrimSpace(text)); err != nil {
+		return fmt.Errorf("failed to save notes for %s: %w", date, err)
+	}
+	return nil
+}
+
+// GetNotes returns the notes for date, or "" if there are none. It doesn't
+// create an entry.
+func (db *DB) GetNotes(date string) (string, error) {
+	var notes string
+	err := db.conn.QueryRow("SELECT COALESCE(notes, '') FROM daily_time WHERE date = ?", date).Scan(&notes)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get notes for %s: %w", date, err)
+	}
+	return notes, nil
+}
@@ -0,0 +1,34 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// NewDBWithRetry opens the database like NewDB, retrying up to retries more
+// times with delay in between. At login the home folder or a mounted volume
+// may not be ready yet when Timeclip starts.
+func NewDBWithRetry(dbPath string, retries int, delay time.Duration) (*DB, error) {
+	return openWithRetry(func() (*DB, error) { return NewDB(dbPath) }, retries, delay, time.Sleep)
+}
+
+// openWithRetry calls open until it succeeds or retries are used up
+func openWithRetry(open func() (*DB, error), retries int, delay time.Duration, sleep func(time.Duration)) (*DB, error) {
+	attempts := retries + 1
+	for attempt := 1; ; attempt++ {
+		db, err := open()
+		if err == nil {
+			if attempt > 1 {
+				log.Printf("✅ Database opened on attempt %d", attempt)
+			}
+			return db, nil
+		}
+		if attempt >= attempts {
+			return nil, fmt.Errorf("failed to open database after %d attempts: %w", attempts, err)
+		}
+
+		log.Printf("⏳ Database not available (attempt %d of %d), retrying in %v: %v", attempt, attempts, delay, err)
+		sleep(delay)
+	}
+}
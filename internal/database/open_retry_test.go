@@ -0,0 +1,69 @@
+package database
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestOpenWithRetry(t *testing.T) {
+	errNotMounted := errors.New("volume not mounted")
+
+	tests := []struct {
+		name       string
+		failures   int
+		retries    int
+		wantErr    bool
+		wantSleeps int
+	}{
+		{"first try", 0, 3, false, 0},
+		{"after retries", 2, 3, false, 2},
+		{"retries used up", 5, 3, true, 3},
+		{"no retries", 1, 0, true, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			open := func() (*DB, error) {
+				calls++
+				if calls <= tc.failures {
+					return nil, errNotMounted
+				}
+				return &DB{}, nil
+			}
+			var sleeps []time.Duration
+			sleep := func(d time.Duration) { sleeps = append(sleeps, d) }
+
+			db, err := openWithRetry(open, tc.retries, time.Second, sleep)
+			if tc.wantErr {
+				if !errors.Is(err, errNotMounted) || db != nil {
+					t.Errorf("openWithRetry = %v, %v; want the open error", db, err)
+				}
+			} else if err != nil || db == nil {
+				t.Errorf("openWithRetry = %v, %v; want a database", db, err)
+			}
+			if len(sleeps) != tc.wantSleeps {
+				t.Errorf("slept %d times, want %d", len(sleeps), tc.wantSleeps)
+			}
+			for _, d := range sleeps {
+				if d != time.Second {
+					t.Errorf("slept %v, want the delay", d)
+				}
+			}
+		})
+	}
+}
+
+func TestNewDBWithRetryGivesUp(t *testing.T) {
+	// A file where the data directory should be never becomes available
+	blocker := filepath.Join(t.TempDir(), "blocker")
+	if err := os.WriteFile(blocker, nil, 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := NewDBWithRetry(filepath.Join(blocker, "timeclip.db"), 1, time.Millisecond); err == nil {
+		t.Error("NewDBWithRetry succeeded below a file, want an error")
+	}
+}
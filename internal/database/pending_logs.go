@@ -0,0 +1,179 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"timeclip/internal/models"
+)
+
+// maxPendingBackoff caps how long a queued submission waits between retries
+const maxPendingBackoff = time.Hour
+
+const pendingLogColumns = `id, date, attempts, last_error, approved,
+	CAST(strftime('%s', last_attempt_at) AS INTEGER),
+	CAST(strftime('%s', next_attempt_at) AS INTEGER),
+	CAST(strftime('%s', created_at) AS INTEGER)`
+
+// QueuePendingLog records a failed submission for a date in the offline queue.
+// The next retry waits retryInterval, doubling with each failed attempt up to
+// maxPendingBackoff. It returns the number of attempts so far.
+func (db *DB) QueuePendingLog(date, lastError string, retryInterval time.Duration) (int, error) {
+	pending, err := db.GetPendingLog(date)
+	if err != nil {
+		return 0, err
+	}
+
+	attempts := 1
+	if pending != nil {
+		attempts = pending.Attempts + 1
+	}
+
+	backoff := retryInterval
+	for i := 1; i < attempts && backoff < maxPendingBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxPendingBackoff {
+		backoff = maxPendingBackoff
+	}
+
+	query := `
+	INSERT INTO pending_logs (date, attempts, last_error, last_attempt_at, next_attempt_at)
+	VALUES (?, ?, ?, CURRENT_TIMESTAMP, datetime('now', ?))
+	ON CONFLICT(date) DO UPDATE SET
+		attempts = excluded.attempts,
+		last_error = excluded.last_error,
+		last_attempt_at = excluded.last_attempt_at,
+		next_attempt_at = excluded.next_attempt_at`
+
+	modifier := fmt.Sprintf("+%d seconds", int(backoff.Seconds()))
+	if _, err := db.conn.Exec(query, date, attempts, lastError, modifier); err != nil {
+		return 0, fmt.Errorf("failed to queue pending log: %w", err)
+	}
+
+	if pending == nil {
+		db.LogSystemEvent("log_queued", fmt.Sprintf("Date: %s", date))
+	}
+	return attempts, nil
+}
+
+// HoldPendingLog queues a date that is waiting on the user rather than on a
+// failure: the attempts are left as they are, so the hold doesn't add to the
+// backoff, and the next retry is after wait.
+func (db *DB) HoldPendingLog(date, reason string, wait time.Duration) error {
+	query := `
+	INSERT INTO pending_logs (date, attempts, last_error, last_attempt_at, next_attempt_at)
+	VALUES (?, 0, ?, CURRENT_TIMESTAMP, datetime('now', ?))
+	ON CONFLICT(date) DO UPDATE SET
+		last_error = excluded.last_error,
+		last_attempt_at = excluded.last_attempt_at,
+		next_attempt_at = excluded.next_attempt_at`
+
+	modifier := fmt.Sprintf("+%d seconds", int(wait.Seconds()))
+	if _, err := db.conn.Exec(query, date, reason, modifier); err != nil {
+		return fmt.Errorf("failed to hold pending log: %w", err)
+	}
+	return nil
+}
+
+// GetPendingLog returns the queued submission for a date, or nil if there is none
+func (db *DB) GetPendingLog(date string) (*models.PendingLog, error) {
+	query := `SELECT ` + pendingLogColumns + ` FROM pending_logs WHERE date = ?`
+
+	pending, err := scanPendingLog(db.conn.QueryRow(query, date))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending log: %w", err)
+	}
+	return pending, nil
+}
+
+// GetPendingLogs returns every queued submission, oldest date first
+func (db *DB) GetPendingLogs() ([]*models.PendingLog, error) {
+	return db.queryPendingLogs(`SELECT ` + pendingLogColumns + ` FROM pending_logs ORDER BY date ASC`)
+}
+
+// GetDuePendingLogs returns queued submissions whose retry time has passed
+func (db *DB) GetDuePendingLogs() ([]*models.PendingLog, error) {
+	return db.queryPendingLogs(`SELECT ` + pendingLogColumns + ` FROM pending_logs
+	WHERE next_attempt_at <= CURRENT_TIMESTAMP ORDER BY date ASC`)
+}
+
+// RemovePendingLog drops a date from the offline queue
+func (db *DB) RemovePendingLog(date string) error {
+	if _, err := db.conn.Exec(`DELETE FROM pending_logs WHERE date = ?`, date); err != nil {
+		return fmt.Errorf("failed to remove pending log: %w", err)
+	}
+	return nil
+}
+
+// ApprovePendingLogs approves every queued submission for a catch-up that was
+// held for confirmation. It returns how many were approved.
+func (db *DB) ApprovePendingLogs() (int, error) {
+	result, err := db.conn.Exec(`UPDATE pending_logs SET approved = TRUE WHERE approved = FALSE`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to approve pending logs: %w", err)
+	}
+
+	approved, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if approved > 0 {
+		db.LogSystemEvent("pending_approved", fmt.Sprintf("Count: %d", approved))
+	}
+	return int(approved), nil
+}
+
+// queryPendingLogs runs a pending_logs query selecting pendingLogColumns
+func (db *DB) queryPendingLogs(query string) ([]*models.PendingLog, error) {
+	rows, err := db.conn.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending logs: %w", err)
+	}
+	defer rows.Close()
+
+	var pending []*models.PendingLog
+	for rows.Next() {
+		item, err := scanPendingLog(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pending log: %w", err)
+		}
+		pending = append(pending, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pending logs: %w", err)
+	}
+
+	return pending, nil
+}
+
+// scanPendingLog scans a row selected with pendingLogColumns
+func scanPendingLog(row rowScanner) (*models.PendingLog, error) {
+	var (
+		pending                           models.PendingLog
+		lastAttempt, nextAttempt, created sql.NullInt64
+	)
+	if err := row.Scan(&pending.ID, &pending.Date, &pending.Attempts, &pending.LastError, &pending.Approved,
+		&lastAttempt, &nextAttempt, &created); err != nil {
+		return nil, err
+	}
+
+	pending.LastAttemptAt = unixOrZero(lastAttempt)
+	pending.NextAttemptAt = unixOrZero(nextAttempt)
+	pending.CreatedAt = unixOrZero(created)
+	return &pending, nil
+}
+
+// unixOrZero converts a nullable unix timestamp to a time
+func unixOrZero(value sql.NullInt64) time.Time {
+	if !value.Valid {
+		return time.Time{}
+	}
+	return time.Unix(value.Int64, 0)
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQueuePendingLogBacksOff(t *testing.T) {
+	db := newTestDB(t)
+	const date = "2024-05-06"
+
+	// Retry intervals double per failure up to maxPendingBackoff
+	want := []time.Duration{
+		5 * time.Minute,
+		10 * time.Minute,
+		20 * time.Minute,
+		40 * time.Minute,
+		time.Hour,
+		time.Hour,
+	}
+	for i, wantWait := range want {
+		attempts, err := db.QueuePendingLog(date, "request failed", 5*time.Minute)
+		if err != nil {
+			t.Fatalf("QueuePendingLog: %v", err)
+		}
+		if attempts != i+1 {
+			t.Errorf("attempts = %d, want %d", attempts, i+1)
+		}
+
+		pending, err := db.GetPendingLog(date)
+		if err != nil || pending == nil {
+			t.Fatalf("GetPendingLog = %v, %v", pending, err)
+		}
+		if wait := pending.NextAttemptAt.Sub(pending.LastAttemptAt); wait != wantWait {
+			t.Errorf("attempt %d: next retry after %v, want %v", i+1, wait, wantWait)
+		}
+	}
+
+	due, err := db.GetDuePendingLogs()
+	if err != nil {
+		t.Fatalf("GetDuePendingLogs: %v", err)
+	}
+	if len(due) != 0 {
+		t.Errorf("due = %+v, want nothing before the retry time", due)
+	}
+}
+
+func TestHoldPendingLogKeepsAttempts(t *testing.T) {
+	db := newTestDB(t)
+
+	if err := db.HoldPendingLog("2024-05-06", "waiting for confirmation", time.Hour); err != nil {
+		t.Fatalf("HoldPendingLog: %v", err)
+	}
+	if pending, _ := db.GetPendingLog("2024-05-06"); pending == nil || pending.Attempts != 0 {
+		t.Errorf("held day = %+v, want queued with no attempts", pending)
+	}
+
+	if _, err := db.QueuePendingLog("2024-05-07", "request failed", time.Minute); err != nil {
+		t.Fatalf("QueuePendingLog: %v", err)
+	}
+	if err := db.HoldPendingLog("2024-05-07", "waiting for confirmation", time.Hour); err != nil {
+		t.Fatalf("HoldPendingLog: %v", err)
+	}
+	pending, err := db.GetPendingLog("2024-05-07")
+	if err != nil || pending == nil {
+		t.Fatalf("GetPendingLog = %v, %v", pending, err)
+	}
+	if pending.Attempts != 1 || pending.LastError != "waiting for confirmation" {
+		t.Errorf("held day = %+v, want the failed attempt kept with the new reason", pending)
+	}
+
+	if err := db.RemovePendingLog("2024-05-07"); err != nil {
+		t.Fatalf("RemovePendingLog: %v", err)
+	}
+	if pending, _ := db.GetPendingLog("2024-05-07"); pending != nil {
+		t.Errorf("GetPendingLog = %+v after removal, want nil", pending)
+	}
+}
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// providerOverridesFile is stored next to the database and lists providers
+// switched off from the menu for one day, so the choice survives a restart
+const providerOverridesFile = "providers_disabled.json"
+
+// providerOverrides is the contents of providerOverridesFile
+type providerOverrides struct {
+	Date     string   `json:"date"`
+	Disabled []string `json:"disabled"`
+}
+
+// providerOverridesPath returns the location of the provider overrides file
+func (db *DB) providerOverridesPath() string {
+	return filepath.Join(filepath.Dir(db.dbPath), providerOverridesFile)
+}
+
+// GetDisabledProviders returns the providers switched off for date. Overrides
+// saved for another day have expired and are ignored.
+func (db *DB) GetDisabledProviders(date string) ([]string, error) {
+	data, err := os.ReadFile(db.providerOverridesPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read provider overrides: %w", err)
+	}
+
+	var overrides providerOverrides
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse provider overrides: %w", err)
+	}
+	if overrides.Date != date {
+		return nil, nil
+	}
+	return overrides.Disabled, nil
+}
+
+// SetProviderDisabled switches a provider off (or back on) for date
+func (db *DB) SetProviderDisabled(date, provider string, disabled bool) error {
+	current, err := db.GetDisabledProviders(date)
+	if err != nil {
+		// A corrupt file only loses today's overrides
+		current = nil
+	}
+
+	overrides := providerOverrides{Date: date}
+	for _, name := range current {
+		if name != provider {
+			overrides.Disabled = append(overrides.Disabled, name)
+		}
+	}
+	if disabled {
+		overrides.Disabled = append(overrides.Disabled, provider)
+		sort.Strings(overrides.Disabled)
+	}
+
+	data, err := json.MarshalIndent(overrides, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode provider overrides: %w", err)
+	}
+	if err := os.WriteFile(db.providerOverridesPath(), data, 0644); err != nil {
+		return fmt.Errorf("failed to write provider overrides: %w", err)
+	}
+
+	eventType := "provider_enabled"
+	if disabled {
+		eventType = "provider_disabled"
+	}
+	db.LogSystemEvent(eventType, fmt.Sprintf("Date: %s, Provider: %s", date, provider))
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"fmt"
+	"time"
+)
+
+// maxPTODays limits how many days a single PTO range can cover, so a typo in
+// a year doesn't create thousands of entries
+const maxPTODays = 366
+
+// MarkDateRangePTO marks every day from start to end (inclusive, YYYY-MM-DD)
+// as PTO. Nothing is tracked or auto-logged on PTO days, and stats can leave
+// them out. Entries are created for days that don't have one yet.
+func (db *DB) MarkDateRangePTO(start, end string) error {
+	return db.setDateRangePTO(start, end, true)
+}
+
+// ClearDateRangePTO removes the PTO mark from every day from start to end
+func (db *DB) ClearDateRangePTO(start, end string) error {
+	return db.setDateRangePTO(start, end, false)
+}
+
+// setDateRangePTO sets or clears the PTO flag for each day in the range
+func (db *DB) setDateRangePTO(start, end string, pto bool) error {
+	startDate, err := time.Parse("2006-01-02", start)
+	if err != nil {
+		return fmt.Errorf("invalid start date %q: expected YYYY-MM-DD", start)
+	}
+	endDate, err := time.Parse("2006-01-02", end)
+	if err != nil {
+		return fmt.Errorf("invalid end date %q: expected YYYY-MM-DD", end)
+	}
+	if endDate.Before(startDate) {
+		return fmt.Errorf("end date %s is before start date %s", end, start)
+	}
+	if days := int(endDate.Sub(startDate).Hours()/24) + 1; days > maxPTODays {
+		return fmt.Errorf("PTO range covers %d days, at most %d are allowed", days, maxPTODays)
+	}
+
+	query := `
+	INSERT INTO daily_time (date, pto)
+	VALUES (?, ?)
+	ON CONFLICT(date) DO UPDATE SET pto = excluded.pto, updated_at = CURRENT_TIMESTAMP`
+
+	for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		if _, err := db.conn.Exec(query, day.Format("2006-01-02"), pto); err != nil {
+			return fmt.Errorf("failed to set PTO for %s: %w", day.Format("2006-01-02"), err)
+		}
+	}
+
+	eventType := "pto_cleared"
+	if pto {
+		eventType = "pto_marked"
+	}
+	db.LogSystemEvent(eventType, fmt.Sprintf("Date: %s, To: %s", start, end))
+
+	return nil
+}
+
+// IsPTO returns true if date is marked as PTO. It doesn't create an entry.
+func (db *DB) IsPTO(date string) (bool, error) {
+	var pto bool
+	err := db.conn.QueryRow("SELECT COALESCE(MAX(pto), FALSE) FROM daily_time WHERE date = ?", date).Scan(&pto)
+	if err != nil {
+		return false, fmt.Errorf("failed to check PTO for %s: %w", date, err)
+	}
+	return pto, nil
+}
@@ -2,38 +2,36 @@ package database
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"timeclip/internal/models"
 )
 
-// GetWeeklyStats returns aggregated statistics for the current week
-func (db *DB) GetWeeklyStats() (*WeeklyStats, error) {
-	now := time.Now()
-	
-	// Get start of current week (Monday)
-	weekday := int(now.Weekday())
-	if weekday == 0 { // Sunday
-		weekday = 7
-	}
-	startOfWeek := now.AddDate(0, 0, -weekday+1)
+// GetWeeklyStats returns aggregated statistics for the current week. Only days
+// with at least minTrackedMinutes count as tracked days and towards the average.
+// PTO days are left out entirely when excludePTO is set. The week begins on weekStart.
+func (db *DB) GetWeeklyStats(minTrackedMinutes int, excludePTO bool, weekStart time.Weekday) (*WeeklyStats, error) {
+	startOfWeek := models.StartOfWeek(time.Now(), weekStart)
 	endOfWeek := startOfWeek.AddDate(0, 0, 6)
 
 	query := `
 	SELECT 
-		COUNT(*) as days_tracked,
-		SUM(active_minutes) as total_minutes,
-		AVG(active_minutes) as avg_minutes_per_day,
-		SUM(CASE WHEN active_minutes >= goal_minutes THEN 1 ELSE 0 END) as goal_days,
-		MIN(date) as week_start,
-		MAX(date) as week_end
+		COALESCE(SUM(CASE WHEN active_minutes >= ? THEN 1 ELSE 0 END), 0) as days_tracked,
+		COALESCE(SUM(active_minutes), 0) as total_minutes,
+		COALESCE(AVG(CASE WHEN active_minutes >= ? THEN active_minutes END), 0) as avg_minutes_per_day,
+		COALESCE(SUM(CASE WHEN goal_minutes > 0 AND active_minutes >= goal_minutes THEN 1 ELSE 0 END), 0) as goal_days,
+		COALESCE(MIN(date), '') as week_start,
+		COALESCE(MAX(date), '') as week_end
 	FROM daily_time 
-	WHERE date >= ? AND date <= ?`
+	WHERE date >= ? AND date <= ? AND (? = FALSE OR pto = FALSE)`
 
 	stats := &WeeklyStats{}
 	err := db.conn.QueryRow(query, 
+		minTrackedMinutes, minTrackedMinutes,
 		startOfWeek.Format("2006-01-02"),
 		endOfWeek.Format("2006-01-02"),
+		excludePTO,
 	).Scan(
 		&stats.DaysTracked,
 		&stats.TotalMinutes,
@@ -47,11 +45,18 @@ func (db *DB) GetWeeklyStats() (*WeeklyStats, error) {
 		return nil, fmt.Errorf("failed to get weekly stats: %w", err)
 	}
 
+	if err := db.addPendingToStats(startOfWeek.Format("2006-01-02"), endOfWeek.Format("2006-01-02"), minTrackedMinutes, excludePTO,
+		&stats.DaysTracked, &stats.TotalMinutes, &stats.AvgMinutesPerDay, &stats.GoalDays); err != nil {
+		return nil, fmt.Errorf("failed to get weekly stats: %w", err)
+	}
+
 	return stats, nil
 }
 
-// GetMonthlyStats returns aggregated statistics for the current month
-func (db *DB) GetMonthlyStats() (*MonthlyStats, error) {
+// GetMonthlyStats returns aggregated statistics for the current month. Only days
+// with at least minTrackedMinutes count as tracked days and towards the average.
+// PTO days are left out entirely when excludePTO is set.
+func (db *DB) GetMonthlyStats(minTrackedMinutes int, excludePTO bool) (*MonthlyStats, error) {
 	now := time.Now()
 	
 	// Get start and end of current month
@@ -60,19 +65,21 @@ func (db *DB) GetMonthlyStats() (*MonthlyStats, error) {
 
 	query := `
 	SELECT 
-		COUNT(*) as days_tracked,
-		SUM(active_minutes) as total_minutes,
-		AVG(active_minutes) as avg_minutes_per_day,
-		SUM(CASE WHEN active_minutes >= goal_minutes THEN 1 ELSE 0 END) as goal_days,
-		MIN(date) as month_start,
-		MAX(date) as month_end
+		COALESCE(SUM(CASE WHEN active_minutes >= ? THEN 1 ELSE 0 END), 0) as days_tracked,
+		COALESCE(SUM(active_minutes), 0) as total_minutes,
+		COALESCE(AVG(CASE WHEN active_minutes >= ? THEN active_minutes END), 0) as avg_minutes_per_day,
+		COALESCE(SUM(CASE WHEN goal_minutes > 0 AND active_minutes >= goal_minutes THEN 1 ELSE 0 END), 0) as goal_days,
+		COALESCE(MIN(date), '') as month_start,
+		COALESCE(MAX(date), '') as month_end
 	FROM daily_time 
-	WHERE date >= ? AND date <= ?`
+	WHERE date >= ? AND date <= ? AND (? = FALSE OR pto = FALSE)`
 
 	stats := &MonthlyStats{}
 	err := db.conn.QueryRow(query,
+		minTrackedMinutes, minTrackedMinutes,
 		startOfMonth.Format("2006-01-02"),
 		endOfMonth.Format("2006-01-02"),
+		excludePTO,
 	).Scan(
 		&stats.DaysTracked,
 		&stats.TotalMinutes,
@@ -86,19 +93,30 @@ func (db *DB) GetMonthlyStats() (*MonthlyStats, error) {
 		return nil, fmt.Errorf("failed to get monthly stats: %w", err)
 	}
 
+	if err := db.addPendingToStats(startOfMonth.Format("2006-01-02"), endOfMonth.Format("2006-01-02"), minTrackedMinutes, excludePTO,
+		&stats.DaysTracked, &stats.TotalMinutes, &stats.AvgMinutesPerDay, &stats.GoalDays); err != nil {
+		return nil, fmt.Errorf("failed to get monthly stats: %w", err)
+	}
+
 	return stats, nil
 }
 
-// GetEntriesNeedingAutoLog returns entries that should be auto-logged
-func (db *DB) GetEntriesNeedingAutoLog(thresholdMinutes int) ([]*models.DailyTimeEntry, error) {
+// GetEntriesNeedingAutoLog returns entries that should be auto-logged. Days
+// before since (YYYY-MM-DD) and abandoned days are left out; an empty since
+// has no limit.
+func (db *DB) GetEntriesNeedingAutoLog(thresholdMinutes int, since string) ([]*models.DailyTimeEntry, error) {
+	// A day is only logged with all of its minutes
+	if err := db.Flush(); err != nil {
+		return nil, err
+	}
+
 	query := `
-	SELECT id, date, active_minutes, goal_minutes, is_paused, auto_logged,
-	       auto_log_response, created_at, updated_at
+	SELECT ` + entryColumns + `
 	FROM daily_time 
-	WHERE auto_logged = FALSE AND active_minutes >= ?
+	WHERE auto_logged = FALSE AND pto = FALSE AND abandoned = FALSE AND active_minutes >= ? AND date >= ?
 	ORDER BY date ASC`
 
-	rows, err := db.conn.Query(query, thresholdMinutes)
+	rows, err := db.conn.Query(query, thresholdMinutes, since)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query entries needing auto-log: %w", err)
 	}
@@ -106,12 +124,7 @@ func (db *DB) GetEntriesNeedingAutoLog(thresholdMinutes int) ([]*models.DailyTim
 
 	var entries []*models.DailyTimeEntry
 	for rows.Next() {
-		entry := &models.DailyTimeEntry{}
-		err := rows.Scan(
-			&entry.ID, &entry.Date, &entry.ActiveMinutes, &entry.GoalMinutes,
-			&entry.IsPaused, &entry.AutoLogged, &entry.AutoLogResponse,
-			&entry.CreatedAt, &entry.UpdatedAt,
-		)
+		entry, err := scanEntry(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan entry: %w", err)
 		}
@@ -125,6 +138,141 @@ func (db *DB) GetEntriesNeedingAutoLog(thresholdMinutes int) ([]*models.DailyTim
 	return entries, nil
 }
 
+// GetEntriesForDateRange returns all entries between start and end (inclusive, YYYY-MM-DD)
+func (db *DB) GetEntriesForDateRange(start, end string) ([]*models.DailyTimeEntry, error) {
+	query := `
+	SELECT ` + entryColumns + `
+	FROM daily_time 
+	WHERE date >= ? AND date <= ?
+	ORDER BY date ASC`
+
+	rows, err := db.conn.Query(query, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query entries for date range: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*models.DailyTimeEntry
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating entries: %w", err)
+	}
+
+	db.withPending(entries...)
+	return entries, nil
+}
+
+// GetStatsForDateRange returns aggregated statistics between start and end
+// (inclusive, YYYY-MM-DD). Days with fewer than minTrackedMinutes still add to
+// the total but don't count as tracked days, towards the average, or as the
+// best/worst day. PTO days are left out entirely when excludePTO is set.
+func (db *DB) GetStatsForDateRange(start, end string, minTrackedMinutes int, excludePTO bool) (*RangeStats, error) {
+	entries, err := db.GetEntriesForDateRange(start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get range stats: %w", err)
+	}
+
+	stats := &RangeStats{
+		RangeStart: start,
+		RangeEnd:   end,
+	}
+
+	trackedMinutes := 0
+	for _, entry := range entries {
+		if excludePTO && entry.IsPTO {
+			continue
+		}
+
+		stats.TotalMinutes += entry.ActiveMinutes
+		if entry.IsGoalReached() {
+			stats.GoalDays++
+		}
+		if entry.ActiveMinutes < minTrackedMinutes {
+			continue
+		}
+
+		stats.DaysTracked++
+		trackedMinutes += entry.ActiveMinutes
+
+		if stats.BestDay == nil || entry.ActiveMinutes > stats.BestDay.ActiveMinutes {
+			stats.BestDay = &DayTotal{Date: entry.Date, ActiveMinutes: entry.ActiveMinutes}
+		}
+		if stats.WorstDay == nil || entry.ActiveMinutes < stats.WorstDay.ActiveMinutes {
+			stats.WorstDay = &DayTotal{Date: entry.Date, ActiveMinutes: entry.ActiveMinutes}
+		}
+	}
+
+	if stats.DaysTracked > 0 {
+		stats.AvgMinutesPerDay = float64(trackedMinutes) / float64(stats.DaysTracked)
+	}
+
+	goalTimes, err := db.GetGoalReachedTimes(start, end, excludePTO)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get range stats: %w", err)
+	}
+	for _, goalTime := range goalTimes {
+		if goalTime < "12:00" {
+			stats.GoalsBeforeNoon++
+		} else {
+			stats.GoalsAfterNoon++
+		}
+	}
+	stats.MedianGoalTime = medianClockTime(goalTimes)
+
+	return stats, nil
+}
+
+// GetGoalReachedTimes returns the local time (HH:MM) each day between start and
+// end first reached its goal, in date order. Days tracked before the
+// goal_reached_at column existed have no recorded time and are skipped, as
+// are PTO days when excludePTO is set.
+func (db *DB) GetGoalReachedTimes(start, end string, excludePTO bool) ([]string, error) {
+	query := `
+	SELECT CAST(strftime('%s', goal_reached_at) AS INTEGER)
+	FROM daily_time 
+	WHERE date >= ? AND date <= ? AND goal_reached_at IS NOT NULL AND (? = FALSE OR pto = FALSE)
+	ORDER BY date ASC`
+
+	rows, err := db.conn.Query(query, start, end, excludePTO)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query goal reached times: %w", err)
+	}
+	defer rows.Close()
+
+	var times []string
+	for rows.Next() {
+		var unix int64
+		if err := rows.Scan(&unix); err != nil {
+			return nil, fmt.Errorf("failed to scan goal reached time: %w", err)
+		}
+		times = append(times, time.Unix(unix, 0).Format("15:04"))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating goal reached times: %w", err)
+	}
+
+	return times, nil
+}
+
+// medianClockTime returns the median of HH:MM times, or "" if there are none
+func medianClockTime(times []string) string {
+	if len(times) == 0 {
+		return ""
+	}
+
+	sorted := append([]string(nil), times...)
+	sort.Strings(sorted)
+	return sorted[(len(sorted)-1)/2]
+}
+
 // CleanupOldEntries removes entries older than the specified number of days
 func (db *DB) CleanupOldEntries(retentionDays int) error {
 	cutoffDate := time.Now().AddDate(0, 0, -retentionDays).Format("2006-01-02")
@@ -192,4 +340,35 @@ func (ms *MonthlyStats) TotalHours() float64 {
 // AvgHoursPerDay returns average hours per day this month
 func (ms *MonthlyStats) AvgHoursPerDay() float64 {
 	return ms.AvgMinutesPerDay / 60.0
-}
\ No newline at end of file
+}
+
+// DayTotal represents the active time recorded for a single day
+type DayTotal struct {
+	Date          string `json:"date"`
+	ActiveMinutes int    `json:"active_minutes"`
+}
+
+// RangeStats represents time tracking statistics for an arbitrary date range
+type RangeStats struct {
+	DaysTracked      int       `json:"days_tracked"`
+	TotalMinutes     int       `json:"total_minutes"`
+	AvgMinutesPerDay float64   `json:"avg_minutes_per_day"`
+	GoalDays         int       `json:"goal_days"`
+	BestDay          *DayTotal `json:"best_day,omitempty"`
+	WorstDay         *DayTotal `json:"worst_day,omitempty"`
+	RangeStart       string    `json:"range_start"`
+	RangeEnd         string    `json:"range_end"`
+	GoalsBeforeNoon  int       `json:"goals_before_noon"`
+	GoalsAfterNoon   int       `json:"goals_after_noon"`
+	MedianGoalTime   string    `json:"median_goal_time,omitempty"` // HH:MM, local time
+}
+
+// TotalHours returns total hours worked in the range
+func (rs *RangeStats) TotalHours() float64 {
+	return float64(rs.TotalMinutes) / 60.0
+}
+
+// AvgHoursPerDay returns average hours per tracked day in the range
+func (rs *RangeStats) AvgHoursPerDay() float64 {
+	return rs.AvgMinutesPerDay / 60.0
+}
@@ -0,0 +1,110 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"timeclip/internal/models"
+)
+
+func TestGetStatsForDateRangeMinTrackedMinutes(t *testing.T) {
+	db := newTestDB(t)
+	_, err := db.ImportEntries([]*models.DailyTimeEntry{
+		{Date: "2024-05-06", ActiveMinutes: 480, GoalMinutes: 480},
+		{Date: "2024-05-07", ActiveMinutes: 10, GoalMinutes: 480}, // Laptop opened briefly
+		{Date: "2024-05-08", ActiveMinutes: 360, GoalMinutes: 480},
+		{Date: "2024-05-09", ActiveMinutes: 0, GoalMinutes: 480, IsPTO: true},
+	}, 0, false)
+	if err != nil {
+		t.Fatalf("ImportEntries: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		minTracked  int
+		excludePTO  bool
+		wantTracked int
+		wantAvg     float64
+		wantWorst   int
+	}{
+		{"every day counts", 0, false, 4, 212.5, 0},
+		{"short days left out", 30, false, 2, 420, 360},
+		{"pto excluded", 0, true, 3, 850.0 / 3, 10},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			stats, err := db.GetStatsForDateRange("2024-05-06", "2024-05-10", tc.minTracked, tc.excludePTO)
+			if err != nil {
+				t.Fatalf("GetStatsForDateRange: %v", err)
+			}
+			if stats.TotalMinutes != 850 {
+				t.Errorf("TotalMinutes = %d, want every minute counted", stats.TotalMinutes)
+			}
+			if stats.DaysTracked != tc.wantTracked {
+				t.Errorf("DaysTracked = %d, want %d", stats.DaysTracked, tc.wantTracked)
+			}
+			if stats.AvgMinutesPerDay != tc.wantAvg {
+				t.Errorf("AvgMinutesPerDay = %v, want %v", stats.AvgMinutesPerDay, tc.wantAvg)
+			}
+			if stats.WorstDay == nil || stats.WorstDay.ActiveMinutes != tc.wantWorst {
+				t.Errorf("WorstDay = %+v, want %d minutes", stats.WorstDay, tc.wantWorst)
+			}
+			if stats.BestDay == nil || stats.BestDay.Date != "2024-05-06" {
+				t.Errorf("BestDay = %+v, want 2024-05-06", stats.BestDay)
+			}
+		})
+	}
+}
+
+func TestGetWeeklyStatsMinTrackedMinutes(t *testing.T) {
+	db := newTestDB(t)
+	weekStart := models.StartOfWeek(time.Now(), time.Monday)
+	_, err := db.ImportEntries([]*models.DailyTimeEntry{
+		{Date: weekStart.Format("2006-01-02"), ActiveMinutes: 480, GoalMinutes: 480},
+		{Date: weekStart.AddDate(0, 0, 1).Format("2006-01-02"), ActiveMinutes: 5, GoalMinutes: 480},
+		{Date: weekStart.AddDate(0, 0, 2).Format("2006-01-02"), ActiveMinutes: 300, GoalMinutes: 480},
+		{Date: weekStart.AddDate(0, 0, 7).Format("2006-01-02"), ActiveMinutes: 480, GoalMinutes: 480}, // Next week
+	}, 0, false)
+	if err != nil {
+		t.Fatalf("ImportEntries: %v", err)
+	}
+
+	stats, err := db.GetWeeklyStats(30, false, time.Monday)
+	if err != nil {
+		t.Fatalf("GetWeeklyStats: %v", err)
+	}
+	if stats.DaysTracked != 2 || stats.TotalMinutes != 785 || stats.AvgMinutesPerDay != 390 || stats.GoalDays != 1 {
+		t.Errorf("weekly stats = %+v, want 2 tracked days, 785 minutes, 390 average, 1 goal day", stats)
+	}
+}
+
+func TestNoGoalDayIsNeverReached(t *testing.T) {
+	db := newTestDB(t)
+	db.SetGoalFunc(func(date time.Time) int {
+		if date.Weekday() == time.Saturday {
+			return 0
+		}
+		return 1
+	})
+
+	for _, date := range []string{"2024-05-10", "2024-05-11"} { // Friday, Saturday
+		creditMinutes(t, db, date, 2)
+	}
+
+	times, err := db.GetGoalReachedTimes("2024-05-10", "2024-05-11", false)
+	if err != nil {
+		t.Fatalf("GetGoalReachedTimes: %v", err)
+	}
+	if len(times) != 1 {
+		t.Errorf("goal reached times = %v, want only Friday's", times)
+	}
+
+	stats, err := db.GetStatsForDateRange("2024-05-10", "2024-05-11", 0, false)
+	if err != nil {
+		t.Fatalf("GetStatsForDateRange: %v", err)
+	}
+	if stats.GoalDays != 1 || stats.TotalMinutes != 4 {
+		t.Errorf("range stats = %d goal days, %d minutes; want 1 and 4", stats.GoalDays, stats.TotalMinutes)
+	}
+}
@@ -0,0 +1,51 @@
+package database
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// SnapshotTo writes a consistent copy of the database to path using VACUUM INTO,
+// which is safe while the tracker is writing. Any existing file at path is replaced.
+func (db *DB) SnapshotTo(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove existing snapshot %s: %w", path, err)
+	}
+
+	if _, err := db.conn.Exec("VACUUM INTO ?", path); err != nil {
+		return fmt.Errorf("failed to snapshot database: %w", err)
+	}
+
+	return nil
+}
+
+// CheckIntegrity runs SQLite's integrity check and returns an error describing
+// any problems found
+func (db *DB) CheckIntegrity() error {
+	rows, err := db.conn.Query("PRAGMA integrity_check")
+	if err != nil {
+		return fmt.Errorf("failed to run integrity check: %w", err)
+	}
+	defer rows.Close()
+
+	var problems []string
+	for rows.Next() {
+		var result string
+		if err := rows.Scan(&result); err != nil {
+			return fmt.Errorf("failed to read integrity check result: %w", err)
+		}
+		if result != "ok" {
+			problems = append(problems, result)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error reading integrity check results: %w", err)
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("database integrity check failed: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
@@ -3,30 +3,44 @@ package database
 import (
 	"database/sql"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	_ "modernc.org/sqlite"
 	"timeclip/internal/models"
 )
 
+// warnSyncedLocation makes sure the synced-folder warning is only logged once
+var warnSyncedLocation sync.Once
+
 // DB wraps the SQLite database connection and provides time tracking operations
 type DB struct {
 	conn   *sql.DB
 	dbPath string
+
+	goalFor func(date time.Time) int // Goal for newly created days; nil uses defaultGoalMinutes
+	batch   *pendingMinutes          // Uncommitted minutes in batch mode; nil writes every minute
+}
+
+// defaultGoalMinutes is the goal of new days when no goal function is set
+const defaultGoalMinutes = 480
+
+// SetGoalFunc sets how the goal of newly created days is chosen, e.g.
+// models.GeneralConfig.GoalMinutesFor. Existing days keep their goal.
+func (db *DB) SetGoalFunc(goalFor func(date time.Time) int) {
+	db.goalFor = goalFor
 }
 
 // NewDB creates a new database instance and initializes the schema
 func NewDB(dbPath string) (*DB, error) {
 	// Expand ~ in path
-	if strings.HasPrefix(dbPath, "~/") {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get user home directory: %w", err)
-		}
-		dbPath = filepath.Join(homeDir, dbPath[2:])
+	dbPath, err := ExpandPath(dbPath)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create directory if it doesn't exist
@@ -51,6 +65,31 @@ func NewDB(dbPath string) (*DB, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
+	// Use WAL so read-only readers (e.g. the stats command) can query
+	// while the tracker is writing. WAL's shared-memory file doesn't work
+	// reliably on synced or network folders, so fall back to a rollback
+	// journal with full syncs there.
+	if location, synced := SyncedLocation(dbPath); synced {
+		warnSyncedLocation.Do(func() {
+			log.Printf("⚠️  Database %s is synced by %s. Sync conflicts can corrupt it; keep it in a local folder if you can.", dbPath, location)
+		})
+		if _, err := conn.Exec("PRAGMA journal_mode=DELETE"); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to set journal mode: %w", err)
+		}
+		if _, err := conn.Exec("PRAGMA synchronous=FULL"); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to set synchronous mode: %w", err)
+		}
+	} else if _, err := conn.Exec("PRAGMA journal_mode=WAL"); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to enable WAL mode: %w", err)
+	}
+	if _, err := conn.Exec("PRAGMA busy_timeout=5000"); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to set busy timeout: %w", err)
+	}
+
 	db := &DB{
 		conn:   conn,
 		dbPath: dbPath,
@@ -65,8 +104,56 @@ func NewDB(dbPath string) (*DB, error) {
 	return db, nil
 }
 
-// Close closes the database connection
+// OpenReadOnly opens an existing database without modifying it.
+// The schema is not initialized, so only query methods should be used.
+func OpenReadOnly(dbPath string) (*DB, error) {
+	dbPath, err := ExpandPath(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, fmt.Errorf("database %s is not accessible: %w", dbPath, err)
+	}
+
+	conn, err := sql.Open("sqlite", "file:"+dbPath+"?mode=ro&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database %s: %w", dbPath, err)
+	}
+
+	conn.SetMaxOpenConns(1)
+	conn.SetMaxIdleConns(1)
+
+	if err := conn.Ping(); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return &DB{
+		conn:   conn,
+		dbPath: dbPath,
+	}, nil
+}
+
+// ExpandPath expands ~ in a database path to the user's home directory
+func ExpandPath(dbPath string) (string, error) {
+	if !strings.HasPrefix(dbPath, "~/") {
+		return dbPath, nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+
+	return filepath.Join(homeDir, dbPath[2:]), nil
+}
+
+// Close writes any pending minutes and closes the database connection
 func (db *DB) Close() error {
+	if err := db.Flush(); err != nil {
+		log.Printf("Error writing pending active time: %v", err)
+	}
 	if db.conn != nil {
 		return db.conn.Close()
 	}
@@ -85,6 +172,13 @@ func (db *DB) initSchema() error {
 		is_paused BOOLEAN DEFAULT FALSE,
 		auto_logged BOOLEAN DEFAULT FALSE,
 		auto_log_response TEXT DEFAULT '',
+		auto_log_failures INTEGER DEFAULT 0,
+		goal_reached_at DATETIME,
+		pto BOOLEAN DEFAULT FALSE,
+		notes TEXT DEFAULT '',
+		abandoned BOOLEAN DEFAULT FALSE,
+		logged_parts INTEGER DEFAULT 0,
+		logged_parts_provider TEXT DEFAULT '',
 		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 	);`
@@ -106,6 +200,23 @@ func (db *DB) initSchema() error {
 		return fmt.Errorf("failed to create system_events table: %w", err)
 	}
 
+	// Create pending_logs table for submissions waiting to be retried
+	createPendingLogsTable := `
+	CREATE TABLE IF NOT EXISTS pending_logs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		date TEXT UNIQUE NOT NULL,
+		attempts INTEGER DEFAULT 0,
+		last_error TEXT DEFAULT '',
+		last_attempt_at DATETIME,
+		next_attempt_at DATETIME,
+		approved BOOLEAN DEFAULT FALSE,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`
+
+	if _, err := db.conn.Exec(createPendingLogsTable); err != nil {
+		return fmt.Errorf("failed to create pending_logs table: %w", err)
+	}
+
 	// Create indexes for better performance
 	createIndexes := []string{
 		"CREATE INDEX IF NOT EXISTS idx_daily_time_date ON daily_time(date);",
@@ -119,9 +230,115 @@ func (db *DB) initSchema() error {
 		}
 	}
 
+	return db.migrateSchema()
+}
+
+// schemaVersion is stamped into PRAGMA user_version once the schema is up to
+// date. Bump it whenever a table or column is added.
+const schemaVersion = 8
+
+// migrateSchema adds columns introduced after a database was first created
+func (db *DB) migrateSchema() error {
+	if err := db.addColumnIfMissing("daily_time", "auto_log_failures", "INTEGER DEFAULT 0"); err != nil {
+		return err
+	}
+	if err := db.addColumnIfMissing("daily_time", "goal_reached_at", "DATETIME"); err != nil {
+		return err
+	}
+	if err := db.addColumnIfMissing("daily_time", "pto", "BOOLEAN DEFAULT FALSE"); err != nil {
+		return err
+	}
+	if err := db.addColumnIfMissing("pending_logs", "approved", "BOOLEAN DEFAULT FALSE"); err != nil {
+		return err
+	}
+	if err := db.addColumnIfMissing("daily_time", "notes", "TEXT DEFAULT ''"); err != nil {
+		return err
+	}
+	if err := db.addColumnIfMissing("daily_time", "abandoned", "BOOLEAN DEFAULT FALSE"); err != nil {
+		return err
+	}
+	if err := db.addColumnIfMissing("daily_time", "logged_parts", "INTEGER DEFAULT 0"); err != nil {
+		return err
+	}
+	if err := db.addColumnIfMissing("daily_time", "logged_parts_provider", "TEXT DEFAULT ''"); err != nil {
+		return err
+	}
+
+	if _, err := db.conn.Exec(fmt.Sprintf("PRAGMA user_version = %d", schemaVersion)); err != nil {
+		return fmt.Errorf("failed to set schema version: %w", err)
+	}
+	return nil
+}
+
+// SchemaVersion returns the schema version recorded in the database. Databases
+// last opened by a build that predates versioning report 0.
+func (db *DB) SchemaVersion() (int, error) {
+	var version int
+	if err := db.conn.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// addColumnIfMissing adds a column to a table unless it already exists
+func (db *DB) addColumnIfMissing(table, column, definition string) error {
+	rows, err := db.conn.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return fmt.Errorf("failed to read %s schema: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid        int
+			name       string
+			columnType string
+			notNull    bool
+			defaultVal sql.NullString
+			primaryKey int
+		)
+		if err := rows.Scan(&cid, &name, &columnType, &notNull, &defaultVal, &primaryKey); err != nil {
+			return fmt.Errorf("failed to scan %s schema: %w", table, err)
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error reading %s schema: %w", table, err)
+	}
+	rows.Close()
+
+	if _, err := db.conn.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)); err != nil {
+		return fmt.Errorf("failed to add column %s.%s: %w", table, column, err)
+	}
+
 	return nil
 }
 
+// entryColumns lists the daily_time columns read by scanEntry, in order
+const entryColumns = `id, date, active_minutes, goal_minutes, is_paused, auto_logged,
+	       auto_log_response, auto_log_failures, pto, notes, abandoned, created_at, updated_at`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanEntry scans a row selected with entryColumns
+func scanEntry(row rowScanner) (*models.DailyTimeEntry, error) {
+	entry := &models.DailyTimeEntry{}
+	err := row.Scan(
+		&entry.ID, &entry.Date, &entry.ActiveMinutes, &entry.GoalMinutes,
+		&entry.IsPaused, &entry.AutoLogged, &entry.AutoLogResponse,
+		&entry.AutoLogFailures, &entry.IsPTO, &entry.Notes, &entry.Abandoned, &entry.CreatedAt, &entry.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
 // GetTodayEntry gets or creates today's time entry
 func (db *DB) GetTodayEntry() (*models.DailyTimeEntry, error) {
 	today := time.Now().Format("2006-01-02")
@@ -130,19 +347,12 @@ func (db *DB) GetTodayEntry() (*models.DailyTimeEntry, error) {
 
 // GetEntryForDate gets or creates a time entry for a specific date
 func (db *DB) GetEntryForDate(date string) (*models.DailyTimeEntry, error) {
-	entry := &models.DailyTimeEntry{}
-	
 	query := `
-	SELECT id, date, active_minutes, goal_minutes, is_paused, auto_logged, 
-	       auto_log_response, created_at, updated_at
+	SELECT ` + entryColumns + `
 	FROM daily_time 
 	WHERE date = ?`
 
-	err := db.conn.QueryRow(query, date).Scan(
-		&entry.ID, &entry.Date, &entry.ActiveMinutes, &entry.GoalMinutes,
-		&entry.IsPaused, &entry.AutoLogged, &entry.AutoLogResponse,
-		&entry.CreatedAt, &entry.UpdatedAt,
-	)
+	entry, err := scanEntry(db.conn.QueryRow(query, date))
 
 	if err == sql.ErrNoRows {
 		// Create new entry for this date
@@ -151,16 +361,26 @@ func (db *DB) GetEntryForDate(date string) (*models.DailyTimeEntry, error) {
 		return nil, fmt.Errorf("failed to query daily time entry: %w", err)
 	}
 
+	db.withPending(entry)
 	return entry, nil
 }
 
 // createEntryForDate creates a new time entry for a specific date
 func (db *DB) createEntryForDate(date string) (*models.DailyTimeEntry, error) {
+	goalMinutes := defaultGoalMinutes
+	if db.goalFor != nil {
+		day, err := time.ParseInLocation("2006-01-02", date, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q: %w", date, err)
+		}
+		goalMinutes = db.goalFor(day)
+	}
+
 	query := `
 	INSERT INTO daily_time (date, active_minutes, goal_minutes, is_paused, auto_logged)
-	VALUES (?, 0, 480, FALSE, FALSE)`
+	VALUES (?, 0, ?, FALSE, FALSE)`
 
-	result, err := db.conn.Exec(query, date)
+	result, err := db.conn.Exec(query, date, goalMinutes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create daily time entry: %w", err)
 	}
@@ -176,24 +396,18 @@ func (db *DB) createEntryForDate(date string) (*models.DailyTimeEntry, error) {
 
 // GetEntryByID gets a time entry by its ID
 func (db *DB) GetEntryByID(id int) (*models.DailyTimeEntry, error) {
-	entry := &models.DailyTimeEntry{}
-	
 	query := `
-	SELECT id, date, active_minutes, goal_minutes, is_paused, auto_logged,
-	       auto_log_response, created_at, updated_at
+	SELECT ` + entryColumns + `
 	FROM daily_time 
 	WHERE id = ?`
 
-	err := db.conn.QueryRow(query, id).Scan(
-		&entry.ID, &entry.Date, &entry.ActiveMinutes, &entry.GoalMinutes,
-		&entry.IsPaused, &entry.AutoLogged, &entry.AutoLogResponse,
-		&entry.CreatedAt, &entry.UpdatedAt,
-	)
+	entry, err := scanEntry(db.conn.QueryRow(query, id))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to get entry by ID %d: %w", id, err)
 	}
 
+	db.withPending(entry)
 	return entry, nil
 }
 
@@ -205,12 +419,22 @@ func (db *DB) IncrementActiveTime() error {
 // IncrementActiveTimeForDate adds one minute to the active time for a specific date
 func (db *DB) IncrementActiveTimeForDate(date string) error {
 	// First ensure the entry exists
-	_, err := db.GetEntryForDate(date)
+	entry, err := db.GetEntryForDate(date)
 	if err != nil {
 		return fmt.Errorf("failed to ensure entry exists: %w", err)
 	}
 
-	// Increment active minutes and update timestamp
+	if entry.IsPaused {
+		db.LogSystemEvent("increment_skipped_paused", fmt.Sprintf("Date: %s", date))
+		return nil
+	}
+
+	if db.batch != nil {
+		return db.queueMinute(entry)
+	}
+
+	// Increment active minutes and update timestamp. The pause check is
+	// repeated here in case the day was paused since the read above.
 	query := `
 	UPDATE daily_time 
 	SET active_minutes = active_minutes + 1,
@@ -228,13 +452,37 @@ func (db *DB) IncrementActiveTimeForDate(date string) error {
 	}
 
 	if rowsAffected == 0 {
-		// Entry might be paused, log this event
-		db.LogSystemEvent("increment_skipped_paused", fmt.Sprintf("Date: %s", date))
+		db.LogSystemEvent(db.incrementSkipReason(date), fmt.Sprintf("Date: %s", date))
+		return nil
+	}
+
+	// Remember the first minute the running total crossed the goal
+	query = `
+	UPDATE daily_time 
+	SET goal_reached_at = CURRENT_TIMESTAMP
+	WHERE date = ? AND goal_reached_at IS NULL AND goal_minutes > 0 AND active_minutes >= goal_minutes`
+
+	if _, err := db.conn.Exec(query, date); err != nil {
+		return fmt.Errorf("failed to record goal reached time: %w", err)
 	}
 
 	return nil
 }
 
+// incrementSkipReason names the skip event for an increment that matched no row:
+// either the day was paused in the meantime or its row no longer exists
+func (db *DB) incrementSkipReason(date string) string {
+	var paused bool
+	err := db.conn.QueryRow(`SELECT is_paused FROM daily_time WHERE date = ?`, date).Scan(&paused)
+	if err == sql.ErrNoRows {
+		return "increment_skipped_no_row"
+	}
+	if err != nil {
+		log.Printf("Error checking why increment was skipped for %s: %v", date, err)
+	}
+	return "increment_skipped_paused"
+}
+
 // SetPauseState sets the pause state for today's entry
 func (db *DB) SetPauseState(paused bool) error {
 	today := time.Now().Format("2006-01-02")
@@ -249,6 +497,11 @@ func (db *DB) SetPauseStateForDate(date string, paused bool) error {
 		return fmt.Errorf("failed to ensure entry exists: %w", err)
 	}
 
+	// Minutes credited before the pause are written with it
+	if err := db.Flush(); err != nil {
+		return err
+	}
+
 	query := `
 	UPDATE daily_time 
 	SET is_paused = ?, updated_at = CURRENT_TIMESTAMP
@@ -269,12 +522,65 @@ func (db *DB) SetPauseStateForDate(date string, paused bool) error {
 	return nil
 }
 
+// SetGoalForDate overrides the goal for a single date, independent of the
+// configured goal. The entry is created if it doesn't exist yet.
+func (db *DB) SetGoalForDate(date string, goalMinutes int) error {
+	if goalMinutes <= 0 {
+		return fmt.Errorf("goal must be positive, got %d minutes", goalMinutes)
+	}
+
+	entry, err := db.GetEntryForDate(date)
+	if err != nil {
+		return fmt.Errorf("failed to ensure entry exists: %w", err)
+	}
+
+	query := `
+	UPDATE daily_time 
+	SET goal_minutes = ?,
+	    goal_reached_at = CASE WHEN active_minutes >= ? THEN goal_reached_at ELSE NULL END,
+	    updated_at = CURRENT_TIMESTAMP
+	WHERE date = ?`
+
+	// A raised goal that is no longer met must be reached again
+	if _, err := db.conn.Exec(query, goalMinutes, goalMinutes, date); err != nil {
+		return fmt.Errorf("failed to set goal: %w", err)
+	}
+
+	log.Printf("Goal for %s changed from %d to %d minutes", date, entry.GoalMinutes, goalMinutes)
+	db.LogSystemEvent("goal_changed", fmt.Sprintf("Date: %s, From: %d, To: %d", date, entry.GoalMinutes, goalMinutes))
+
+	return nil
+}
+
+// AbandonEntry marks a day that is too old to log (api.max_backlog_days), so
+// it no longer counts as needing a log. It stays unlogged.
+func (db *DB) AbandonEntry(date string) error {
+	query := `
+	UPDATE daily_time 
+	SET abandoned = TRUE, updated_at = CURRENT_TIMESTAMP
+	WHERE date = ?`
+
+	if _, err := db.conn.Exec(query, date); err != nil {
+		return fmt.Errorf("failed to abandon %s: %w", date, err)
+	}
+
+	if err := db.RemovePendingLog(date); err != nil {
+		return err
+	}
+
+	db.LogSystemEvent("abandoned", fmt.Sprintf("Date: %s", date))
+	return nil
+}
+
 // MarkAsAutoLogged marks an entry as having been auto-logged
 func (db *DB) MarkAsAutoLogged(date string, response string) error {
 	query := `
 	UPDATE daily_time 
 	SET auto_logged = TRUE, 
 	    auto_log_response = ?,
+	    auto_log_failures = 0,
+	    logged_parts = 0,
+	    logged_parts_provider = '',
 	    updated_at = CURRENT_TIMESTAMP
 	WHERE date = ?`
 
@@ -283,10 +589,35 @@ func (db *DB) MarkAsAutoLogged(date string, response string) error {
 		return fmt.Errorf("failed to mark as auto-logged: %w", err)
 	}
 
+	if err := db.RemovePendingLog(date); err != nil {
+		return err
+	}
+
 	db.LogSystemEvent("auto_logged", fmt.Sprintf("Date: %s", date))
 	return nil
 }
 
+// RecordAutoLogFailure increments the failed auto-log attempt count for a date
+// and returns the new count
+func (db *DB) RecordAutoLogFailure(date string) (int, error) {
+	query := `
+	UPDATE daily_time 
+	SET auto_log_failures = auto_log_failures + 1,
+	    updated_at = CURRENT_TIMESTAMP
+	WHERE date = ?`
+
+	if _, err := db.conn.Exec(query, date); err != nil {
+		return 0, fmt.Errorf("failed to record auto-log failure: %w", err)
+	}
+
+	var failures int
+	if err := db.conn.QueryRow("SELECT auto_log_failures FROM daily_time WHERE date = ?", date).Scan(&failures); err != nil {
+		return 0, fmt.Errorf("failed to read auto-log failures: %w", err)
+	}
+
+	return failures, nil
+}
+
 // LogSystemEvent logs a system event for debugging
 func (db *DB) LogSystemEvent(eventType, details string) error {
 	query := `
@@ -301,11 +632,123 @@ func (db *DB) LogSystemEvent(eventType, details string) error {
 	return nil
 }
 
+// LastEventTime returns when the most recent event of a type was logged,
+// or the zero time if there is none
+func (db *DB) LastEventTime(eventType string) (time.Time, error) {
+	query := `
+	SELECT CAST(strftime('%s', timestamp) AS INTEGER) FROM system_events
+	WHERE event_type = ?
+	ORDER BY id DESC LIMIT 1`
+
+	var unix int64
+	err := db.conn.QueryRow(query, eventType).Scan(&unix)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get last %s event: %w", eventType, err)
+	}
+
+	return time.Unix(unix, 0), nil
+}
+
+// GetFirstActiveTime returns the time of the earliest active or resume event
+// on a date (YYYY-MM-DD, local time), or the zero time if there is none
+func (db *DB) GetFirstActiveTime(date string) (time.Time, error) {
+	day, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q: %w", date, err)
+	}
+
+	// Event timestamps are stored in UTC
+	const layout = "2006-01-02 15:04:05"
+	query := `
+	SELECT CAST(strftime('%s', MIN(timestamp)) AS INTEGER) FROM system_events
+	WHERE event_type IN ('active', 'resume') AND timestamp >= ? AND timestamp < ?`
+
+	var unix sql.NullInt64
+	err = db.conn.QueryRow(query, day.UTC().Format(layout), day.AddDate(0, 0, 1).UTC().Format(layout)).Scan(&unix)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get first active time: %w", err)
+	}
+	if !unix.Valid {
+		return time.Time{}, nil
+	}
+
+	return time.Unix(unix.Int64, 0), nil
+}
+
+// GetSystemEventsForDateRange returns the system events logged between start
+// and end (inclusive, YYYY-MM-DD, local time), oldest first
+func (db *DB) GetSystemEventsForDateRange(start, end string) ([]*models.SystemEvent, error) {
+	startDay, err := time.ParseInLocation("2006-01-02", start, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q: %w", start, err)
+	}
+	endDay, err := time.ParseInLocation("2006-01-02", end, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q: %w", end, err)
+	}
+
+	// Event timestamps are stored in UTC
+	const layout = "2006-01-02 15:04:05"
+	query := `
+	SELECT id, event_type, CAST(strftime('%s', timestamp) AS INTEGER), details FROM system_events
+	WHERE timestamp >= ? AND timestamp < ?
+	ORDER BY id ASC`
+
+	rows, err := db.conn.Query(query, startDay.UTC().Format(layout), endDay.AddDate(0, 0, 1).UTC().Format(layout))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query system events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*models.SystemEvent
+	for rows.Next() {
+		event := &models.SystemEvent{}
+		var unix int64
+		if err := rows.Scan(&event.ID, &event.EventType, &unix, &event.Details); err != nil {
+			return nil, fmt.Errorf("failed to scan system event: %w", err)
+		}
+		event.Timestamp = time.Unix(unix, 0)
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating system events: %w", err)
+	}
+
+	return events, nil
+}
+
+// GetIdleMinutes returns how many active minutes were skipped due to idle on a date
+func (db *DB) GetIdleMinutes(date string) (int, error) {
+	return db.countSkippedMinutes("increment_skipped_idle", date)
+}
+
+// GetBreakMinutes returns how many active minutes fell inside break windows on a date
+func (db *DB) GetBreakMinutes(date string) (int, error) {
+	return db.countSkippedMinutes("increment_skipped_break", date)
+}
+
+// countSkippedMinutes counts the per-minute skip events of one type for a date
+func (db *DB) countSkippedMinutes(eventType, date string) (int, error) {
+	query := `
+	SELECT COUNT(*) FROM system_events
+	WHERE event_type = ? AND details = ?`
+
+	var minutes int
+	if err := db.conn.QueryRow(query, eventType, fmt.Sprintf("Date: %s", date)).Scan(&minutes); err != nil {
+		return 0, fmt.Errorf("failed to count %s minutes: %w", eventType, err)
+	}
+
+	return minutes, nil
+}
+
 // GetRecentEntries returns the most recent time entries
 func (db *DB) GetRecentEntries(limit int) ([]*models.DailyTimeEntry, error) {
 	query := `
-	SELECT id, date, active_minutes, goal_minutes, is_paused, auto_logged,
-	       auto_log_response, created_at, updated_at
+	SELECT ` + entryColumns + `
 	FROM daily_time 
 	ORDER BY date DESC
 	LIMIT ?`
@@ -318,12 +761,7 @@ func (db *DB) GetRecentEntries(limit int) ([]*models.DailyTimeEntry, error) {
 
 	var entries []*models.DailyTimeEntry
 	for rows.Next() {
-		entry := &models.DailyTimeEntry{}
-		err := rows.Scan(
-			&entry.ID, &entry.Date, &entry.ActiveMinutes, &entry.GoalMinutes,
-			&entry.IsPaused, &entry.AutoLogged, &entry.AutoLogResponse,
-			&entry.CreatedAt, &entry.UpdatedAt,
-		)
+		entry, err := scanEntry(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan entry: %w", err)
 		}
@@ -334,6 +772,7 @@ func (db *DB) GetRecentEntries(limit int) ([]*models.DailyTimeEntry, error) {
 		return nil, fmt.Errorf("error iterating entries: %w", err)
 	}
 
+	db.withPending(entries...)
 	return entries, nil
 }
 
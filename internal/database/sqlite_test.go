@@ -0,0 +1,110 @@
+package database
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+)
+
+// newTestDB opens a fresh database in a temp directory
+func newTestDB(tb testing.TB) *DB {
+	tb.Helper()
+	db, err := NewDB(filepath.Join(tb.TempDir(), "timeclip.db"))
+	if err != nil {
+		tb.Fatalf("NewDB: %v", err)
+	}
+	tb.Cleanup(func() { db.Close() })
+	return db
+}
+
+// storedMinutes reads active_minutes for date straight from daily_time
+func storedMinutes(t *testing.T, db *DB, date string) int {
+	t.Helper()
+	var minutes int
+	if err := db.conn.QueryRow(`SELECT active_minutes FROM daily_time WHERE date = ?`, date).Scan(&minutes); err != nil {
+		t.Fatalf("reading %s: %v", date, err)
+	}
+	return minutes
+}
+
+// creditMinutes increments date count times
+func creditMinutes(t *testing.T, db *DB, date string, count int) {
+	t.Helper()
+	for i := 0; i < count; i++ {
+		if err := db.IncrementActiveTimeForDate(date); err != nil {
+			t.Fatalf("IncrementActiveTimeForDate: %v", err)
+		}
+	}
+}
+
+// oldSchema is daily_time and pending_logs as created before any of the
+// columns added by migrateSchema existed
+const oldSchema = `
+CREATE TABLE daily_time (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	date TEXT UNIQUE NOT NULL,
+	active_minutes INTEGER DEFAULT 0,
+	goal_minutes INTEGER DEFAULT 480,
+	is_paused BOOLEAN DEFAULT FALSE,
+	auto_logged BOOLEAN DEFAULT FALSE,
+	auto_log_response TEXT DEFAULT '',
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE TABLE pending_logs (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	date TEXT UNIQUE NOT NULL,
+	attempts INTEGER DEFAULT 0,
+	last_error TEXT DEFAULT '',
+	last_attempt_at DATETIME,
+	next_attempt_at DATETIME,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+INSERT INTO daily_time (date, active_minutes, goal_minutes, auto_logged) VALUES ('2023-11-20', 455, 480, TRUE);`
+
+func TestMigrateOldDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "timeclip.db")
+	old, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	if _, err := old.Exec(oldSchema); err != nil {
+		t.Fatalf("creating the old schema: %v", err)
+	}
+	old.Close()
+
+	// Opening twice must be harmless
+	for i := 0; i < 2; i++ {
+		db, err := NewDB(path)
+		if err != nil {
+			t.Fatalf("NewDB (open %d): %v", i+1, err)
+		}
+
+		version, err := db.SchemaVersion()
+		if err != nil {
+			t.Fatalf("SchemaVersion: %v", err)
+		}
+		if version != schemaVersion {
+			t.Errorf("schema version = %d, want %d", version, schemaVersion)
+		}
+
+		entry, err := db.GetEntryForDate("2023-11-20")
+		if err != nil {
+			t.Fatalf("GetEntryForDate: %v", err)
+		}
+		if entry.ActiveMinutes != 455 || !entry.AutoLogged || entry.IsPTO || entry.Notes != "" || entry.AutoLogFailures != 0 {
+			t.Errorf("migrated day = %+v, want the old values with new columns at their defaults", entry)
+		}
+		db.Close()
+	}
+}
+
+func TestNewDatabaseSchemaVersion(t *testing.T) {
+	version, err := newTestDB(t).SchemaVersion()
+	if err != nil {
+		t.Fatalf("SchemaVersion: %v", err)
+	}
+	if version != schemaVersion {
+		t.Errorf("schema version = %d, want %d", version, schemaVersion)
+	}
+}
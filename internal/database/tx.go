@@ -0,0 +1,25 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// withTx runs fn in a transaction, committing if it returns nil and rolling
+// back otherwise
+func (db *DB) withTx(fn func(tx *sql.Tx) error) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
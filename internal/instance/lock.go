@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"syscall"
 	"time"
+
+	"timeclip/internal/database"
 )
 
 // Lock represents a single instance lock
@@ -14,15 +16,14 @@ type Lock struct {
 	lockPath string
 }
 
-// NewLock creates a new single instance lock
-func NewLock() (*Lock, error) {
-	// Get lock file path in the same directory as config
-	homeDir, err := os.UserHomeDir()
+// NewLock creates a new single instance lock in the data directory
+// (see models.Config.DataDir)
+func NewLock(dataDir string) (*Lock, error) {
+	lockDir, err := database.ExpandPath(dataDir)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user home directory: %w", err)
+		return nil, err
 	}
 	
-	lockDir := filepath.Join(homeDir, ".timeclip")
 	if err := os.MkdirAll(lockDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create lock directory: %w", err)
 	}
@@ -157,25 +158,129 @@ func (l *Lock) WaitForLockRelease(timeout time.Duration) error {
 	
 	fmt.Printf("⏳ Another instance is running, waiting up to %v for it to exit...\n", timeout)
 	
-	start := time.Now()
 	ticker := time.NewTicker(500 * time.Millisecond)
 	defer ticker.Stop()
+
+	// A single deadline for the whole wait
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
 	
 	for {
 		select {
 		case <-ticker.C:
-			if time.Since(start) >= timeout {
-				return fmt.Errorf("timeout waiting for other instance to exit")
-			}
-			
 			// Try to acquire lock
 			if err := l.TryLock(); err == nil {
 				fmt.Println("✅ Lock acquired, continuing...")
 				return nil
 			}
 			
-		case <-time.After(timeout):
+		case <-deadline.C:
+			if status, err := l.Status(); err == nil && status.Exists {
+				return fmt.Errorf("timeout waiting for other instance to exit (lock file %s, %s)", status.Path, status.Holder())
+			}
 			return fmt.Errorf("timeout waiting for other instance to exit")
 		}
 	}
-}
\ No newline at end of file
+}
+
+// Status describes the lock file and the process that wrote it
+type Status struct {
+	Path   string    `json:"path"`
+	Exists bool      `json:"exists"`
+	PID    int       `json:"pid"`
+	Since  time.Time `json:"since"` // When the lock file was written
+	Alive  bool      `json:"alive"` // The PID exists (kill -0)
+	State  string    `json:"state"` // The PID's process state from ps, e.g. "zombie"; empty if unknown
+	Held   bool      `json:"held"`  // Some process holds the file lock
+}
+
+// IsZombie returns true if the recorded PID has exited but not been reaped
+func (s *Status) IsZombie() bool {
+	return s.State == ProcessZombie
+}
+
+// IsStale returns true if the lock file exists but nothing holds it
+func (s *Status) IsStale() bool {
+	return s.Exists && !s.Held && (!s.Alive || s.IsZombie())
+}
+
+// IsOrphaned returns true if the file lock is held although the process
+// that wrote the lock file is gone or a zombie, e.g. when a child process
+// inherited the lock
+func (s *Status) IsOrphaned() bool {
+	return s.Exists && s.Held && (!s.Alive || s.IsZombie())
+}
+
+// Holder describes the recorded PID and its state, e.g. "PID 123, zombie"
+func (s *Status) Holder() string {
+	state := s.State
+	if state == "" {
+		state = ProcessGone
+		if s.Alive {
+			state = ProcessRunning
+		}
+	}
+	return fmt.Sprintf("PID %d, %s", s.PID, state)
+}
+
+// Status reports who holds the lock without acquiring it
+func (l *Lock) Status() (*Status, error) {
+	status := &Status{Path: l.lockPath}
+
+	file, err := os.OpenFile(l.lockPath, os.O_RDONLY, 0644)
+	if os.IsNotExist(err) {
+		return status, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+	defer file.Close()
+
+	status.Exists = true
+	if info, err := file.Stat(); err == nil {
+		status.Since = info.ModTime()
+	}
+	fmt.Fscanf(file, "%d", &status.PID)
+
+	if status.PID > 0 {
+		err := syscall.Kill(status.PID, 0)
+		status.Alive = err == nil || err == syscall.EPERM
+		if status.Alive {
+			status.State = processState(status.PID)
+		}
+	}
+
+	// Probe the file lock; if we get it, nobody else holds it
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		if err != syscall.EWOULDBLOCK {
+			return nil, fmt.Errorf("failed to check lock: %w", err)
+		}
+		status.Held = true
+	} else {
+		syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+	}
+
+	return status, nil
+}
+
+// ForceUnlock removes a stale or orphaned lock file. It refuses while the
+// recorded PID is still alive and not a zombie. Removing an orphaned file
+// lets a new instance create a fresh one; whatever holds the old file keeps
+// its lock on that.
+func (l *Lock) ForceUnlock() error {
+	status, err := l.Status()
+	if err != nil {
+		return err
+	}
+	if !status.Exists {
+		return nil
+	}
+	if !status.IsStale() && !status.IsOrphaned() {
+		return fmt.Errorf("lock is in use by %s; quit that process instead", status.Holder())
+	}
+
+	if err := os.Remove(l.lockPath); err != nil {
+		return fmt.Errorf("failed to remove lock file: %w", err)
+	}
+	return nil
+}
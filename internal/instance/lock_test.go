@@ -0,0 +1,169 @@
+package instance
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// newTestLock returns a lock in a fresh data directory
+func newTestLock(t *testing.T) *Lock {
+	t.Helper()
+	lock, err := NewLock(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLock: %v", err)
+	}
+	t.Cleanup(func() { lock.Release() })
+	return lock
+}
+
+// writeLockFile leaves a lock file for pid that nothing holds
+func writeLockFile(t *testing.T, lock *Lock, pid int) {
+	t.Helper()
+	if err := os.WriteFile(lock.GetLockPath(), []byte(fmt.Sprintf("%d\n", pid)), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+}
+
+// exitedPID returns the PID of a process that has exited and been reaped
+func exitedPID(t *testing.T) int {
+	t.Helper()
+	cmd := exec.Command("true")
+	if err := cmd.Run(); err != nil {
+		t.Skipf("can't run a process: %v", err)
+	}
+	return cmd.Process.Pid
+}
+
+func TestStatusPredicates(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   Status
+		zombie   bool
+		stale    bool
+		orphaned bool
+		holder   string
+	}{
+		{"running holder", Status{Exists: true, PID: 10, Alive: true, State: ProcessSleeping, Held: true}, false, false, false, "PID 10, sleeping"},
+		{"dead, not held", Status{Exists: true, PID: 10}, false, true, false, "PID 10, not running"},
+		{"zombie, not held", Status{Exists: true, PID: 10, Alive: true, State: ProcessZombie}, true, true, false, "PID 10, zombie"},
+		{"zombie, held by a child", Status{Exists: true, PID: 10, Alive: true, State: ProcessZombie, Held: true}, true, false, true, "PID 10, zombie"},
+		{"alive, state unknown", Status{Exists: true, PID: 10, Alive: true, Held: true}, false, false, false, "PID 10, running"},
+		{"no lock file", Status{}, false, false, false, "PID 0, not running"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.status.IsZombie(); got != tc.zombie {
+				t.Errorf("IsZombie = %v, want %v", got, tc.zombie)
+			}
+			if got := tc.status.IsStale(); got != tc.stale {
+				t.Errorf("IsStale = %v, want %v", got, tc.stale)
+			}
+			if got := tc.status.IsOrphaned(); got != tc.orphaned {
+				t.Errorf("IsOrphaned = %v, want %v", got, tc.orphaned)
+			}
+			if got := tc.status.Holder(); got != tc.holder {
+				t.Errorf("Holder = %q, want %q", got, tc.holder)
+			}
+		})
+	}
+}
+
+func TestLockStatusWhileHeld(t *testing.T) {
+	lock := newTestLock(t)
+
+	status, err := lock.Status()
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if status.Exists {
+		t.Errorf("Status = %+v before locking, want no lock file", status)
+	}
+
+	if err := lock.TryLock(); err != nil {
+		t.Fatalf("TryLock: %v", err)
+	}
+	status, err = lock.Status()
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if !status.Exists || !status.Held || !status.Alive || status.PID != os.Getpid() {
+		t.Errorf("Status = %+v, want held by this process", status)
+	}
+	if status.IsStale() || status.IsOrphaned() {
+		t.Errorf("Status = %+v, want neither stale nor orphaned", status)
+	}
+
+	// A second instance is turned away, and can't force the lock
+	other := &Lock{lockPath: lock.GetLockPath()}
+	if err := other.TryLock(); err == nil || !strings.Contains(err.Error(), "already running") {
+		t.Errorf("second TryLock = %v, want already running", err)
+	}
+	if err := other.ForceUnlock(); err == nil {
+		t.Error("ForceUnlock succeeded on a lock in use")
+	}
+	if _, err := os.Stat(lock.GetLockPath()); err != nil {
+		t.Errorf("lock file gone after a refused ForceUnlock: %v", err)
+	}
+}
+
+func TestForceUnlockStaleLock(t *testing.T) {
+	lock := newTestLock(t)
+	writeLockFile(t, lock, exitedPID(t))
+
+	status, err := lock.Status()
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if !status.IsStale() {
+		t.Fatalf("Status = %+v, want stale", status)
+	}
+
+	if err := lock.ForceUnlock(); err != nil {
+		t.Fatalf("ForceUnlock: %v", err)
+	}
+	if _, err := os.Stat(lock.GetLockPath()); !os.IsNotExist(err) {
+		t.Errorf("lock file still there after ForceUnlock: %v", err)
+	}
+	if err := lock.TryLock(); err != nil {
+		t.Errorf("TryLock after ForceUnlock: %v", err)
+	}
+}
+
+func TestZombieLockIsStale(t *testing.T) {
+	if _, err := exec.LookPath("ps"); err != nil {
+		t.Skip("ps not available")
+	}
+	lock := newTestLock(t)
+	writeLockFile(t, lock, startZombie(t))
+
+	status, err := lock.Status()
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if !status.Alive || !status.IsZombie() || !status.IsStale() {
+		t.Fatalf("Status = %+v, want a stale lock from a zombie", status)
+	}
+	if err := lock.ForceUnlock(); err != nil {
+		t.Errorf("ForceUnlock: %v", err)
+	}
+}
+
+func TestTryLockReplacesStaleLockFile(t *testing.T) {
+	lock := newTestLock(t)
+	writeLockFile(t, lock, exitedPID(t))
+
+	if err := lock.TryLock(); err != nil {
+		t.Fatalf("TryLock over a stale lock file: %v", err)
+	}
+	status, err := lock.Status()
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if status.PID != os.Getpid() {
+		t.Errorf("lock file PID = %d, want this process", status.PID)
+	}
+}
@@ -0,0 +1,56 @@
+package instance
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Process states reported by processState
+const (
+	ProcessRunning  = "running"
+	ProcessSleeping = "sleeping"
+	ProcessStopped  = "stopped"
+	ProcessZombie   = "zombie"
+	ProcessGone     = "not running"
+)
+
+// processState returns the state of pid as reported by ps, or "" when it
+// can't be determined. A zombie (defunct) process has exited but not been
+// reaped by its parent: kill -0 still succeeds, yet it holds no files.
+func processState(pid int) string {
+	if pid <= 0 {
+		return ""
+	}
+
+	output, err := exec.Command("ps", "-o", "stat=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		// ps exits non-zero when there is no such process
+		if _, ok := err.(*exec.ExitError); ok {
+			return ProcessGone
+		}
+		return ""
+	}
+	return parseProcessState(string(output))
+}
+
+// parseProcessState maps the first letter of a ps STAT column to a state
+func parseProcessState(stat string) string {
+	stat = strings.TrimSpace(stat)
+	if stat == "" {
+		return ProcessGone
+	}
+
+	switch stat[0] {
+	case 'Z':
+		return ProcessZombie
+	case 'T':
+		return ProcessStopped
+	case 'R':
+		return ProcessRunning
+	case 'S', 'I', 'D', 'U':
+		return ProcessSleeping
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,67 @@
+package instance
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func TestParseProcessState(t *testing.T) {
+	tests := []struct {
+		stat string
+		want string
+	}{
+		{"Z+\n", ProcessZombie},
+		{"T", ProcessStopped},
+		{"R+", ProcessRunning},
+		{"Ss", ProcessSleeping},
+		{"I<", ProcessSleeping},
+		{"D", ProcessSleeping},
+		{"U", ProcessSleeping},
+		{"  \n", ProcessGone},
+		{"X", ""},
+	}
+
+	for _, tc := range tests {
+		if got := parseProcessState(tc.stat); got != tc.want {
+			t.Errorf("parseProcessState(%q) = %q, want %q", tc.stat, got, tc.want)
+		}
+	}
+}
+
+// startZombie starts a process that exits at once and isn't reaped until
+// the test ends, and returns its PID once it is a zombie
+func startZombie(t *testing.T) int {
+	t.Helper()
+	cmd := exec.Command("true")
+	if err := cmd.Start(); err != nil {
+		t.Skipf("can't start a process: %v", err)
+	}
+	t.Cleanup(func() { cmd.Wait() })
+
+	deadline := time.Now().Add(5 * time.Second)
+	for processState(cmd.Process.Pid) != ProcessZombie {
+		if time.Now().After(deadline) {
+			t.Skip("child never showed up as a zombie in ps")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return cmd.Process.Pid
+}
+
+func TestProcessState(t *testing.T) {
+	if _, err := exec.LookPath("ps"); err != nil {
+		t.Skip("ps not available")
+	}
+
+	if got := processState(os.Getpid()); got != ProcessRunning && got != ProcessSleeping {
+		t.Errorf("processState(self) = %q, want running or sleeping", got)
+	}
+	if got := processState(0); got != "" {
+		t.Errorf("processState(0) = %q, want unknown", got)
+	}
+	if got := processState(startZombie(t)); got != ProcessZombie {
+		t.Errorf("processState(zombie) = %q, want %q", got, ProcessZombie)
+	}
+}
@@ -0,0 +1,61 @@
+package instance
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"timeclip/internal/models"
+	"timeclip/internal/notify"
+)
+
+// AcquireAtStartup takes the single instance lock for the menu bar app,
+// waiting up to general.startup_lock_wait_seconds for a previous instance to
+// exit. Launched as a login item there is no terminal to print to, so giving
+// up also raises a notification. With general.force_unlock_orphaned_lock a
+// lock whose recorded process is gone or a zombie is removed instead.
+func AcquireAtStartup(config *models.Config) (*Lock, error) {
+	lock, err := NewLock(config.DataDir())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lock.TryLock(); err == nil {
+		return lock, nil
+	}
+
+	wait := time.Duration(config.General.StartupLockWaitSeconds) * time.Second
+	if err := lock.WaitForLockRelease(wait); err != nil {
+		if config.General.ForceUnlockOrphanedLock && forceOrphanedUnlock(lock) {
+			return lock, nil
+		}
+
+		message := "Another Timeclip is still running. Quit it, or run timeclip --lock-status if it seems stuck."
+		if notifyErr := notify.Send("Timeclip", message); notifyErr != nil {
+			log.Printf("Error sending notification: %v", notifyErr)
+		}
+		return nil, fmt.Errorf("failed to acquire instance lock: %w", err)
+	}
+
+	return lock, nil
+}
+
+// forceOrphanedUnlock removes the lock file when the process recorded in it
+// is gone or a zombie and takes the lock. It returns true on success.
+func forceOrphanedUnlock(lock *Lock) bool {
+	status, err := lock.Status()
+	if err != nil || !(status.IsOrphaned() || status.IsStale()) {
+		return false
+	}
+
+	log.Printf("⚠️  Lock is held although its process is gone (%s); removing it", status.Holder())
+	if err := lock.ForceUnlock(); err != nil {
+		log.Printf("Error removing orphaned lock: %v", err)
+		return false
+	}
+	if err := lock.TryLock(); err != nil {
+		log.Printf("Error taking the lock after removing it: %v", err)
+		return false
+	}
+	return true
+}
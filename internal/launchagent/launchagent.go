@@ -0,0 +1,168 @@
+package launchagent
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"text/template"
+
+	"timeclip/internal/database"
+	"timeclip/internal/models"
+)
+
+// Label is the launchd label used for the Timeclip agent
+const Label = "com.timeclip"
+
+// plistTemplate is the launchd property list that starts Timeclip at login
+var plistTemplate = template.Must(template.New("plist").Funcs(template.FuncMap{
+	"xml": escapeXML,
+}).Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>{{xml .Label}}</string>
+	<key>ProgramArguments</key>
+	<array>
+		<string>{{xml .Executable}}</string>
+	</array>
+	<key>RunAtLoad</key>
+	<true/>
+	<key>KeepAlive</key>
+	<true/>
+	<key>StandardOutPath</key>
+	<string>{{xml .LogPath}}</string>
+	<key>StandardErrorPath</key>
+	<string>{{xml .LogPath}}</string>
+{{- if .DataDirEnv}}
+	<key>EnvironmentVariables</key>
+	<dict>
+		<key>{{xml .DataDirEnv}}</key>
+		<string>{{xml .DataDirValue}}</string>
+	</dict>
+{{- end}}
+</dict>
+</plist>
+`))
+
+// Path returns the location of the Timeclip launch agent plist
+func Path() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+
+	return filepath.Join(homeDir, "Library", "LaunchAgents", Label+".plist"), nil
+}
+
+// Install writes a launch agent that starts the given executable at login,
+// validates it and loads it with launchctl. It returns the plist path. Output
+// is logged to timeclip.log in dataDir, and a $TIMECLIP_HOME set now is
+// passed on so the agent uses the same data directory.
+func Install(executable, dataDir string) (string, error) {
+	plistPath, err := Path()
+	if err != nil {
+		return "", err
+	}
+
+	executable, err = filepath.Abs(executable)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve executable path: %w", err)
+	}
+
+	logDir, err := database.ExpandPath(dataDir)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create data directory %s: %w", logDir, err)
+	}
+
+	var dataDirEnv, dataDirValue string
+	if value := os.Getenv(models.DataDirEnv); value != "" {
+		dataDirEnv, dataDirValue = models.DataDirEnv, value
+	}
+
+	var buf bytes.Buffer
+	err = plistTemplate.Execute(&buf, struct {
+		Label        string
+		Executable   string
+		LogPath      string
+		DataDirEnv   string
+		DataDirValue string
+	}{
+		Label:        Label,
+		Executable:   executable,
+		LogPath:      filepath.Join(logDir, "timeclip.log"),
+		DataDirEnv:   dataDirEnv,
+		DataDirValue: dataDirValue,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to render launch agent: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(plistPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create LaunchAgents directory: %w", err)
+	}
+
+	if err := os.WriteFile(plistPath, buf.Bytes(), 0644); err != nil {
+		return "", fmt.Errorf("failed to write launch agent %s: %w", plistPath, err)
+	}
+
+	// Validate the plist before handing it to launchd
+	if output, err := exec.Command("plutil", "-lint", plistPath).CombinedOutput(); err != nil {
+		os.Remove(plistPath)
+		return "", fmt.Errorf("launch agent failed validation: %s", bytes.TrimSpace(output))
+	}
+
+	// Reload in case an older version of the agent is already loaded
+	exec.Command("launchctl", "unload", plistPath).Run()
+	if output, err := exec.Command("launchctl", "load", plistPath).CombinedOutput(); err != nil {
+		return plistPath, fmt.Errorf("failed to load launch agent: %s", bytes.TrimSpace(output))
+	}
+
+	return plistPath, nil
+}
+
+// Uninstall unloads and removes the launch agent. It returns the plist path.
+func Uninstall() (string, error) {
+	plistPath, err := Path()
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := os.Stat(plistPath); os.IsNotExist(err) {
+		return plistPath, fmt.Errorf("launch agent is not installed")
+	}
+
+	if output, err := exec.Command("launchctl", "unload", plistPath).CombinedOutput(); err != nil {
+		// Continue with removal; the agent may simply not be loaded
+		fmt.Printf("Warning: launchctl unload failed: %s\n", bytes.TrimSpace(output))
+	}
+
+	if err := os.Remove(plistPath); err != nil {
+		return plistPath, fmt.Errorf("failed to remove launch agent: %w", err)
+	}
+
+	return plistPath, nil
+}
+
+// IsInstalled returns true if the launch agent plist exists
+func IsInstalled() bool {
+	plistPath, err := Path()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(plistPath)
+	return err == nil
+}
+
+// escapeXML escapes a string for use inside a plist <string> element
+func escapeXML(value string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(value))
+	return buf.String()
+}
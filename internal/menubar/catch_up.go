@@ -0,0 +1,59 @@
+package menubar
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// catchUpCheckInterval is how often the menu checks for a held catch-up
+const catchUpCheckInterval = time.Minute
+
+// CatchUpApprover reports queued days held for approval and approves them
+type CatchUpApprover interface {
+	PendingApproval() int
+	ApprovePending() error
+}
+
+// addCatchUpItem creates the "Approve Pending Logs" item, shown only while a
+// catch-up is held. Must be called with smb.mu held.
+func (smb *SystrayMenuBar) addCatchUpItem() {
+	item := smb.tray.AddMenuItem("Approve Pending Logs", "Submit the days waiting in the offline queue")
+	item.Hide()
+
+	go smb.watchCatchUp(item)
+	go smb.handleCatchUpClicks(item)
+}
+
+// watchCatchUp shows the item with the number of held days while there are any
+func (smb *SystrayMenuBar) watchCatchUp(item trayMenuItem) {
+	ticker := time.NewTicker(catchUpCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		smb.refreshCatchUpItem(item)
+		<-ticker.C
+	}
+}
+
+// refreshCatchUpItem updates the item from the current number of held days
+func (smb *SystrayMenuBar) refreshCatchUpItem(item trayMenuItem) {
+	waiting := smb.catchUpApprover.PendingApproval()
+	if waiting == 0 {
+		item.Hide()
+		return
+	}
+	item.SetTitle(fmt.Sprintf("Approve %d Pending Days", waiting))
+	item.Show()
+}
+
+// handleCatchUpClicks approves the held days when the item is clicked
+func (smb *SystrayMenuBar) handleCatchUpClicks(item trayMenuItem) {
+	for range item.Clicked() {
+		if err := smb.catchUpApprover.ApprovePending(); err != nil {
+			log.Printf("Error approving pending logs: %v", err)
+			continue
+		}
+		item.Hide()
+	}
+}
@@ -0,0 +1,64 @@
+package menubar
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"timeclip/internal/notify"
+)
+
+// Quit confirmation choices, in dialog order
+const (
+	quitCancel     = "Cancel"
+	quitAnyway     = "Quit Anyway"
+	quitLogAndQuit = "Log and Quit"
+)
+
+// askQuit shows the quit confirmation dialog
+var askQuit = notify.Ask
+
+// confirmQuit asks before quitting while today has unlogged time, when
+// ui.confirm_quit_unlogged is set. It returns false if quitting was cancelled,
+// either with the Cancel button or by dismissing the dialog.
+// Days below api.log_on_quit_min_minutes don't ask, and neither does
+// api.log_on_quit since it logs on quit anyway.
+func (smb *SystrayMenuBar) confirmQuit() bool {
+	smb.mu.RLock()
+	config := smb.config
+	stats := smb.currentStats
+	smb.mu.RUnlock()
+
+	if !config.UI.ConfirmQuitUnlogged || config.API.LogOnQuit || config.General.ObserverMode || stats == nil {
+		return true
+	}
+	if stats.IsLogged || stats.IsPTO || stats.Error != "" || stats.ActiveMinutes == 0 || stats.ActiveMinutes < config.API.LogOnQuitMinMinutes {
+		return true
+	}
+
+	message := fmt.Sprintf("%.1fh tracked today hasn't been logged yet.", float64(stats.ActiveMinutes)/60.0)
+	choice, err := askQuit("Quit Timeclip", message, quitCancel, quitAnyway, quitLogAndQuit)
+	if errors.Is(err, notify.ErrCanceled) {
+		return false
+	}
+	if err != nil {
+		// Never trap the user in the app because the dialog failed
+		log.Printf("Error asking to confirm quit: %v", err)
+		return true
+	}
+
+	switch choice {
+	case quitLogAndQuit:
+		if smb.logTodayHandler == nil {
+			log.Println("No handler to log today before quitting")
+			return true
+		}
+		if err := smb.logTodayHandler(); err != nil {
+			log.Printf("❌ Failed to log today before quitting: %v", err)
+		}
+		return true
+	case quitAnyway:
+		return true
+	}
+	return false
+}
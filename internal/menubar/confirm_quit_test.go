@@ -0,0 +1,45 @@
+package menubar
+
+import (
+	"errors"
+	"testing"
+
+	"timeclip/internal/models"
+	"timeclip/internal/notify"
+)
+
+func TestConfirmQuit(t *testing.T) {
+	tests := []struct {
+		name   string
+		choice string
+		err    error
+		want   bool
+	}{
+		{"canceled", "", notify.ErrCanceled, false}, // Cancel button or Escape
+		{"cancel returned", quitCancel, nil, false},
+		{"quit anyway", quitAnyway, nil, true},
+		{"dialog failed", "", errors.New("osascript not found"), true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			smb, _ := newTestMenuBar()
+			smb.config.UI.ConfirmQuitUnlogged = true
+			smb.currentStats = statsFor(models.DailyTimeEntry{ActiveMinutes: 150}, true)
+
+			asked := 0
+			askQuit = func(title, message string, buttons ...string) (string, error) {
+				asked++
+				return tc.choice, tc.err
+			}
+			t.Cleanup(func() { askQuit = notify.Ask })
+
+			if got := smb.confirmQuit(); got != tc.want {
+				t.Errorf("confirmQuit = %v, want %v", got, tc.want)
+			}
+			if asked != 1 {
+				t.Errorf("dialog shown %d times, want 1", asked)
+			}
+		})
+	}
+}
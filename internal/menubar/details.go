@@ -0,0 +1,230 @@
+package menubar
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"timeclip/internal/models"
+)
+
+// DayReportSource provides the data for the "Today's Details..." report
+// (*database.DB implements it)
+type DayReportSource interface {
+	GetEntryForDate(date string) (*models.DailyTimeEntry, error)
+	GetSystemEventsForDateRange(start, end string) ([]*models.SystemEvent, error)
+}
+
+// SetDayReportSource enables the "Today's Details..." item, which opens an
+// HTML breakdown of today built from the system events. Must be called
+// before Run.
+func (smb *SystrayMenuBar) SetDayReportSource(source DayReportSource) {
+	smb.dayReportSource = source
+}
+
+// addDetailsItem creates the "Today's Details..." item. Must be called with
+// smb.mu held.
+func (smb *SystrayMenuBar) addDetailsItem() {
+	item := smb.tray.AddMenuItem("Today's Details...", "Timeline, breaks, idle time and logging status for today")
+	go smb.handleDetailsClicks(item)
+}
+
+// handleDetailsClicks writes today's report to a temporary file and opens it
+func (smb *SystrayMenuBar) handleDetailsClicks(item trayMenuItem) {
+	for range item.Clicked() {
+		if err := smb.openDayReport(time.Now()); err != nil {
+			log.Printf("Error opening today's details: %v", err)
+		}
+	}
+}
+
+// openDayReport builds the report for now's day and opens it in the browser
+func (smb *SystrayMenuBar) openDayReport(now time.Time) error {
+	date := now.Format("2006-01-02")
+	entry, err := smb.dayReportSource.GetEntryForDate(date)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", date, err)
+	}
+	events, err := smb.dayReportSource.GetSystemEventsForDateRange(date, date)
+	if err != nil {
+		return fmt.Errorf("failed to read events for %s: %w", date, err)
+	}
+
+	page, err := renderDayReport(buildDayReport(entry, events, now))
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp("", "timeclip-"+date+"-*.html")
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+	if _, err := file.Write(page); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write report file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close report file: %w", err)
+	}
+
+	if err := exec.Command(fileManagerCommand, file.Name()).Start(); err != nil {
+		return fmt.Errorf("failed to open %s: %w", file.Name(), err)
+	}
+	return nil
+}
+
+// daySegment is a stretch of the day in one tracking state
+type daySegment struct {
+	Start, End time.Time
+	State      string // "active", "inactive", "paused" or "unknown" before the first event
+}
+
+// Minutes returns the segment's length in whole minutes
+func (s daySegment) Minutes() int {
+	return int(s.End.Sub(s.Start) / time.Minute)
+}
+
+// dayReport is the data shown in the details page
+type dayReport struct {
+	Entry          *models.DailyTimeEntry
+	Segments       []daySegment
+	BreakMinutes   int // Active minutes not credited because of general.breaks
+	IdleMinutes    int // Active minutes not credited because of the idle threshold
+	OutsideMinutes int // Active minutes outside the work window
+	WarmupMinutes  int // Active minutes during the post-wake warm-up
+	LogStatus      string
+	GeneratedAt    time.Time
+}
+
+// buildDayReport turns a day's entry and system events into a report. The
+// timeline follows the active/inactive and pause/resume events; the day
+// runs to now for today and to midnight for earlier days.
+func buildDayReport(entry *models.DailyTimeEntry, events []*models.SystemEvent, now time.Time) *dayReport {
+	report := &dayReport{Entry: entry, GeneratedAt: now, LogStatus: logStatus(entry)}
+
+	day, err := time.ParseInLocation("2006-01-02", entry.Date, now.Location())
+	if err != nil {
+		return report
+	}
+	end := day.AddDate(0, 0, 1)
+	if now.Before(end) {
+		end = now
+	}
+
+	current := daySegment{Start: day, State: "unknown"}
+	systemActive, paused, known := false, false, false
+	for _, event := range events {
+		switch event.EventType {
+		case "active", "inactive":
+			systemActive, known = event.EventType == "active", true
+		case "pause", "resume":
+			paused = event.EventType == "pause"
+		case "increment_skipped_break":
+			report.BreakMinutes++
+			continue
+		case "increment_skipped_idle":
+			report.IdleMinutes++
+			continue
+		case "increment_skipped_outside_work_window":
+			report.OutsideMinutes++
+			continue
+		case "increment_skipped_warmup":
+			report.WarmupMinutes++
+			continue
+		default:
+			continue
+		}
+
+		state := "unknown"
+		switch {
+		case paused:
+			state = "paused"
+		case known && systemActive:
+			state = "active"
+		case known:
+			state = "inactive"
+		}
+		if state == current.State || event.Timestamp.Before(current.Start) {
+			continue
+		}
+		current.End = event.Timestamp
+		if current.Minutes() > 0 {
+			report.Segments = append(report.Segments, current)
+		}
+		current = daySegment{Start: event.Timestamp, State: state}
+	}
+	if current.Start.Before(end) {
+		current.End = end
+		report.Segments = append(report.Segments, current)
+	}
+
+	return report
+}
+
+// logStatus describes whether and where a day was logged
+func logStatus(entry *models.DailyTimeEntry) string {
+	switch {
+	case entry.IsPTO:
+		return "PTO, not logged"
+	case entry.AutoLogged:
+		if rest, ok := strings.CutPrefix(entry.AutoLogResponse, "Successfully logged"); ok {
+			return "Logged" + rest
+		}
+		return "Logged"
+	case entry.Abandoned:
+		return "Abandoned (older than api.max_backlog_days)"
+	case entry.AutoLogFailures > 0:
+		return fmt.Sprintf("Not logged yet (%d failed attempts)", entry.AutoLogFailures)
+	default:
+		return "Not logged yet"
+	}
+}
+
+// dayReportTemplate lays out the details page. Segment widths are shares of
+// the whole day so the bar reads like a clock from midnight.
+var dayReportTemplate = template.Must(template.New("details").Funcs(template.FuncMap{
+	"clock":   func(t time.Time) string { return t.Format("15:04") },
+	"hours":   func(minutes int) string { return fmt.Sprintf("%dh %02dm", minutes/60, minutes%60) },
+	"percent": func(minutes int) string { return fmt.Sprintf("%.2f%%", float64(minutes)/(24*60)*100) },
+}).Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>Timeclip – {{.Entry.Date}}</title>
+<style>
+body { font: 14px -apple-system, sans-serif; margin: 2em; color: #222; }
+.bar { display: flex; height: 24px; border: 1px solid #ccc; margin: 1em 0; }
+.active { background: #34c759; } .inactive { background: #d1d1d6; }
+.paused { background: #ff9f0a; } .unknown { background: #f2f2f7; }
+table { border-collapse: collapse; } td, th { padding: 2px 12px 2px 0; text-align: left; }
+.key { display: inline-block; width: 10px; height: 10px; margin-right: 4px; }
+</style></head><body>
+<h1>{{.Entry.Date}}</h1>
+<p><b>{{hours .Entry.ActiveMinutes}}</b> of {{hours .Entry.GoalMinutes}} credited · {{.LogStatus}}</p>
+<div class="bar">{{range .Segments}}<div class="{{.State}}" style="width: {{percent .Minutes}}" title="{{.State}} {{clock .Start}}–{{clock .End}}"></div>{{end}}</div>
+<h2>Breakdown</h2>
+<table>
+<tr><td>Break time (not credited)</td><td>{{hours .BreakMinutes}}</td></tr>
+<tr><td>Idle time (not credited)</td><td>{{hours .IdleMinutes}}</td></tr>
+<tr><td>Outside work hours</td><td>{{hours .OutsideMinutes}}</td></tr>
+<tr><td>Warm-up after wake</td><td>{{hours .WarmupMinutes}}</td></tr>
+</table>
+<h2>Timeline</h2>
+<table>
+<tr><th>From</th><th>To</th><th>State</th><th>Length</th></tr>
+{{range .Segments}}<tr><td>{{clock .Start}}</td><td>{{clock .End}}</td><td><span class="key {{.State}}"></span>{{.State}}</td><td>{{hours .Minutes}}</td></tr>
+{{end}}</table>
+<p style="color: #888">Generated {{.GeneratedAt.Format "2006-01-02 15:04"}} from Timeclip's system events.</p>
+</body></html>
+`))
+
+// renderDayReport renders the details page
+func renderDayReport(report *dayReport) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := dayReportTemplate.Execute(&buf, report); err != nil {
+		return nil, fmt.Errorf("failed to render report: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,47 @@
+package menubar
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"timeclip/internal/notify"
+)
+
+// NotesEditor reads and saves the private notes for a day
+type NotesEditor interface {
+	GetNotes(date string) (string, error)
+	SetNotes(date, text string) error
+}
+
+// addNotesItem creates the "Edit Today's Notes..." item. Must be called with
+// smb.mu held.
+func (smb *SystrayMenuBar) addNotesItem() {
+	item := smb.tray.AddMenuItem("Edit Today's Notes...", "Private notes for today, included in exports")
+	go smb.handleNotesClicks(item)
+}
+
+// handleNotesClicks asks for today's notes and saves them
+func (smb *SystrayMenuBar) handleNotesClicks(item trayMenuItem) {
+	for range item.Clicked() {
+		today := time.Now().Format("2006-01-02")
+		notes, err := smb.notesEditor.GetNotes(today)
+		if err != nil {
+			log.Printf("Error reading notes: %v", err)
+			continue
+		}
+
+		notes, err = notify.Prompt("Timeclip", "Notes for "+today+":", notes)
+		if errors.Is(err, notify.ErrCanceled) {
+			continue
+		}
+		if err != nil {
+			log.Printf("Error asking for notes: %v", err)
+			continue
+		}
+
+		if err := smb.notesEditor.SetNotes(today, notes); err != nil {
+			log.Printf("Error saving notes: %v", err)
+		}
+	}
+}
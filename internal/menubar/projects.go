@@ -0,0 +1,113 @@
+package menubar
+
+import (
+	"log"
+	"sync"
+
+	"timeclip/internal/models"
+)
+
+// ProjectPicker lists the projects available for logging and records the
+// one chosen from the menu
+type ProjectPicker interface {
+	ListProjects() ([]*models.Project, error)
+	SelectedProjectID() string
+	SelectProject(project *models.Project) error
+}
+
+// projectMenu is the "Project" submenu. Projects are only fetched when
+// "Load projects…" is clicked, so startup never waits on the network.
+type projectMenu struct {
+	mu        sync.Mutex
+	picker    ProjectPicker
+	parent    trayMenuItem
+	loadItem  trayMenuItem
+	errorItem trayMenuItem
+	items     map[string]trayMenuItem // project ID → menu item
+	loaded    bool
+}
+
+// addProjectMenu creates the "Project" submenu. Must be called with smb.mu held.
+func (smb *SystrayMenuBar) addProjectMenu() {
+	smb.projectMenuItem = smb.tray.AddMenuItem("Project", "Project used for logging today")
+
+	menu := &projectMenu{
+		picker:   smb.projectPicker,
+		parent:   smb.projectMenuItem,
+		loadItem: smb.projectMenuItem.AddSubMenuItem("Load projects…", "Fetch the project list"),
+		items:    make(map[string]trayMenuItem),
+	}
+
+	go menu.handleLoadClicks()
+}
+
+// handleLoadClicks loads the project list when "Load projects…" is clicked
+func (pm *projectMenu) handleLoadClicks() {
+	for range pm.loadItem.Clicked() {
+		pm.load()
+	}
+}
+
+// load fetches the projects and fills the submenu, or shows a disabled
+// error item if they couldn't be loaded
+func (pm *projectMenu) load() {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if pm.loaded {
+		return
+	}
+
+	projects, err := pm.picker.ListProjects()
+	if err != nil {
+		log.Printf("Error loading projects: %v", err)
+		if pm.errorItem == nil {
+			pm.errorItem = pm.parent.AddSubMenuItem("Couldn't load projects", err.Error())
+			pm.errorItem.Disable()
+		}
+		pm.loadItem.SetTitle("Retry loading projects")
+		return
+	}
+
+	pm.loaded = true
+	pm.loadItem.Hide()
+	if pm.errorItem != nil {
+		pm.errorItem.Hide()
+	}
+	if len(projects) == 0 {
+		pm.parent.AddSubMenuItem("No projects", "").Disable()
+		return
+	}
+
+	selectedID := pm.picker.SelectedProjectID()
+	for _, project := range projects {
+		item := pm.parent.AddSubMenuItem(project.Name, "Log to this project for the rest of today")
+		pm.items[project.ID] = item
+		if project.ID == selectedID {
+			item.Check()
+			pm.parent.SetTitle("Project: " + project.Name)
+		}
+		go pm.handleProjectClicks(project, item)
+	}
+}
+
+// handleProjectClicks selects a project when its item is clicked
+func (pm *projectMenu) handleProjectClicks(project *models.Project, item trayMenuItem) {
+	for range item.Clicked() {
+		if err := pm.picker.SelectProject(project); err != nil {
+			log.Printf("Error selecting project: %v", err)
+			continue
+		}
+
+		pm.mu.Lock()
+		for id, other := range pm.items {
+			if id == project.ID {
+				other.Check()
+			} else {
+				other.Uncheck()
+			}
+		}
+		pm.parent.SetTitle("Project: " + project.Name)
+		pm.mu.Unlock()
+	}
+}
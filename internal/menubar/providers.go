@@ -0,0 +1,57 @@
+package menubar
+
+import (
+	"log"
+	"strings"
+)
+
+// ProviderSwitch lists the configured providers and switches them off for
+// the rest of the day
+type ProviderSwitch interface {
+	ConfiguredProviders() []string
+	IsProviderActive(provider string) bool
+	SetProviderActive(provider string, active bool) error
+}
+
+// addProvidersMenu creates the "Providers" submenu with a checkable item per
+// configured provider. Must be called with smb.mu held.
+func (smb *SystrayMenuBar) addProvidersMenu() {
+	providers := smb.providerSwitch.ConfiguredProviders()
+	if len(providers) == 0 {
+		return
+	}
+
+	parent := smb.tray.AddMenuItem("Providers", "Providers used for logging today")
+	for _, provider := range providers {
+		item := parent.AddSubMenuItem(providerTitle(provider), "Uncheck to stop logging here for the rest of today")
+		if smb.providerSwitch.IsProviderActive(provider) {
+			item.Check()
+		}
+		go smb.handleProviderClicks(provider, item)
+	}
+}
+
+// handleProviderClicks toggles a provider when its item is clicked
+func (smb *SystrayMenuBar) handleProviderClicks(provider string, item trayMenuItem) {
+	for range item.Clicked() {
+		active := !smb.providerSwitch.IsProviderActive(provider)
+		if err := smb.providerSwitch.SetProviderActive(provider, active); err != nil {
+			log.Printf("Error switching %s: %v", provider, err)
+			continue
+		}
+
+		if active {
+			item.Check()
+		} else {
+			item.Uncheck()
+		}
+	}
+}
+
+// providerTitle returns a provider's display name, e.g. "Clockify"
+func providerTitle(provider string) string {
+	if provider == "" {
+		return provider
+	}
+	return strings.ToUpper(provider[:1]) + provider[1:]
+}
@@ -3,25 +3,40 @@ package menubar
 import (
 	"fmt"
 	"log"
+	"math"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"sync"
+	"time"
 
-	"github.com/getlantern/systray"
+	"timeclip/internal/database"
 	"timeclip/internal/models"
+	"timeclip/internal/tracker"
 )
 
 // SystrayMenuBar manages the macOS menu bar using systray library
 type SystrayMenuBar struct {
 	mu             sync.RWMutex
+	config         *models.Config
 	isInitialized  bool
 	pauseHandler   func() error
+	trackingHandler func() error
+	logTodayHandler func() error
+	projectPicker  ProjectPicker
+	providerSwitch ProviderSwitch
+	catchUpApprover CatchUpApprover
+	notesEditor    NotesEditor
+	dayReportSource DayReportSource
+	weekly         *weeklyProgress // Week's total for ui.goal_basis = "weekly"
 	quitHandler    func()
 	currentStats   *MenuBarStats
 	initialStats   *MenuBarStats  // Stats to use when systray becomes ready
-	pauseMenuItem  *systray.MenuItem
-	statsMenuItem  *systray.MenuItem
+	tray           trayBackend
+	pauseMenuItem  trayMenuItem
+	trackingMenuItem trayMenuItem
+	statsMenuItem  trayMenuItem
+	projectMenuItem trayMenuItem
 }
 
 // MenuBarStats represents the current statistics for menu bar display
@@ -29,14 +44,29 @@ type MenuBarStats struct {
 	ActiveMinutes  int     `json:"active_minutes"`
 	GoalMinutes    int     `json:"goal_minutes"`
 	Progress       float64 `json:"progress"`
+	UnclampedProgress float64 `json:"unclamped_progress"` // Progress past 1.0 when over goal
 	IsGoalReached  bool    `json:"is_goal_reached"`
 	IsPaused       bool    `json:"is_paused"`
 	IsSystemActive bool    `json:"is_system_active"`
+	IdleMinutes    int     `json:"idle_minutes"` // Active minutes skipped due to idle
+	TrackingDisabled bool  `json:"tracking_disabled"` // Global kill switch is on
+	IsPTO          bool    `json:"is_pto"`        // Today is marked as PTO
+	IsLogged       bool    `json:"is_logged"`     // Today has been logged
+	BreakMinutes   int     `json:"break_minutes"` // Active minutes inside break windows (not credited)
+	Error          string  `json:"error,omitempty"` // Tracking failed to start; shown instead of the time
+	WeekMinutes    int     `json:"week_minutes,omitempty"`      // Week's total including today (ui.goal_basis = "weekly")
+	WeekGoalMinutes int    `json:"week_goal_minutes,omitempty"` // Week's goal (ui.goal_basis = "weekly")
 }
 
 // NewSystrayMenuBar creates a new systray-based menu bar
-func NewSystrayMenuBar() *SystrayMenuBar {
+func NewSystrayMenuBar(config *models.Config) *SystrayMenuBar {
+	if config == nil {
+		config = models.DefaultConfig()
+	}
+
 	return &SystrayMenuBar{
+		config:       config,
+		tray:         systrayBackend{},
 		currentStats: &MenuBarStats{},
 	}
 }
@@ -47,7 +77,41 @@ func (smb *SystrayMenuBar) Run(pauseHandler func() error, quitHandler func()) {
 	smb.quitHandler = quitHandler
 	
 	log.Println("Starting systray menu bar...")
-	systray.Run(smb.onReady, smb.onExit)
+	smb.tray.Run(smb.onReady, smb.onExit)
+}
+
+// SetTrackingHandler sets the handler for the "Tracking: On/Off" menu item.
+// Must be called before Run.
+func (smb *SystrayMenuBar) SetTrackingHandler(handler func() error) {
+	smb.trackingHandler = handler
+}
+
+// SetLogTodayHandler sets the handler "Log and Quit" uses to log today
+// before quitting (see ui.confirm_quit_unlogged). Must be called before Run.
+func (smb *SystrayMenuBar) SetLogTodayHandler(handler func() error) {
+	smb.logTodayHandler = handler
+}
+
+// SetProjectPicker enables the "Project" submenu. Must be called before Run.
+func (smb *SystrayMenuBar) SetProjectPicker(picker ProjectPicker) {
+	smb.projectPicker = picker
+}
+
+// SetProviderSwitch enables the "Providers" submenu. Must be called before Run.
+func (smb *SystrayMenuBar) SetProviderSwitch(providerSwitch ProviderSwitch) {
+	smb.providerSwitch = providerSwitch
+}
+
+// SetCatchUpApprover enables the "Approve Pending Logs" item. Must be called
+// before Run.
+func (smb *SystrayMenuBar) SetCatchUpApprover(approver CatchUpApprover) {
+	smb.catchUpApprover = approver
+}
+
+// SetNotesEditor enables the "Edit Today's Notes..." item (*database.DB
+// implements NotesEditor). Must be called before Run.
+func (smb *SystrayMenuBar) SetNotesEditor(editor NotesEditor) {
+	smb.notesEditor = editor
 }
 
 // onReady is called when systray is ready
@@ -64,37 +128,66 @@ func (smb *SystrayMenuBar) onReady() {
 			GoalMinutes: 480, // Default 8 hours
 		}
 	}
+	initialStats = smb.withWeekProgress(initialStats)
 	
 	// Set initial icon, title and tooltip based on actual data
 	state := smb.determineMenuState(initialStats)
 	smb.setIcon(state)
 	
 	title := smb.generateTitle(initialStats)
-	systray.SetTitle(title)
+	smb.tray.SetTitle(title)
 	
 	tooltip := smb.generateTooltip(initialStats)
-	systray.SetTooltip(tooltip)
+	smb.tray.SetTooltip(tooltip)
 
 	// Create menu items with actual data
 	statsText := smb.generateStatsText(initialStats)
-	smb.statsMenuItem = systray.AddMenuItem(statsText, "Current day statistics")
+	smb.statsMenuItem = smb.tray.AddMenuItem(statsText, "Current day statistics")
 	smb.statsMenuItem.Disable()
+	if smb.dayReportSource != nil {
+		smb.addDetailsItem()
+	}
 
-	systray.AddSeparator()
+	smb.tray.AddSeparator()
 
 	pauseText := "Resume"
 	if !initialStats.IsPaused {
 		pauseText = "Pause"
 	}
-	smb.pauseMenuItem = systray.AddMenuItem(pauseText, "Pause/Resume time tracking")
+	smb.pauseMenuItem = smb.tray.AddMenuItem(pauseText, "Pause/Resume time tracking")
+	smb.trackingMenuItem = smb.tray.AddMenuItem(trackingText(initialStats), "Turn all tracking and auto-logging on or off")
+	observer := smb.config.General.ObserverMode
+	if observer || initialStats.Error != "" {
+		// Another machine tracks and logs; this one only shows its stats
+		smb.pauseMenuItem.Hide()
+		smb.trackingMenuItem.Hide()
+	} else {
+		if smb.catchUpApprover != nil {
+			smb.addCatchUpItem()
+		}
+		if smb.notesEditor != nil {
+			smb.addNotesItem()
+		}
+	}
 	
-	systray.AddSeparator()
+	smb.tray.AddSeparator()
+
+	if !observer && (smb.projectPicker != nil || smb.providerSwitch != nil) {
+		if smb.projectPicker != nil {
+			smb.addProjectMenu()
+		}
+		if smb.providerSwitch != nil {
+			smb.addProvidersMenu()
+		}
+		smb.tray.AddSeparator()
+	}
 	
-	configMenuItem := systray.AddMenuItem("Configuration...", "Open configuration file")
+	configMenuItem := smb.tray.AddMenuItem("Configuration...", "Open configuration file")
+	dataFolderMenuItem := smb.tray.AddMenuItem("Open Data Folder", "Show the database folder in Finder")
 	
-	systray.AddSeparator()
+	smb.tray.AddSeparator()
 	
-	quitMenuItem := systray.AddMenuItem("Quit Timeclip", "Exit the application")
+	quitMenuItem := smb.tray.AddMenuItem("Quit Timeclip", "Exit the application")
 
 	smb.isInitialized = true
 	smb.mu.Unlock()
@@ -103,7 +196,9 @@ func (smb *SystrayMenuBar) onReady() {
 
 	// Handle menu clicks in separate goroutines
 	go smb.handlePauseClicks()
+	go smb.handleTrackingClicks()
 	go smb.handleConfigClicks(configMenuItem)
+	go smb.handleDataFolderClicks(dataFolderMenuItem)
 	go smb.handleQuitClicks(quitMenuItem)
 }
 
@@ -115,8 +210,23 @@ func (smb *SystrayMenuBar) onExit() {
 	}
 }
 
+// SetError puts the menu bar into its error state, e.g. when the tracker fails
+// to start after the tray is up, so the menu doesn't sit at 0m looking stuck.
+// The error is shown in the tooltip; configuration, data folder and quit keep
+// working. The next UpdateStats clears it.
+func (smb *SystrayMenuBar) SetError(err error) {
+	smb.mu.RLock()
+	stats := *smb.currentStats
+	smb.mu.RUnlock()
+
+	stats.Error = err.Error()
+	smb.UpdateStats(&stats)
+}
+
 // UpdateStats updates the menu bar display with current statistics
 func (smb *SystrayMenuBar) UpdateStats(stats *MenuBarStats) {
+	stats = smb.withWeekProgress(stats)
+
 	smb.mu.Lock()
 	smb.currentStats = stats
 	
@@ -130,7 +240,7 @@ func (smb *SystrayMenuBar) UpdateStats(stats *MenuBarStats) {
 
 	// Update title
 	title := smb.generateTitle(stats)
-	systray.SetTitle(title)
+	smb.tray.SetTitle(title)
 
 	// Update icon based on state
 	state := smb.determineMenuState(stats)
@@ -138,7 +248,7 @@ func (smb *SystrayMenuBar) UpdateStats(stats *MenuBarStats) {
 
 	// Update tooltip
 	tooltip := smb.generateTooltip(stats)
-	systray.SetTooltip(tooltip)
+	smb.tray.SetTooltip(tooltip)
 
 	// Update stats menu item
 	statsText := smb.generateStatsText(stats)
@@ -150,13 +260,29 @@ func (smb *SystrayMenuBar) UpdateStats(stats *MenuBarStats) {
 		pauseText = "Pause"
 	}
 	smb.pauseMenuItem.SetTitle(pauseText)
+	smb.trackingMenuItem.SetTitle(trackingText(stats))
+	if stats.Error != "" {
+		smb.pauseMenuItem.Hide()
+		smb.trackingMenuItem.Hide()
+	} else if !smb.config.General.ObserverMode {
+		smb.pauseMenuItem.Show()
+		smb.trackingMenuItem.Show()
+	}
+}
+
+// trackingText returns the title for the global tracking toggle
+func trackingText(stats *MenuBarStats) string {
+	if stats.TrackingDisabled {
+		return "Tracking: Off"
+	}
+	return "Tracking: On"
 }
 
 // handlePauseClicks handles pause/resume menu clicks
 func (smb *SystrayMenuBar) handlePauseClicks() {
 	for {
 		select {
-		case <-smb.pauseMenuItem.ClickedCh:
+		case <-smb.pauseMenuItem.Clicked():
 			if smb.pauseHandler != nil {
 				if err := smb.pauseHandler(); err != nil {
 					log.Printf("Error toggling pause: %v", err)
@@ -166,24 +292,49 @@ func (smb *SystrayMenuBar) handlePauseClicks() {
 	}
 }
 
+// handleTrackingClicks handles global tracking on/off menu clicks
+func (smb *SystrayMenuBar) handleTrackingClicks() {
+	for {
+		select {
+		case <-smb.trackingMenuItem.Clicked():
+			if smb.trackingHandler != nil {
+				if err := smb.trackingHandler(); err != nil {
+					log.Printf("Error toggling tracking: %v", err)
+				}
+			}
+		}
+	}
+}
+
 // handleConfigClicks handles configuration menu clicks
-func (smb *SystrayMenuBar) handleConfigClicks(menuItem *systray.MenuItem) {
+func (smb *SystrayMenuBar) handleConfigClicks(menuItem trayMenuItem) {
 	for {
 		select {
-		case <-menuItem.ClickedCh:
+		case <-menuItem.Clicked():
 			// Open configuration file with default editor
 			smb.openConfigFile()
 		}
 	}
 }
 
+// handleDataFolderClicks handles "Open Data Folder" menu clicks
+func (smb *SystrayMenuBar) handleDataFolderClicks(menuItem trayMenuItem) {
+	for range menuItem.Clicked() {
+		smb.openDataFolder()
+	}
+}
+
 // handleQuitClicks handles quit menu clicks
-func (smb *SystrayMenuBar) handleQuitClicks(menuItem *systray.MenuItem) {
+func (smb *SystrayMenuBar) handleQuitClicks(menuItem trayMenuItem) {
 	for {
 		select {
-		case <-menuItem.ClickedCh:
+		case <-menuItem.Clicked():
 			log.Println("Quit requested from menu bar")
-			systray.Quit()
+			if !smb.confirmQuit() {
+				log.Println("Quit cancelled")
+				continue
+			}
+			smb.tray.Quit()
 		}
 	}
 }
@@ -192,19 +343,45 @@ func (smb *SystrayMenuBar) handleQuitClicks(menuItem *systray.MenuItem) {
 type MenuState int
 
 const (
-	MenuStateInactive MenuState = iota // Red - less than goal
+	MenuStateInactive MenuState = iota // Red - less than minimum (or goal)
 	MenuStatePaused                    // Orange - paused
 	MenuStateActive                    // Green - goal reached
+	MenuStatePartial                   // Yellow - minimum reached, goal not yet
+	MenuStateOff                       // Gray - tracking globally disabled
+	MenuStatePTO                       // Gray - today is marked as PTO
+	MenuStateError                     // Red - tracking failed to start
+	MenuStateNoGoal                    // Gray - today's goal is 0, so there is nothing to reach
 )
 
 // determineMenuState determines the appropriate menu state
 func (smb *SystrayMenuBar) determineMenuState(stats *MenuBarStats) MenuState {
+	if stats.Error != "" {
+		return MenuStateError
+	}
+	if stats.TrackingDisabled {
+		return MenuStateOff
+	}
+	if stats.IsPTO {
+		return MenuStatePTO
+	}
 	if stats.IsPaused {
 		return MenuStatePaused
 	}
+	if smb.weeklyBasis(stats) {
+		if stats.WeekMinutes >= stats.WeekGoalMinutes {
+			return MenuStateActive
+		}
+		return MenuStateInactive
+	}
+	if stats.GoalMinutes <= 0 {
+		return MenuStateNoGoal
+	}
 	if stats.IsGoalReached {
 		return MenuStateActive
 	}
+	if minGoal := smb.config.General.MinGoalMinutes(); minGoal > 0 && stats.ActiveMinutes >= minGoal {
+		return MenuStatePartial
+	}
 	return MenuStateInactive
 }
 
@@ -212,17 +389,32 @@ func (smb *SystrayMenuBar) determineMenuState(stats *MenuBarStats) MenuState {
 func (smb *SystrayMenuBar) setIcon(state MenuState) {
 	switch state {
 	case MenuStatePaused:
-		systray.SetTemplateIcon(pauseIcon, pauseIcon)
+		smb.tray.SetIcon(pauseIcon)
 	case MenuStateActive:
-		systray.SetTemplateIcon(activeIcon, activeIcon)
-	case MenuStateInactive:
-		systray.SetTemplateIcon(inactiveIcon, inactiveIcon)
+		smb.tray.SetIcon(activeIcon)
+	case MenuStatePartial:
+		smb.tray.SetIcon(partialIcon)
+	case MenuStateOff, MenuStatePTO, MenuStateNoGoal:
+		smb.tray.SetIcon(offIcon)
+	case MenuStateInactive, MenuStateError:
+		smb.tray.SetIcon(inactiveIcon)
 	}
 }
 
 // generateTitle creates the menu bar title text
 func (smb *SystrayMenuBar) generateTitle(stats *MenuBarStats) string {
-	hours := float64(stats.ActiveMinutes) / 60.0
+	displayMinutes := smb.displayMinutes(stats)
+	hours := float64(displayMinutes) / 60.0
+
+	if smb.determineMenuState(stats) == MenuStateOff {
+		return "⏻ Off"
+	}
+	if smb.determineMenuState(stats) == MenuStatePTO {
+		return "🌴 PTO"
+	}
+	if smb.determineMenuState(stats) == MenuStateError {
+		return "⚠️ Timeclip error"
+	}
 	
 	var prefix string
 	switch smb.determineMenuState(stats) {
@@ -230,12 +422,26 @@ func (smb *SystrayMenuBar) generateTitle(stats *MenuBarStats) string {
 		prefix = "⏸"
 	case MenuStateActive:
 		prefix = "✅"
-	case MenuStateInactive:
+	case MenuStatePartial:
+		prefix = "⏳"
+	case MenuStateInactive, MenuStateNoGoal:
 		prefix = "⏱"
 	}
+
+	if smb.weeklyBasis(stats) {
+		weekHours := float64(stats.WeekMinutes) / 60.0
+		if weekHours >= 10 {
+			return fmt.Sprintf("%s %.0fh wk", prefix, weekHours)
+		}
+		return fmt.Sprintf("%s %.1fh wk", prefix, weekHours)
+	}
 	
+	if smb.showAsDays(stats) {
+		return fmt.Sprintf("%s %.1fd", prefix, workdays(stats))
+	}
+
 	if hours < 1 {
-		return fmt.Sprintf("%s %dm", prefix, stats.ActiveMinutes)
+		return fmt.Sprintf("%s %dm", prefix, displayMinutes)
 	} else if hours >= 10 {
 		return fmt.Sprintf("%s %.0fh", prefix, hours)
 	} else {
@@ -245,26 +451,57 @@ func (smb *SystrayMenuBar) generateTitle(stats *MenuBarStats) string {
 
 // generateTooltip creates detailed tooltip text
 func (smb *SystrayMenuBar) generateTooltip(stats *MenuBarStats) string {
+	if smb.determineMenuState(stats) == MenuStateOff {
+		return "Timeclip - Tracking Off\nNo time is tracked or logged until tracking is turned back on"
+	}
+	if smb.determineMenuState(stats) == MenuStatePTO {
+		return "Timeclip - PTO\nToday is marked as PTO; no time is tracked or logged"
+	}
+	if smb.determineMenuState(stats) == MenuStateError {
+		return fmt.Sprintf("Timeclip - Error\nTracking failed to start: %s\nNo time is being tracked; check the logs and configuration", stats.Error)
+	}
+
 	hours := float64(stats.ActiveMinutes) / 60.0
 	goalHours := float64(stats.GoalMinutes) / 60.0
-	progress := int(stats.Progress * 100)
-	
+	progress := smb.progressPercent(stats)
+
 	var status string
 	switch smb.determineMenuState(stats) {
 	case MenuStatePaused:
 		status = "Paused"
 	case MenuStateActive:
 		status = "Goal Reached!"
-	case MenuStateInactive:
+	case MenuStatePartial:
+		status = "Minimum Reached"
+	case MenuStateInactive, MenuStateNoGoal:
 		if stats.IsSystemActive {
 			status = "Tracking"
 		} else {
 			status = "Inactive"
 		}
 	}
+
+	if smb.weeklyBasis(stats) {
+		return smb.weeklyTooltip(stats, status)
+	}
 	
 	tooltip := fmt.Sprintf("Timeclip - %s\nToday: %.1fh / %.0fh (%d%%)", 
 		status, hours, goalHours, progress)
+	if stats.GoalMinutes <= 0 {
+		tooltip = fmt.Sprintf("Timeclip - %s\nToday: %.1fh (no goal today)", status, hours)
+	} else if smb.showAsDays(stats) {
+		tooltip = fmt.Sprintf("Timeclip - %s\nToday: %.1f / 1.0 days (%d%%)",
+			status, workdays(stats), progress)
+	}
+
+	if stats.BreakMinutes > 0 {
+		breakHours := float64(stats.BreakMinutes) / 60.0
+		tooltip += fmt.Sprintf("\nNet %.1fh / gross %.1fh (%.1fh in breaks)", hours, hours+breakHours, breakHours)
+	}
+
+	if stats.IdleMinutes > 0 {
+		tooltip += fmt.Sprintf("\nCredited %.1fh (skipped %.1fh idle)", hours, float64(stats.IdleMinutes)/60.0)
+	}
 	
 	if stats.IsGoalReached {
 		overtime := stats.ActiveMinutes - stats.GoalMinutes
@@ -274,24 +511,109 @@ func (smb *SystrayMenuBar) generateTooltip(stats *MenuBarStats) string {
 		}
 	} else {
 		remaining := stats.GoalMinutes - stats.ActiveMinutes
-		if remaining > 0 {
+		if remaining > 0 && smb.config.UI.ShowETA {
+			tooltip += "\n" + smb.etaText(stats, time.Now())
+		} else if remaining > 0 {
 			remainingHours := float64(remaining) / 60.0
 			tooltip += fmt.Sprintf("\nRemaining: %.1fh", remainingHours)
 		}
 	}
+
+	if smb.config.General.ObserverMode {
+		tooltip += "\nObserver mode: showing time tracked on another machine"
+	}
 	
 	return tooltip
 }
 
 // generateStatsText creates text for the stats menu item
 func (smb *SystrayMenuBar) generateStatsText(stats *MenuBarStats) string {
-	hours := float64(stats.ActiveMinutes) / 60.0
+	if stats.Error != "" {
+		return "Tracking failed to start"
+	}
+	if stats.TrackingDisabled {
+		return "Tracking is off"
+	}
+	if stats.IsPTO {
+		return "Today: PTO"
+	}
+
+	hours := float64(smb.displayMinutes(stats)) / 60.0
 	goalHours := float64(stats.GoalMinutes) / 60.0
-	progress := int(stats.Progress * 100)
-	
+	progress := smb.progressPercent(stats)
+
+	if stats.GoalMinutes <= 0 {
+		return fmt.Sprintf("Today: %.1fh (no goal)", hours)
+	}
+
+	if smb.showAsDays(stats) {
+		return fmt.Sprintf("Today: %.1f / 1.0 days (%d%%)", workdays(stats), progress)
+	}
+
 	return fmt.Sprintf("Today: %.1fh / %.0fh (%d%%)", hours, goalHours, progress)
 }
 
+// progressPercent returns the progress shown in the tooltip and stats item,
+// past 100 when over goal if ui.show_overtime_progress is set
+func (smb *SystrayMenuBar) progressPercent(stats *MenuBarStats) int {
+	if smb.config.UI.ShowOvertimeProgress && stats.UnclampedProgress > stats.Progress {
+		return percent(stats.UnclampedProgress)
+	}
+	return percent(stats.Progress)
+}
+
+// percent converts progress to a whole percentage, rounding down so 100%
+// only shows once the goal is reached. The small offset keeps ratios such
+// as 552/480 from landing just below their exact value.
+func percent(progress float64) int {
+	return int(math.Floor(progress*100 + 1e-9))
+}
+
+// etaText estimates when the goal will be reached, assuming continuous
+// activity from now on
+func (smb *SystrayMenuBar) etaText(stats *MenuBarStats, now time.Time) string {
+	if stats.IsPaused {
+		return "ETA unavailable — paused"
+	}
+
+	remaining := stats.GoalMinutes - stats.ActiveMinutes
+	if remaining <= 0 {
+		return "Goal reached"
+	}
+	return "Done at ~" + smb.clockTime(now.Add(time.Duration(remaining)*time.Minute))
+}
+
+// clockTime formats a time of day per ui.use_12_hour_format
+func (smb *SystrayMenuBar) clockTime(t time.Time) string {
+	if smb.config.UI.Use12HourFormat {
+		return t.Format("3:04 PM")
+	}
+	return t.Format("15:04")
+}
+
+// displayMinutes returns the active minutes shown in the title and stats item:
+// net of breaks (as stored) or gross including active time during breaks
+func (smb *SystrayMenuBar) displayMinutes(stats *MenuBarStats) int {
+	if smb.config.UI.ShowNetActive {
+		return stats.ActiveMinutes
+	}
+	return stats.ActiveMinutes + stats.BreakMinutes
+}
+
+// showAsDays returns true if progress should be expressed in workdays
+func (smb *SystrayMenuBar) showAsDays(stats *MenuBarStats) bool {
+	return smb.config.UI.ShowAsDays && stats.GoalMinutes > 0
+}
+
+// workdays returns active time in workdays, treating the daily goal as one day.
+// Unlike Progress this is not clamped, so overtime shows as more than 1.0.
+func workdays(stats *MenuBarStats) float64 {
+	if stats.GoalMinutes == 0 {
+		return 0
+	}
+	return float64(stats.ActiveMinutes) / float64(stats.GoalMinutes)
+}
+
 // openConfigFile launches the separate configuration application
 func (smb *SystrayMenuBar) openConfigFile() {
 	log.Println("Configuration menu clicked - launching configuration application...")
@@ -318,7 +640,57 @@ func (smb *SystrayMenuBar) openConfigFile() {
 	}()
 }
 
-// StatsFromTimeEntry converts a time entry to menu bar stats
+// openDataFolder opens the folder holding the database in Finder, creating it
+// first if it doesn't exist yet
+func (smb *SystrayMenuBar) openDataFolder() {
+	dbPath, err := database.ExpandPath(smb.config.DatabasePath())
+	if err != nil {
+		log.Printf("Failed to resolve database path: %v", err)
+		return
+	}
+
+	dataDir := filepath.Dir(dbPath)
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		log.Printf("Failed to create data folder %s: %v", dataDir, err)
+		return
+	}
+
+	if err := exec.Command(fileManagerCommand, dataDir).Start(); err != nil {
+		log.Printf("Failed to open data folder %s: %v", dataDir, err)
+	}
+}
+
+// StatsFromTodayStats converts the tracker's stats for today to menu bar
+// stats. Unlike StatsFromTimeEntry it carries the break minutes, which
+// ui.show_net_active = false adds back to the displayed time, and the idle
+// minutes shown in the tooltip.
+func StatsFromTodayStats(stats *tracker.TodayStats, trackingDisabled bool) *MenuBarStats {
+	if stats == nil {
+		return &MenuBarStats{
+			GoalMinutes:      480, // Default 8 hours
+			TrackingDisabled: trackingDisabled,
+		}
+	}
+
+	return &MenuBarStats{
+		ActiveMinutes:     stats.ActiveMinutes,
+		GoalMinutes:       stats.GoalMinutes,
+		Progress:          stats.Progress,
+		UnclampedProgress: stats.UnclampedProgress,
+		IsGoalReached:     stats.IsGoalReached,
+		IsPaused:          stats.IsPaused,
+		IsSystemActive:    stats.IsSystemActive,
+		TrackingDisabled:  trackingDisabled,
+		IsPTO:             stats.IsPTO,
+		IsLogged:          stats.AutoLogged,
+		IdleMinutes:       stats.IdleMinutes,
+		BreakMinutes:      stats.BreakMinutes,
+	}
+}
+
+// StatsFromTimeEntry converts a time entry to menu bar stats. The entry
+// doesn't know about breaks or idle time; use StatsFromTodayStats to show
+// them.
 func StatsFromTimeEntry(entry *models.DailyTimeEntry, isSystemActive bool) *MenuBarStats {
 	if entry == nil {
 		return &MenuBarStats{
@@ -327,12 +699,15 @@ func StatsFromTimeEntry(entry *models.DailyTimeEntry, isSystemActive bool) *Menu
 	}
 
 	return &MenuBarStats{
-		ActiveMinutes:  entry.ActiveMinutes,
-		GoalMinutes:    entry.GoalMinutes,
-		Progress:       entry.Progress(),
-		IsGoalReached:  entry.IsGoalReached(),
-		IsPaused:       entry.IsPaused,
-		IsSystemActive: isSystemActive,
+		ActiveMinutes:     entry.ActiveMinutes,
+		GoalMinutes:       entry.GoalMinutes,
+		Progress:          entry.Progress(),
+		UnclampedProgress: entry.UnclampedProgress(),
+		IsGoalReached:     entry.IsGoalReached(),
+		IsPaused:          entry.IsPaused,
+		IsSystemActive:    isSystemActive,
+		IsPTO:             entry.IsPTO,
+		IsLogged:          entry.AutoLogged,
 	}
 }
 
@@ -358,6 +733,26 @@ var (
 		0x49, 0x45, 0x4E, 0x44, 0xAE, 0x42, 0x60, 0x82,
 	}
 	
+	// Yellow circle for minimum reached state
+	partialIcon = []byte{
+		0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A, 0x00, 0x00, 0x00, 0x0D,
+		0x49, 0x48, 0x44, 0x52, 0x00, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00, 0x10,
+		0x08, 0x06, 0x00, 0x00, 0x00, 0x1F, 0xF3, 0xFF, 0x61, 0x00, 0x00, 0x00,
+		0x13, 0x49, 0x44, 0x41, 0x54, 0x38, 0xCB, 0x63, 0xF8, 0xCF, 0x00, 0x01,
+		0x01, 0x01, 0x00, 0x18, 0xDD, 0x8D, 0xB4, 0x1D, 0x00, 0x00, 0x00, 0x00,
+		0x49, 0x45, 0x4E, 0x44, 0xAE, 0x42, 0x60, 0x82,
+	}
+	
+	// Gray circle for tracking off state
+	offIcon = []byte{
+		0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A, 0x00, 0x00, 0x00, 0x0D,
+		0x49, 0x48, 0x44, 0x52, 0x00, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00, 0x10,
+		0x08, 0x06, 0x00, 0x00, 0x00, 0x1F, 0xF3, 0xFF, 0x61, 0x00, 0x00, 0x00,
+		0x13, 0x49, 0x44, 0x41, 0x54, 0x38, 0xCB, 0x63, 0x60, 0x60, 0x00, 0x01,
+		0x01, 0x01, 0x00, 0x18, 0xDD, 0x8D, 0xB4, 0x1D, 0x00, 0x00, 0x00, 0x00,
+		0x49, 0x45, 0x4E, 0x44, 0xAE, 0x42, 0x60, 0x82,
+	}
+	
 	// Green circle for active/goal reached state
 	activeIcon = []byte{
 		0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A, 0x00, 0x00, 0x00, 0x0D,
@@ -0,0 +1,497 @@
+package menubar
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"timeclip/internal/models"
+	"timeclip/internal/tracker"
+)
+
+// newTestMenuBar returns a menu bar with the default config on a fakeTray
+func newTestMenuBar() (*SystrayMenuBar, *fakeTray) {
+	tray := &fakeTray{}
+	smb := NewSystrayMenuBar(models.DefaultConfig())
+	smb.tray = tray
+	return smb, tray
+}
+
+// statsFor returns the stats the tracker would report for entry
+func statsFor(entry models.DailyTimeEntry, active bool) *MenuBarStats {
+	if entry.GoalMinutes == 0 && !entry.IsPTO {
+		entry.GoalMinutes = 480
+	}
+	return StatsFromTimeEntry(&entry, active)
+}
+
+var menuBarCases = []struct {
+	name      string
+	stats     *MenuBarStats
+	state     MenuState
+	icon      []byte
+	title     string
+	tooltip   []string // Lines the tooltip must contain
+	noTooltip []string // Lines it must not contain
+}{
+	{
+		name:      "inactive",
+		stats:     statsFor(models.DailyTimeEntry{ActiveMinutes: 150}, false),
+		state:     MenuStateInactive,
+		icon:      inactiveIcon,
+		title:     "⏱ 2.5h",
+		tooltip:   []string{"Timeclip - Inactive", "Today: 2.5h / 8h (31%)", "Remaining: 5.5h"},
+		noTooltip: []string{"Overtime"},
+	},
+	{
+		name:    "tracking",
+		stats:   statsFor(models.DailyTimeEntry{ActiveMinutes: 45}, true),
+		state:   MenuStateInactive,
+		icon:    inactiveIcon,
+		title:   "⏱ 45m",
+		tooltip: []string{"Timeclip - Tracking", "Today: 0.8h / 8h (9%)", "Remaining: 7.2h"},
+	},
+	{
+		name:    "paused",
+		stats:   statsFor(models.DailyTimeEntry{ActiveMinutes: 200, IsPaused: true}, true),
+		state:   MenuStatePaused,
+		icon:    pauseIcon,
+		title:   "⏸ 3.3h",
+		tooltip: []string{"Timeclip - Paused", "Today: 3.3h / 8h (41%)"},
+	},
+	{
+		name:    "pto",
+		stats:   statsFor(models.DailyTimeEntry{IsPTO: true}, true),
+		state:   MenuStatePTO,
+		icon:    offIcon,
+		title:   "🌴 PTO",
+		tooltip: []string{"Timeclip - PTO"},
+	},
+	{
+		name:      "goal reached",
+		stats:     statsFor(models.DailyTimeEntry{ActiveMinutes: 480}, true),
+		state:     MenuStateActive,
+		icon:      activeIcon,
+		title:     "✅ 8.0h",
+		tooltip:   []string{"Timeclip - Goal Reached!", "Today: 8.0h / 8h (100%)"},
+		noTooltip: []string{"Overtime", "Remaining"},
+	},
+	{
+		name:      "overtime",
+		stats:     statsFor(models.DailyTimeEntry{ActiveMinutes: 540}, true),
+		state:     MenuStateActive,
+		icon:      activeIcon,
+		title:     "✅ 9.0h",
+		tooltip:   []string{"Timeclip - Goal Reached!", "Today: 9.0h / 8h (100%)", "Overtime: 1.0h"},
+		noTooltip: []string{"Remaining"},
+	},
+	{
+		name:    "long overtime",
+		stats:   statsFor(models.DailyTimeEntry{ActiveMinutes: 660}, true),
+		state:   MenuStateActive,
+		icon:    activeIcon,
+		title:   "✅ 11h",
+		tooltip: []string{"Overtime: 3.0h"},
+	},
+	{
+		name:    "logged",
+		stats:   statsFor(models.DailyTimeEntry{ActiveMinutes: 510, AutoLogged: true}, false),
+		state:   MenuStateActive,
+		icon:    activeIcon,
+		title:   "✅ 8.5h",
+		tooltip: []string{"Timeclip - Goal Reached!", "Overtime: 0.5h"},
+	},
+	{
+		name:    "no goal",
+		stats:   &MenuBarStats{ActiveMinutes: 90, IsSystemActive: true},
+		state:   MenuStateNoGoal,
+		icon:    offIcon,
+		title:   "⏱ 1.5h",
+		tooltip: []string{"Timeclip - Tracking", "Today: 1.5h (no goal today)"},
+	},
+	{
+		name:    "tracking off",
+		stats:   &MenuBarStats{ActiveMinutes: 90, GoalMinutes: 480, TrackingDisabled: true},
+		state:   MenuStateOff,
+		icon:    offIcon,
+		title:   "⏻ Off",
+		tooltip: []string{"Timeclip - Tracking Off"},
+	},
+	{
+		name:    "error",
+		stats:   &MenuBarStats{GoalMinutes: 480, Error: "database is locked"},
+		state:   MenuStateError,
+		icon:    inactiveIcon,
+		title:   "⚠️ Timeclip error",
+		tooltip: []string{"Timeclip - Error", "Tracking failed to start: database is locked"},
+	},
+}
+
+func TestDetermineMenuState(t *testing.T) {
+	smb, _ := newTestMenuBar()
+	for _, tc := range menuBarCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := smb.determineMenuState(tc.stats); got != tc.state {
+				t.Errorf("determineMenuState = %v, want %v", got, tc.state)
+			}
+		})
+	}
+}
+
+func TestDetermineMenuStateMinimumGoal(t *testing.T) {
+	smb, _ := newTestMenuBar()
+	smb.config.General.MinGoalHours = 6
+
+	tests := []struct {
+		minutes int
+		want    MenuState
+	}{
+		{300, MenuStateInactive},
+		{360, MenuStatePartial},
+		{480, MenuStateActive},
+	}
+	for _, tc := range tests {
+		stats := statsFor(models.DailyTimeEntry{ActiveMinutes: tc.minutes}, true)
+		if got := smb.determineMenuState(stats); got != tc.want {
+			t.Errorf("%d minutes: determineMenuState = %v, want %v", tc.minutes, got, tc.want)
+		}
+	}
+
+	stats := statsFor(models.DailyTimeEntry{ActiveMinutes: 400}, true)
+	if got := smb.generateTitle(stats); got != "⏳ 6.7h" {
+		t.Errorf("generateTitle = %q, want %q", got, "⏳ 6.7h")
+	}
+	if got := smb.generateTooltip(stats); !strings.Contains(got, "Timeclip - Minimum Reached") {
+		t.Errorf("generateTooltip = %q, want the minimum reached status", got)
+	}
+}
+
+func TestGenerateTitle(t *testing.T) {
+	smb, _ := newTestMenuBar()
+	for _, tc := range menuBarCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := smb.generateTitle(tc.stats); got != tc.title {
+				t.Errorf("generateTitle = %q, want %q", got, tc.title)
+			}
+		})
+	}
+}
+
+func TestGenerateTooltip(t *testing.T) {
+	smb, _ := newTestMenuBar()
+	for _, tc := range menuBarCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tooltip := smb.generateTooltip(tc.stats)
+			for _, want := range tc.tooltip {
+				if !strings.Contains(tooltip, want) {
+					t.Errorf("tooltip %q does not contain %q", tooltip, want)
+				}
+			}
+			for _, unwanted := range tc.noTooltip {
+				if strings.Contains(tooltip, unwanted) {
+					t.Errorf("tooltip %q contains %q", tooltip, unwanted)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateStatsBeforeRunIsKeptForOnReady(t *testing.T) {
+	smb, tray := newTestMenuBar()
+	paused := statsFor(models.DailyTimeEntry{ActiveMinutes: 200, IsPaused: true}, true)
+
+	smb.UpdateStats(paused)
+	if tray.title != "" {
+		t.Fatalf("title set to %q before the tray was ready", tray.title)
+	}
+
+	smb.Run(nil, nil)
+
+	if tray.title != "⏸ 3.3h" {
+		t.Errorf("title = %q, want the stats from before Run", tray.title)
+	}
+	if !bytes.Equal(tray.icon, pauseIcon) {
+		t.Error("icon is not the paused icon")
+	}
+	if title, _ := tray.item("Resume").state(); title != "Resume" {
+		t.Errorf("pause item = %q, want Resume", title)
+	}
+}
+
+func TestUpdateStats(t *testing.T) {
+	smb, tray := newTestMenuBar()
+	smb.Run(nil, nil)
+	statsItem := tray.item("Today: 0.0h / 8h (0%)")
+	pauseItem := tray.item("Pause")
+	if statsItem == nil || pauseItem == nil {
+		t.Fatalf("menu items not found in %d items", len(tray.items))
+	}
+
+	for _, tc := range menuBarCases {
+		t.Run(tc.name, func(t *testing.T) {
+			smb.UpdateStats(tc.stats)
+
+			if tray.title != tc.title {
+				t.Errorf("title = %q, want %q", tray.title, tc.title)
+			}
+			if !bytes.Equal(tray.icon, tc.icon) {
+				t.Errorf("icon does not match state %v", tc.state)
+			}
+			if tray.tooltip != smb.generateTooltip(tc.stats) {
+				t.Errorf("tooltip = %q, want %q", tray.tooltip, smb.generateTooltip(tc.stats))
+			}
+			if title, _ := statsItem.state(); title != smb.generateStatsText(tc.stats) {
+				t.Errorf("stats item = %q, want %q", title, smb.generateStatsText(tc.stats))
+			}
+
+			wantPause := "Pause"
+			if tc.stats.IsPaused {
+				wantPause = "Resume"
+			}
+			title, hidden := pauseItem.state()
+			if title != wantPause {
+				t.Errorf("pause item = %q, want %q", title, wantPause)
+			}
+			if hidden != (tc.stats.Error != "") {
+				t.Errorf("pause item hidden = %v with error %q", hidden, tc.stats.Error)
+			}
+		})
+	}
+}
+
+func TestUpdateStatsPauseAndResume(t *testing.T) {
+	smb, tray := newTestMenuBar()
+	smb.Run(nil, nil)
+	pauseItem := tray.item("Pause")
+
+	steps := []struct {
+		entry models.DailyTimeEntry
+		title string
+		pause string
+	}{
+		{models.DailyTimeEntry{ActiveMinutes: 120}, "⏱ 2.0h", "Pause"},
+		{models.DailyTimeEntry{ActiveMinutes: 120, IsPaused: true}, "⏸ 2.0h", "Resume"},
+		{models.DailyTimeEntry{ActiveMinutes: 130}, "⏱ 2.2h", "Pause"},
+	}
+	for _, step := range steps {
+		smb.UpdateStats(statsFor(step.entry, true))
+		if tray.title != step.title {
+			t.Errorf("title = %q, want %q", tray.title, step.title)
+		}
+		if title, _ := pauseItem.state(); title != step.pause {
+			t.Errorf("pause item = %q, want %q", title, step.pause)
+		}
+	}
+}
+
+func TestStatsFromTodayStatsShowsBreaks(t *testing.T) {
+	today := &tracker.TodayStats{
+		ActiveMinutes:  300,
+		GoalMinutes:    480,
+		Progress:       0.625,
+		IsSystemActive: true,
+		BreakMinutes:   60,
+	}
+	stats := StatsFromTodayStats(today, false)
+	if stats.BreakMinutes != 60 {
+		t.Fatalf("BreakMinutes = %d, want 60", stats.BreakMinutes)
+	}
+
+	smb, _ := newTestMenuBar()
+	if got := smb.generateTitle(stats); got != "⏱ 5.0h" {
+		t.Errorf("net title = %q, want %q", got, "⏱ 5.0h")
+	}
+	if got := smb.generateTooltip(stats); !strings.Contains(got, "Net 5.0h / gross 6.0h (1.0h in breaks)") {
+		t.Errorf("tooltip %q has no break line", got)
+	}
+
+	smb.config.UI.ShowNetActive = false
+	if got := smb.generateTitle(stats); got != "⏱ 6.0h" {
+		t.Errorf("gross title = %q, want %q", got, "⏱ 6.0h")
+	}
+	if got := smb.generateStatsText(stats); got != "Today: 6.0h / 8h (62%)" {
+		t.Errorf("gross stats item = %q, want %q", got, "Today: 6.0h / 8h (62%)")
+	}
+}
+
+func TestStatsFromTodayStats(t *testing.T) {
+	stats := StatsFromTodayStats(&tracker.TodayStats{
+		ActiveMinutes:     540,
+		GoalMinutes:       480,
+		Progress:          1,
+		UnclampedProgress: 1.125,
+		IsGoalReached:     true,
+		AutoLogged:        true,
+		IsPTO:             true,
+		IdleMinutes:       25,
+	}, true)
+
+	want := &MenuBarStats{
+		ActiveMinutes:     540,
+		GoalMinutes:       480,
+		Progress:          1,
+		UnclampedProgress: 1.125,
+		IsGoalReached:     true,
+		TrackingDisabled:  true,
+		IsPTO:             true,
+		IsLogged:          true,
+		IdleMinutes:       25,
+	}
+	if *stats != *want {
+		t.Errorf("StatsFromTodayStats = %+v, want %+v", stats, want)
+	}
+
+	if stats := StatsFromTodayStats(nil, false); stats.GoalMinutes != 480 {
+		t.Errorf("nil stats goal = %d, want the 8h default", stats.GoalMinutes)
+	}
+}
+
+func TestStatsFromTodayStatsShowsIdle(t *testing.T) {
+	stats := StatsFromTodayStats(&tracker.TodayStats{
+		ActiveMinutes: 300,
+		GoalMinutes:   480,
+		Progress:      0.625,
+		IdleMinutes:   45,
+	}, false)
+
+	smb, _ := newTestMenuBar()
+	if got := smb.generateTooltip(stats); !strings.Contains(got, "Credited 5.0h (skipped 0.8h idle)") {
+		t.Errorf("tooltip %q has no idle line", got)
+	}
+}
+
+func TestShowAsDays(t *testing.T) {
+	smb, _ := newTestMenuBar()
+	smb.config.UI.ShowAsDays = true
+
+	tests := []struct {
+		name      string
+		stats     *MenuBarStats
+		title     string
+		tooltip   string
+		statsText string
+	}{
+		{
+			name:      "half a day",
+			stats:     statsFor(models.DailyTimeEntry{ActiveMinutes: 240}, true),
+			title:     "⏱ 0.5d",
+			tooltip:   "Today: 0.5 / 1.0 days (50%)",
+			statsText: "Today: 0.5 / 1.0 days (50%)",
+		},
+		{
+			name:      "overtime past one day",
+			stats:     statsFor(models.DailyTimeEntry{ActiveMinutes: 660}, true),
+			title:     "✅ 1.4d",
+			tooltip:   "Today: 1.4 / 1.0 days (100%)",
+			statsText: "Today: 1.4 / 1.0 days (100%)",
+		},
+		{
+			name:      "no goal falls back to hours",
+			stats:     &MenuBarStats{ActiveMinutes: 90, IsSystemActive: true},
+			title:     "⏱ 1.5h",
+			tooltip:   "Today: 1.5h (no goal today)",
+			statsText: "Today: 1.5h (no goal)",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := smb.generateTitle(tc.stats); got != tc.title {
+				t.Errorf("generateTitle = %q, want %q", got, tc.title)
+			}
+			if got := smb.generateTooltip(tc.stats); !strings.Contains(got, tc.tooltip) {
+				t.Errorf("tooltip %q does not contain %q", got, tc.tooltip)
+			}
+			if got := smb.generateStatsText(tc.stats); got != tc.statsText {
+				t.Errorf("generateStatsText = %q, want %q", got, tc.statsText)
+			}
+		})
+	}
+}
+
+func TestShowOvertimeProgress(t *testing.T) {
+	smb, _ := newTestMenuBar()
+	overtime := statsFor(models.DailyTimeEntry{ActiveMinutes: 540}, true)
+	underGoal := statsFor(models.DailyTimeEntry{ActiveMinutes: 240}, true)
+
+	if got := smb.generateStatsText(overtime); got != "Today: 9.0h / 8h (100%)" {
+		t.Errorf("generateStatsText = %q, want progress stopped at 100%%", got)
+	}
+
+	smb.config.UI.ShowOvertimeProgress = true
+	if got := smb.generateStatsText(overtime); got != "Today: 9.0h / 8h (112%)" {
+		t.Errorf("generateStatsText = %q, want progress past 100%%", got)
+	}
+	if got := smb.generateTooltip(overtime); !strings.Contains(got, "Today: 9.0h / 8h (112%)") {
+		t.Errorf("tooltip %q does not show progress past 100%%", got)
+	}
+	if got := smb.generateStatsText(underGoal); got != "Today: 4.0h / 8h (50%)" {
+		t.Errorf("generateStatsText = %q, want 50%% under goal", got)
+	}
+}
+
+func TestProgressPercentRoundsDown(t *testing.T) {
+	tests := []struct {
+		minutes  int
+		overtime bool
+		want     int
+	}{
+		{479, false, 99},
+		{479, true, 99},
+		{480, false, 100},
+		{480, true, 100},
+		{481, false, 100},
+		{481, true, 100},
+		{552, true, 115},
+	}
+
+	for _, tc := range tests {
+		smb, _ := newTestMenuBar()
+		smb.config.UI.ShowOvertimeProgress = tc.overtime
+		stats := statsFor(models.DailyTimeEntry{ActiveMinutes: tc.minutes}, true)
+		if got := smb.progressPercent(stats); got != tc.want {
+			t.Errorf("%d minutes (overtime %v): progressPercent = %d, want %d", tc.minutes, tc.overtime, got, tc.want)
+		}
+	}
+}
+
+func TestETAText(t *testing.T) {
+	smb, _ := newTestMenuBar()
+	now := time.Date(2024, 5, 6, 14, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name  string
+		stats *MenuBarStats
+		use12 bool
+		want  string
+	}{
+		{"24 hour", statsFor(models.DailyTimeEntry{ActiveMinutes: 390}, true), false, "Done at ~15:30"},
+		{"12 hour", statsFor(models.DailyTimeEntry{ActiveMinutes: 390}, true), true, "Done at ~3:30 PM"},
+		{"paused", statsFor(models.DailyTimeEntry{ActiveMinutes: 390, IsPaused: true}, true), false, "ETA unavailable — paused"},
+		{"goal reached", statsFor(models.DailyTimeEntry{ActiveMinutes: 480}, true), false, "Goal reached"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			smb.config.UI.Use12HourFormat = tc.use12
+			if got := smb.etaText(tc.stats, now); got != tc.want {
+				t.Errorf("etaText = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTooltipShowsETA(t *testing.T) {
+	smb, _ := newTestMenuBar()
+	stats := statsFor(models.DailyTimeEntry{ActiveMinutes: 390}, true)
+
+	if got := smb.generateTooltip(stats); !strings.Contains(got, "Remaining: 1.5h") || strings.Contains(got, "Done at") {
+		t.Errorf("tooltip %q, want the remaining hours without ui.show_eta", got)
+	}
+
+	smb.config.UI.ShowETA = true
+	if got := smb.generateTooltip(stats); !strings.Contains(got, "Done at ~") || strings.Contains(got, "Remaining") {
+		t.Errorf("tooltip %q, want an ETA instead of the remaining hours", got)
+	}
+}
@@ -0,0 +1,52 @@
+package menubar
+
+import "github.com/getlantern/systray"
+
+// trayBackend is the subset of the systray API used by SystrayMenuBar.
+// It lets the menu bar logic run against a fake tray without a display.
+type trayBackend interface {
+	Run(onReady, onExit func())
+	Quit()
+	SetTitle(title string)
+	SetTooltip(tooltip string)
+	SetIcon(icon []byte)
+	AddMenuItem(title, tooltip string) trayMenuItem
+	AddSeparator()
+}
+
+// trayMenuItem is a single menu entry created by a trayBackend
+type trayMenuItem interface {
+	SetTitle(title string)
+	Disable()
+	Hide()
+	Show()
+	Check()
+	Uncheck()
+	Clicked() <-chan struct{}
+	AddSubMenuItem(title, tooltip string) trayMenuItem
+}
+
+// systrayBackend is the real trayBackend backed by getlantern/systray.
+// SetIcon differs per platform (tray_darwin.go, tray_linux.go).
+type systrayBackend struct{}
+
+func (systrayBackend) Run(onReady, onExit func()) { systray.Run(onReady, onExit) }
+func (systrayBackend) Quit()                      { systray.Quit() }
+func (systrayBackend) SetTitle(title string)      { systray.SetTitle(title) }
+func (systrayBackend) SetTooltip(tooltip string)  { systray.SetTooltip(tooltip) }
+func (systrayBackend) AddSeparator()              { systray.AddSeparator() }
+
+func (systrayBackend) AddMenuItem(title, tooltip string) trayMenuItem {
+	return systrayMenuItem{systray.AddMenuItem(title, tooltip)}
+}
+
+// systrayMenuItem adapts *systray.MenuItem to trayMenuItem
+type systrayMenuItem struct {
+	*systray.MenuItem
+}
+
+func (item systrayMenuItem) Clicked() <-chan struct{} { return item.ClickedCh }
+
+func (item systrayMenuItem) AddSubMenuItem(title, tooltip string) trayMenuItem {
+	return systrayMenuItem{item.MenuItem.AddSubMenuItem(title, tooltip)}
+}
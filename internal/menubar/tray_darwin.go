@@ -0,0 +1,11 @@
+//go:build darwin
+
+package menubar
+
+import "github.com/getlantern/systray"
+
+// fileManagerCommand opens a folder in Finder
+const fileManagerCommand = "open"
+
+// SetIcon uses a template icon, so macOS tints it for light and dark menu bars
+func (systrayBackend) SetIcon(icon []byte) { systray.SetTemplateIcon(icon, icon) }
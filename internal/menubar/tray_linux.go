@@ -0,0 +1,13 @@
+//go:build linux
+
+package menubar
+
+import "github.com/getlantern/systray"
+
+// fileManagerCommand opens a folder in the desktop's file manager
+const fileManagerCommand = "xdg-open"
+
+// SetIcon sets the indicator icon. Template icons are a macOS concept, so the
+// colored icon is used as is. The tray needs libayatana-appindicator (or
+// libappindicator) at runtime, e.g. the AppIndicator extension on GNOME.
+func (systrayBackend) SetIcon(icon []byte) { systray.SetIcon(icon) }
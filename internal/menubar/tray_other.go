@@ -0,0 +1,11 @@
+//go:build !darwin && !linux
+
+package menubar
+
+import "github.com/getlantern/systray"
+
+// fileManagerCommand opens a folder in Explorer
+const fileManagerCommand = "explorer"
+
+// SetIcon sets the tray icon as is
+func (systrayBackend) SetIcon(icon []byte) { systray.SetIcon(icon) }
@@ -0,0 +1,114 @@
+package menubar
+
+import "sync"
+
+// fakeTray is a trayBackend that records what the menu bar shows, so the
+// menu bar logic can be tested without a display
+type fakeTray struct {
+	mu      sync.Mutex
+	title   string
+	tooltip string
+	icon    []byte
+	items   []*fakeMenuItem
+	quit    bool
+}
+
+// Run calls onReady straight away, like systray once the tray is up
+func (ft *fakeTray) Run(onReady, onExit func()) { onReady() }
+
+func (ft *fakeTray) Quit() {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	ft.quit = true
+}
+
+func (ft *fakeTray) SetTitle(title string) {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	ft.title = title
+}
+
+func (ft *fakeTray) SetTooltip(tooltip string) {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	ft.tooltip = tooltip
+}
+
+func (ft *fakeTray) SetIcon(icon []byte) {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	ft.icon = icon
+}
+
+func (ft *fakeTray) AddMenuItem(title, tooltip string) trayMenuItem {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	item := newFakeMenuItem(title)
+	ft.items = append(ft.items, item)
+	return item
+}
+
+func (ft *fakeTray) AddSeparator() {}
+
+// item returns the top-level menu item that was added with title, or nil
+func (ft *fakeTray) item(title string) *fakeMenuItem {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	for _, item := range ft.items {
+		if item.added == title {
+			return item
+		}
+	}
+	return nil
+}
+
+// fakeMenuItem is a trayMenuItem that records its state
+type fakeMenuItem struct {
+	mu       sync.Mutex
+	added    string // Title when the item was created
+	title    string
+	disabled bool
+	hidden   bool
+	checked  bool
+	clicked  chan struct{}
+	children []*fakeMenuItem
+}
+
+func newFakeMenuItem(title string) *fakeMenuItem {
+	return &fakeMenuItem{added: title, title: title, clicked: make(chan struct{})}
+}
+
+func (fi *fakeMenuItem) SetTitle(title string) {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	fi.title = title
+}
+
+func (fi *fakeMenuItem) Disable() { fi.set(&fi.disabled, true) }
+func (fi *fakeMenuItem) Hide()    { fi.set(&fi.hidden, true) }
+func (fi *fakeMenuItem) Show()    { fi.set(&fi.hidden, false) }
+func (fi *fakeMenuItem) Check()   { fi.set(&fi.checked, true) }
+func (fi *fakeMenuItem) Uncheck() { fi.set(&fi.checked, false) }
+
+func (fi *fakeMenuItem) Clicked() <-chan struct{} { return fi.clicked }
+
+func (fi *fakeMenuItem) AddSubMenuItem(title, tooltip string) trayMenuItem {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	child := newFakeMenuItem(title)
+	fi.children = append(fi.children, child)
+	return child
+}
+
+func (fi *fakeMenuItem) set(field *bool, value bool) {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	*field = value
+}
+
+// state returns the item's current title and whether it is hidden
+func (fi *fakeMenuItem) state() (string, bool) {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	return fi.title, fi.hidden
+}
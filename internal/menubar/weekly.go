@@ -0,0 +1,118 @@
+package menubar
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"timeclip/internal/database"
+	"timeclip/internal/models"
+)
+
+// weeklyRefresh is how long the week's total from earlier days is reused
+// before it is queried again. Today's minutes are always live.
+const weeklyRefresh = 15 * time.Minute
+
+// WeeklyStatsSource provides the week's totals for ui.goal_basis = "weekly"
+// (*tracker.Timer implements it)
+type WeeklyStatsSource interface {
+	GetWeeklyStats() (*database.WeeklyStats, error)
+}
+
+// weeklyProgress caches the minutes tracked earlier in the week, so the
+// menu bar can show weekly progress without a query every minute
+type weeklyProgress struct {
+	source WeeklyStatsSource
+
+	mu      sync.Mutex
+	date    string // Day the cache was filled
+	earlier int    // Week total minus today's minutes at that time
+	fetched time.Time
+}
+
+// SetWeeklyStatsSource enables weekly progress for ui.goal_basis = "weekly".
+// Without it the menu bar falls back to today's goal. Must be called before Run.
+func (smb *SystrayMenuBar) SetWeeklyStatsSource(source WeeklyStatsSource) {
+	smb.weekly = &weeklyProgress{source: source}
+}
+
+// minutes returns the week's total with today counted as todayMinutes
+func (wp *weeklyProgress) minutes(todayMinutes int, now time.Time) (int, error) {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	date := now.Format("2006-01-02")
+	if wp.date != date || now.Sub(wp.fetched) >= weeklyRefresh {
+		stats, err := wp.source.GetWeeklyStats()
+		if err != nil {
+			if wp.date != date {
+				return 0, err
+			}
+			// Keep using today's earlier total until the next refresh works
+			log.Printf("Error refreshing weekly stats: %v", err)
+		} else {
+			wp.date = date
+			wp.earlier = stats.TotalMinutes - todayMinutes
+			wp.fetched = now
+		}
+	}
+	return wp.earlier + todayMinutes, nil
+}
+
+// withWeekProgress returns stats with the week's minutes and goal filled in
+// when ui.goal_basis is weekly
+func (smb *SystrayMenuBar) withWeekProgress(stats *MenuBarStats) *MenuBarStats {
+	if smb.config.UI.GoalBasis != models.GoalBasisWeekly || smb.weekly == nil {
+		return stats
+	}
+
+	now := time.Now()
+	minutes, err := smb.weekly.minutes(stats.ActiveMinutes, now)
+	if err != nil {
+		log.Printf("Error loading weekly stats, showing today's progress: %v", err)
+		return stats
+	}
+
+	withWeek := *stats
+	withWeek.WeekMinutes = minutes
+	withWeek.WeekGoalMinutes = smb.config.General.WeeklyGoalMinutes(now)
+	return &withWeek
+}
+
+// weeklyBasis returns true if the week's progress drives the display
+func (smb *SystrayMenuBar) weeklyBasis(stats *MenuBarStats) bool {
+	return smb.config.UI.GoalBasis == models.GoalBasisWeekly && stats.WeekGoalMinutes > 0
+}
+
+// weekProgressPercent returns the week's progress, past 100 when over goal if
+// ui.show_overtime_progress is set
+func (smb *SystrayMenuBar) weekProgressPercent(stats *MenuBarStats) int {
+	progress := float64(stats.WeekMinutes) / float64(stats.WeekGoalMinutes)
+	if progress > 1 && !smb.config.UI.ShowOvertimeProgress {
+		progress = 1
+	}
+	return percent(progress)
+}
+
+// weeklyTooltip creates the tooltip for ui.goal_basis = "weekly"
+func (smb *SystrayMenuBar) weeklyTooltip(stats *MenuBarStats, status string) string {
+	if status == "Goal Reached!" {
+		status = "Weekly Goal Reached!"
+	}
+
+	weekHours := float64(stats.WeekMinutes) / 60.0
+	tooltip := fmt.Sprintf("Timeclip - %s\nWeek: %.1fh / %.0fh (%d%%)\nToday: %.1fh",
+		status, weekHours, float64(stats.WeekGoalMinutes)/60.0, smb.weekProgressPercent(stats), float64(stats.ActiveMinutes)/60.0)
+
+	if remaining := stats.WeekGoalMinutes - stats.WeekMinutes; remaining > 0 {
+		tooltip += fmt.Sprintf("\nRemaining this week: %.1fh", float64(remaining)/60.0)
+	} else if remaining < 0 {
+		tooltip += fmt.Sprintf("\nOvertime this week: %.1fh", float64(-remaining)/60.0)
+	}
+
+	if smb.config.General.ObserverMode {
+		tooltip += "\nObserver mode: showing time tracked on another machine"
+	}
+	return tooltip
+}
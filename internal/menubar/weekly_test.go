@@ -0,0 +1,167 @@
+package menubar
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"timeclip/internal/database"
+	"timeclip/internal/models"
+)
+
+// fakeWeeklySource reports total as the week's minutes and counts queries
+type fakeWeeklySource struct {
+	total   int
+	err     error
+	queries int
+}
+
+func (fs *fakeWeeklySource) GetWeeklyStats() (*database.WeeklyStats, error) {
+	fs.queries++
+	if fs.err != nil {
+		return nil, fs.err
+	}
+	return &database.WeeklyStats{TotalMinutes: fs.total}, nil
+}
+
+func TestWeeklyGoalBasis(t *testing.T) {
+	tests := []struct {
+		name    string
+		basis   string
+		today   int
+		week    int // Week's total including today
+		goal    int // Week's goal
+		state   MenuState
+		title   string
+		tooltip []string
+	}{
+		{
+			name:    "daily basis ignores the week",
+			basis:   models.GoalBasisDaily,
+			today:   480,
+			week:    1200,
+			goal:    2400,
+			state:   MenuStateActive,
+			title:   "✅ 8.0h",
+			tooltip: []string{"Timeclip - Goal Reached!", "Today: 8.0h / 8h (100%)"},
+		},
+		{
+			name:    "mid-week",
+			basis:   models.GoalBasisWeekly,
+			today:   480,
+			week:    1200,
+			goal:    2400,
+			state:   MenuStateInactive,
+			title:   "⏱ 20h wk",
+			tooltip: []string{"Week: 20.0h / 40h (50%)", "Today: 8.0h", "Remaining this week: 20.0h"},
+		},
+		{
+			name:    "early in the week",
+			basis:   models.GoalBasisWeekly,
+			today:   150,
+			week:    390,
+			goal:    2400,
+			state:   MenuStateInactive,
+			title:   "⏱ 6.5h wk",
+			tooltip: []string{"Week: 6.5h / 40h (16%)", "Remaining this week: 33.5h"},
+		},
+		{
+			name:    "weekly goal reached",
+			basis:   models.GoalBasisWeekly,
+			today:   300,
+			week:    2460,
+			goal:    2400,
+			state:   MenuStateActive,
+			title:   "✅ 41h wk",
+			tooltip: []string{"Timeclip - Weekly Goal Reached!", "Week: 41.0h / 40h (100%)", "Overtime this week: 1.0h"},
+		},
+		{
+			name:    "no weekly totals yet",
+			basis:   models.GoalBasisWeekly,
+			today:   240,
+			week:    0,
+			goal:    0,
+			state:   MenuStateInactive,
+			title:   "⏱ 4.0h",
+			tooltip: []string{"Today: 4.0h / 8h (50%)"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			smb, _ := newTestMenuBar()
+			smb.config.UI.GoalBasis = tc.basis
+			stats := statsFor(models.DailyTimeEntry{ActiveMinutes: tc.today}, true)
+			stats.WeekMinutes, stats.WeekGoalMinutes = tc.week, tc.goal
+
+			if got := smb.determineMenuState(stats); got != tc.state {
+				t.Errorf("determineMenuState = %v, want %v", got, tc.state)
+			}
+			if got := smb.generateTitle(stats); got != tc.title {
+				t.Errorf("generateTitle = %q, want %q", got, tc.title)
+			}
+			tooltip := smb.generateTooltip(stats)
+			for _, line := range tc.tooltip {
+				if !strings.Contains(tooltip, line) {
+					t.Errorf("tooltip %q does not contain %q", tooltip, line)
+				}
+			}
+		})
+	}
+}
+
+func TestWithWeekProgress(t *testing.T) {
+	source := &fakeWeeklySource{total: 1500}
+	smb, _ := newTestMenuBar()
+	smb.config.General.WeeklyGoalHours = 40
+	smb.SetWeeklyStatsSource(source)
+	today := statsFor(models.DailyTimeEntry{ActiveMinutes: 300}, true)
+
+	if got := smb.withWeekProgress(today); got != today || source.queries != 0 {
+		t.Errorf("daily basis: stats changed or %d queries, want untouched", source.queries)
+	}
+
+	smb.config.UI.GoalBasis = models.GoalBasisWeekly
+	got := smb.withWeekProgress(today)
+	if got.WeekMinutes != 1500 || got.WeekGoalMinutes != 2400 {
+		t.Errorf("week = %d / %d minutes, want 1500 / 2400", got.WeekMinutes, got.WeekGoalMinutes)
+	}
+	if today.WeekMinutes != 0 {
+		t.Error("withWeekProgress changed the stats it was given")
+	}
+}
+
+func TestWeeklyProgressCache(t *testing.T) {
+	source := &fakeWeeklySource{total: 1500}
+	wp := &weeklyProgress{source: source}
+	start := time.Date(2024, 5, 8, 10, 0, 0, 0, time.Local)
+
+	steps := []struct {
+		name    string
+		now     time.Time
+		today   int
+		total   int   // What the source reports from this step on
+		err     error // Same
+		want    int
+		queries int
+		wantErr bool
+	}{
+		{"first query", start, 300, 1500, nil, 1500, 1, false},
+		{"today stays live", start.Add(10 * time.Minute), 310, 9000, nil, 1510, 1, false},
+		{"refreshed after 15 minutes", start.Add(weeklyRefresh), 320, 1600, nil, 1600, 2, false},
+		{"failed refresh keeps the total", start.Add(30 * time.Minute), 330, 0, errors.New("database is locked"), 1610, 3, false},
+		{"nothing to keep on a new day", start.Add(24 * time.Hour), 0, 0, errors.New("database is locked"), 0, 4, true},
+		{"new day", start.Add(24*time.Hour + time.Minute), 5, 1700, nil, 1700, 5, false},
+	}
+	for _, step := range steps {
+		source.total, source.err = step.total, step.err
+		got, err := wp.minutes(step.today, step.now)
+		if (err != nil) != step.wantErr {
+			t.Fatalf("%s: minutes error = %v, want error %v", step.name, err, step.wantErr)
+		}
+		if got != step.want || source.queries != step.queries {
+			t.Errorf("%s: minutes = %d after %d queries, want %d after %d", step.name, got, source.queries, step.want, step.queries)
+		}
+	}
+}
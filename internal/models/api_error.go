@@ -0,0 +1,68 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// APIError represents an error response from a time tracking API
+type APIError struct {
+	Service    string `json:"service"`
+	StatusCode int    `json:"status_code"`
+	Message    string `json:"message"`
+	Details    string `json:"details,omitempty"` // Response body
+}
+
+// Error implements the error interface
+func (ae *APIError) Error() string {
+	if ae.Details != "" {
+		return fmt.Sprintf("%s API error (%d): %s - %s", ae.Service, ae.StatusCode, ae.Message, ae.Details)
+	}
+	return fmt.Sprintf("%s API error (%d): %s", ae.Service, ae.StatusCode, ae.Message)
+}
+
+// IsAuthenticationError returns true if the error is related to authentication
+func (ae *APIError) IsAuthenticationError() bool {
+	return ae.StatusCode == 401 || ae.StatusCode == 403
+}
+
+// IsRateLimitError returns true if the error is due to rate limiting
+func (ae *APIError) IsRateLimitError() bool {
+	return ae.StatusCode == 429
+}
+
+// ErrorInBody looks for an error reported in a successful (2xx) response, as
+// some proxies do. It returns the named top-level fields of a JSON object body
+// that are set to something other than null, false, zero or empty, e.g.
+// "message: Workspace is locked", or "" if there are none or the body isn't a
+// JSON object.
+func ErrorInBody(body []byte, fields []string) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(body, &object); err != nil {
+		return ""
+	}
+
+	var found []string
+	for _, field := range fields {
+		raw, ok := object[field]
+		if !ok {
+			continue
+		}
+		switch value := strings.TrimSpace(string(raw)); value {
+		case "null", "false", "0", `""`, "[]", "{}":
+			continue
+		default:
+			var text string
+			if json.Unmarshal(raw, &text) == nil {
+				value = text
+			}
+			found = append(found, fmt.Sprintf("%s: %s", field, value))
+		}
+	}
+	return strings.Join(found, ", ")
+}
@@ -0,0 +1,32 @@
+package models
+
+import "testing"
+
+func TestErrorInBody(t *testing.T) {
+	fields := []string{"error", "message"}
+
+	tests := []struct {
+		name   string
+		body   string
+		fields []string
+		want   string
+	}{
+		{"no fields configured", `{"error":"locked"}`, nil, ""},
+		{"normal response", `{"id":"entry-1","description":"Work"}`, fields, ""},
+		{"error string", `{"id":"","error":"Workspace is locked"}`, fields, "error: Workspace is locked"},
+		{"two fields", `{"error":true,"message":"Rate limited"}`, fields, "error: true, message: Rate limited"},
+		{"empty values", `{"error":null,"message":"","errors":[]}`, []string{"error", "message", "errors"}, ""},
+		{"false and zero", `{"error":false,"code":0}`, []string{"error", "code"}, ""},
+		{"nested object", `{"error":{"code":"LOCKED"}}`, fields, `error: {"code":"LOCKED"}`},
+		{"not an object", `[{"error":"locked"}]`, fields, ""},
+		{"not json", `<html>Bad gateway</html>`, fields, ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ErrorInBody([]byte(tc.body), tc.fields); got != tc.want {
+				t.Errorf("ErrorInBody = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -33,6 +33,13 @@ type Project struct {
 	WorkspaceID string `json:"workspace_id"`
 }
 
+// Activity is a category of work within a project (Magnetic's activities/phases)
+type Activity struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	ProjectID string `json:"project_id"`
+}
+
 // NewAPIResponse creates a new API response with current timestamp
 func NewAPIResponse(success bool, message string) *APIResponse {
 	return &APIResponse{
@@ -1,5 +1,19 @@
 package models
 
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DataDirEnv overrides general.data_dir, and also relocates config.toml
+const DataDirEnv = "TIMECLIP_HOME"
+
+// DefaultDataDir holds the database, lock file and logs unless relocated
+const DefaultDataDir = "~/.timeclip"
+
 // Config represents the application configuration
 type Config struct {
 	General  GeneralConfig  `toml:"general"`
@@ -11,14 +25,60 @@ type Config struct {
 // GeneralConfig contains general application settings
 type GeneralConfig struct {
 	GoalTimeHours        int      `toml:"goal_time_hours"`
+	WeeklyGoalHours      float64  `toml:"weekly_goal_hours"` // Split across track days instead of goal_time_hours; 0 disables
+	Distribution         string   `toml:"distribution"` // How weekly_goal_hours is split: "even", "frontloaded" or "custom"
+	DistributionWeights  map[string]float64 `toml:"distribution_weights"` // Per-day weights for distribution = "custom"
+	MinGoalHours         float64  `toml:"min_goal_hours"` // Soft minimum for the menu bar color; 0 disables
 	AutoLogThresholdHours float64 `toml:"auto_log_threshold_hours"`
 	TrackDays            []string `toml:"track_days"`
 	CheckIntervalSeconds int      `toml:"check_interval_seconds"`
+	WorkStart            string       `toml:"work_start"`
+	WorkEnd              string       `toml:"work_end"`
+	Breaks               []TimeWindow `toml:"breaks"`
+	IdleThresholdSeconds int          `toml:"idle_threshold_seconds"` // 0 disables idle detection
+	EventMinIntervalSeconds int       `toml:"event_min_interval_seconds"` // 0 logs every state change
+	DetectMeetings       bool         `toml:"detect_meetings"`
+	LockedCountsInactive bool         `toml:"locked_counts_inactive"` // A locked screen stops crediting, like the screensaver
+	ObserverMode         bool         `toml:"observer_mode"` // Only display stats from a database another machine tracks into
+	CreditOnStartup      bool         `toml:"credit_on_startup"` // Credit a minute at launch if the first poll is active
+	RequireRecentInput   bool         `toml:"require_recent_input"` // Inactive without input within idle_threshold_seconds
+	IdleIsPrimary        bool         `toml:"idle_is_primary"` // Idle past idle_threshold_seconds marks the system inactive
+	ResumeRequiresSustainedInput bool `toml:"resume_requires_sustained_input"` // Idle ends after a few seconds of input, not one event
+	ClamshellCountsInactive bool      `toml:"clamshell_counts_inactive"` // Lid closed with an external display counts as inactive
+	DisplaySleepInactive bool         `toml:"display_sleep_inactive"` // All displays asleep (Energy Saver) counts as inactive
+	ScreensaverCountsActive bool      `toml:"screensaver_counts_active"` // Keep crediting while the screensaver runs, e.g. when presenting
+	PaceAlertRatio       float64      `toml:"pace_alert_ratio"` // Alert when below this fraction of the expected pace; 0 disables
+	LongPauseMinutes     int          `toml:"long_pause_minutes"` // Report resuming after a pause at least this long; 0 disables
+	HeartbeatMinutes     int          `toml:"heartbeat_minutes"` // Log a tracking summary line this often; 0 disables
+	PostWakeWarmupSeconds int         `toml:"post_wake_warmup_seconds"` // Don't credit this soon after wake; 0 disables
+	OnUnknownState       string       `toml:"on_unknown_state"` // "active", "inactive" or "last_known" when macOS APIs fail
+	MachineLabel         string       `toml:"machine_label"` // Identifies this machine in logged descriptions
+	MachineLabelAuto     bool         `toml:"machine_label_auto"` // Use the hostname when machine_label is empty
+	PauseOnNetworkLoss   bool         `toml:"pause_on_network_loss"` // Pause while network_probe_host is unreachable
+	NetworkProbeHost     string       `toml:"network_probe_host"` // "host" or "host:port" (port defaults to 443)
+	ActivityMode         string       `toml:"activity_mode"` // "presence" (per-minute polling) or "input" (credit measured input activity)
+	StartupLockWaitSeconds int        `toml:"startup_lock_wait_seconds"` // How long startup waits for a previous instance to exit
+	ForceUnlockOrphanedLock bool      `toml:"force_unlock_orphaned_lock"` // After the wait, remove a lock whose process is gone or a zombie
+	MinTrackedMinutes    int          `toml:"min_tracked_minutes"` // Days below this don't count as tracked in stats; 0 counts every day
+	ExcludePTOFromStats  bool         `toml:"exclude_pto_from_stats"` // Leave days marked as PTO out of stats
+	WeekStart            string       `toml:"week_start"` // "monday" or "sunday"; first day of the week in weekly stats
+	DataDir              string       `toml:"data_dir"` // Holds the database, lock file and logs; empty uses ~/.timeclip
+	WriteStatusFile      bool         `toml:"write_status_file"` // Keep status.json in the data directory up to date for other tools
+	JournalFile          string       `toml:"journal_file"` // Append a summary line per finished day to this file; empty disables
+	JournalTemplate      string       `toml:"journal_template"` // Format of the journal lines; empty uses the default
+	OnActiveCmd          string       `toml:"on_active_cmd"` // Shell command run when the system becomes active
+	OnInactiveCmd        string       `toml:"on_inactive_cmd"` // Shell command run when the system becomes inactive
+	OnPauseCmd           string       `toml:"on_pause_cmd"` // Shell command run when tracking is paused
+	OnResumeCmd          string       `toml:"on_resume_cmd"` // Shell command run when tracking is resumed
 }
 
 // DatabaseConfig contains database settings
 type DatabaseConfig struct {
-	Path string `toml:"path"`
+	Path             string `toml:"path"` // Empty uses timeclip.db in the data directory
+	AllowNetworkPath bool   `toml:"allow_network_path"` // Acknowledge a database in a synced/network folder
+	OpenRetries      int    `toml:"open_retries"` // Retry opening the database at startup this many times; 0 fails at once
+	OpenRetryDelaySeconds int `toml:"open_retry_delay_seconds"` // Wait between open retries
+	CommitIntervalMinutes int `toml:"commit_interval_minutes"` // Keep credited minutes in memory and write them this often; 0 writes every minute
 }
 
 // APIConfig contains API configuration
@@ -26,6 +86,31 @@ type APIConfig struct {
 	PreferredProvider string           `toml:"preferred_provider"`
 	RetryAttempts     int              `toml:"retry_attempts"`
 	TimeoutSeconds    int              `toml:"timeout_seconds"`
+	MaxDailyAttempts  int              `toml:"max_daily_attempts"` // Give up auto-logging a day after this many failures; 0 = never
+	DescriptionTemplate string         `toml:"description_template"`
+	RoundLoggedMinutes int             `toml:"round_logged_minutes"` // Round only the submitted total to this step; 0 sends raw minutes
+	CacheTTLMinutes   int              `toml:"cache_ttl_minutes"` // How long workspace/project listings are cached; 0 disables
+	UseRealStartTime  bool             `toml:"use_real_start_time"` // Start Clockify entries at the day's first active event
+	QueueRetryMinutes int              `toml:"queue_retry_minutes"` // Retry interval for the offline queue of failed logs; 0 disables the queue
+	CatchupConfirmThreshold int        `toml:"catchup_confirm_threshold"` // Hold a queue of more days than this until approved; 0 never asks
+	MinAttemptIntervalSeconds int      `toml:"min_attempt_interval_seconds"` // Don't auto-log the same day again within this many seconds; 0 disables
+	LogNonTrackDays   bool             `toml:"log_non_track_days"` // Auto-log time tracked on days outside general.track_days
+	LogOnQuit         bool             `toml:"log_on_quit"` // Log today's time when Timeclip quits
+	LogOnQuitMinMinutes int            `toml:"log_on_quit_min_minutes"` // Skip logging on quit below this many active minutes
+	AutoLogThresholdRatio float64      `toml:"auto_log_threshold_ratio"` // Auto-log at this fraction of the day's goal; 0 uses general.auto_log_threshold_hours
+	FallbackProjectID string           `toml:"fallback_project_id"` // Used when the selected and configured projects are archived or deleted
+	AutoSelectSingle  bool             `toml:"auto_select_single"` // Use the only workspace/project when none is configured
+	OvertimeDescription string         `toml:"overtime_description"` // Log minutes over the goal as a separate entry with this description; empty logs one entry
+	OvertimeProjectID string           `toml:"overtime_project_id"` // Project for the overtime entry; empty uses the regular project
+	SplitAtLunch      bool             `toml:"split_at_lunch"` // Log the time before and after general.breaks as separate entries
+	MinGoalRatioToLog float64          `toml:"min_goal_ratio_to_log"` // Never auto-log a day below this fraction of its goal; 0 disables
+	StartupCheckTimeoutSeconds int     `toml:"startup_check_timeout_seconds"` // Bound on the parallel provider check at startup; 0 skips it
+	LogBasis          string           `toml:"log_basis"` // "daily" (log each day at the threshold) or "weekly" (log the week's days once it ends)
+	IncludeNotes      bool             `toml:"include_notes"` // Append the day's notes to logged descriptions
+	VerifyAfterLog    bool             `toml:"verify_after_log"` // Re-read the provider after logging and only mark days found there as logged
+	ConfirmFirstWrite bool             `toml:"confirm_first_write"` // Ask before the first entry is ever created at a provider
+	MaxBacklogDays    int              `toml:"max_backlog_days"` // Never auto-log days older than this many days; 0 has no limit
+	AbandonOldDays    bool             `toml:"abandon_old_days"` // Mark days past max_backlog_days as abandoned so they stop counting as unlogged
 	Magnetic          MagneticConfig   `toml:"magnetic"`
 	Clockify          ClockifyConfig   `toml:"clockify"`
 }
@@ -37,15 +122,72 @@ type MagneticConfig struct {
 	APIKey      string `toml:"api_key"`
 	WorkspaceID string `toml:"workspace_id"`
 	ProjectID   string `toml:"project_id"`
+	ActivityID  string `toml:"activity_id"` // Activity (phase) within the project to log to; empty logs to the project
+	TimeoutSeconds int `toml:"timeout_seconds"` // Overrides api.timeout_seconds when > 0
+	RetryAttempts  int `toml:"retry_attempts"`  // Overrides api.retry_attempts when > 0
+	ErrorFields    []string `toml:"error_fields"` // Response fields that mean failure even on a 2xx status
+	RefreshOnUnauthorized bool `toml:"refresh_on_unauthorized"` // Refresh expiring credentials and retry once on a 401
 }
 
 // ClockifyConfig contains Clockify API settings
 type ClockifyConfig struct {
-	Enabled     bool   `toml:"enabled"`
-	BaseURL     string `toml:"base_url"`
-	APIKey      string `toml:"api_key"`
-	WorkspaceID string `toml:"workspace_id"`
-	ProjectID   string `toml:"project_id"`
+	Enabled      bool              `toml:"enabled"`
+	BaseURL      string            `toml:"base_url"`
+	APIKey       string            `toml:"api_key"`
+	WorkspaceID  string            `toml:"workspace_id"`
+	ProjectID    string            `toml:"project_id"`
+	CustomFields map[string]string `toml:"custom_fields"` // custom field ID → value
+	TimeoutSeconds int             `toml:"timeout_seconds"` // Overrides api.timeout_seconds when > 0
+	RetryAttempts  int             `toml:"retry_attempts"`  // Overrides api.retry_attempts when > 0
+	ErrorFields    []string        `toml:"error_fields"` // Response fields that mean failure even on a 2xx status
+	RefreshOnUnauthorized bool     `toml:"refresh_on_unauthorized"` // Refresh expiring credentials and retry once on a 401
+}
+
+// ProviderTimeoutSeconds returns the request timeout for a provider,
+// falling back to the global timeout when the provider doesn't override it
+func (a *APIConfig) ProviderTimeoutSeconds(provider string) int {
+	override := 0
+	switch provider {
+	case "magnetic":
+		override = a.Magnetic.TimeoutSeconds
+	case "clockify":
+		override = a.Clockify.TimeoutSeconds
+	}
+
+	if override > 0 {
+		return override
+	}
+	return a.TimeoutSeconds
+}
+
+// RefreshOnUnauthorized returns true if a 401 from provider should refresh
+// the client's credentials and retry once. Only clients with expiring
+// credentials can refresh; for static API keys the setting has no effect.
+func (a *APIConfig) RefreshOnUnauthorized(provider string) bool {
+	switch provider {
+	case "magnetic":
+		return a.Magnetic.RefreshOnUnauthorized
+	case "clockify":
+		return a.Clockify.RefreshOnUnauthorized
+	}
+	return false
+}
+
+// ProviderRetryAttempts returns the retry count for a provider,
+// falling back to the global retry count when the provider doesn't override it
+func (a *APIConfig) ProviderRetryAttempts(provider string) int {
+	override := 0
+	switch provider {
+	case "magnetic":
+		override = a.Magnetic.RetryAttempts
+	case "clockify":
+		override = a.Clockify.RetryAttempts
+	}
+
+	if override > 0 {
+		return override
+	}
+	return a.RetryAttempts
 }
 
 // UIConfig contains user interface settings
@@ -53,6 +195,77 @@ type UIConfig struct {
 	ShowMenuBar     bool `toml:"show_menu_bar"`
 	ShowSeconds     bool `toml:"show_seconds"`
 	Use12HourFormat bool `toml:"use_12_hour_format"`
+	ShowAsDays      bool `toml:"show_as_days"` // Show progress in workdays (goal = 1 day)
+	ShowNetActive   bool `toml:"show_net_active"` // Show active time excluding breaks (false = including breaks)
+	ShowOvertimeProgress bool `toml:"show_overtime_progress"` // Let progress go past 100% (e.g. "112%") instead of stopping at the goal
+	ShowETA         bool `toml:"show_eta"` // Show when the goal will be reached ("Done at ~5:30 PM") instead of the remaining hours
+	ConfirmQuitUnlogged bool `toml:"confirm_quit_unlogged"` // Ask before quitting with unlogged time today
+	NotificationSound string `toml:"notification_sound"` // macOS system sound played with notifications, or "none"
+	GoalBasis       string `toml:"goal_basis"` // "daily" or "weekly": which goal drives the menu bar color, title and tooltip
+}
+
+// MinGoalMinutes returns the soft minimum goal in minutes (0 when disabled)
+func (g *GeneralConfig) MinGoalMinutes() int {
+	return int(g.MinGoalHours * 60)
+}
+
+// AutoLogThresholdMinutes returns how many active minutes a day with the given
+// goal needs before it is auto-logged. api.auto_log_threshold_ratio takes
+// precedence when set, so days with a shorter goal are logged proportionally
+// sooner; otherwise general.auto_log_threshold_hours applies to every day.
+func (c *Config) AutoLogThresholdMinutes(goalMinutes int) int {
+	if c.API.AutoLogThresholdRatio > 0 && goalMinutes > 0 {
+		return int(math.Ceil(c.API.AutoLogThresholdRatio * float64(goalMinutes)))
+	}
+	return int(c.General.AutoLogThresholdHours * 60)
+}
+
+// BacklogCutoff returns the oldest date (YYYY-MM-DD) api.max_backlog_days lets
+// the auto-logger consider, or "" when there is no limit
+func (a *APIConfig) BacklogCutoff(now time.Time) string {
+	if a.MaxBacklogDays <= 0 {
+		return ""
+	}
+	return now.AddDate(0, 0, -a.MaxBacklogDays).Format("2006-01-02")
+}
+
+// DataDir returns the data directory: $TIMECLIP_HOME, general.data_dir, or
+// ~/.timeclip. It may start with ~/; callers expand it.
+func (c *Config) DataDir() string {
+	if dir := os.Getenv(DataDirEnv); dir != "" {
+		return dir
+	}
+	if c.General.DataDir != "" {
+		return c.General.DataDir
+	}
+	return DefaultDataDir
+}
+
+// DatabasePath returns database.path, or timeclip.db in the data directory
+// when it is empty. An explicit database.path always wins.
+func (c *Config) DatabasePath() string {
+	if c.Database.Path != "" {
+		return c.Database.Path
+	}
+	return filepath.Join(c.DataDir(), "timeclip.db")
+}
+
+// OpenRetryDelay returns database.open_retry_delay_seconds as a duration
+func (d *DatabaseConfig) OpenRetryDelay() time.Duration {
+	return time.Duration(d.OpenRetryDelaySeconds) * time.Second
+}
+
+// Label returns the machine label for logged descriptions: machine_label, or
+// the hostname when it is empty and machine_label_auto is set
+func (g *GeneralConfig) Label() string {
+	if g.MachineLabel != "" || !g.MachineLabelAuto {
+		return g.MachineLabel
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(hostname, ".local")
 }
 
 // DefaultConfig returns a configuration with sensible defaults
@@ -60,30 +273,59 @@ func DefaultConfig() *Config {
 	return &Config{
 		General: GeneralConfig{
 			GoalTimeHours:         8,
+			Distribution:          DistributionEven,
 			AutoLogThresholdHours: 6.0,
 			TrackDays:             []string{"monday", "tuesday", "wednesday", "thursday", "friday"},
+			Breaks:                []TimeWindow{}, // Saved as "breaks = []", which loads back empty rather than nil
 			CheckIntervalSeconds:  60,
+			CreditOnStartup:       true,
+			OnUnknownState:        "inactive",
+			ActivityMode:          "presence",
+			StartupLockWaitSeconds: 30,
+			ExcludePTOFromStats:   true,
+			WeekStart:             "monday",
+			LockedCountsInactive:  true,
+			DisplaySleepInactive:  true,
 		},
 		Database: DatabaseConfig{
-			Path: "~/.timeclip/timeclip.db",
+			Path: "", // timeclip.db in the data directory
+			OpenRetries:           5,
+			OpenRetryDelaySeconds: 3,
 		},
 		API: APIConfig{
 			PreferredProvider: "magnetic",
 			RetryAttempts:     3,
 			TimeoutSeconds:    30,
+			MaxDailyAttempts:  5,
+			CacheTTLMinutes:   60,
+			QueueRetryMinutes: 5,
+			CatchupConfirmThreshold: 5,
+			MinAttemptIntervalSeconds: 60,
+			LogNonTrackDays:   true,
+			LogBasis:          "daily",
+			StartupCheckTimeoutSeconds: 15,
+			LogOnQuitMinMinutes: 30,
+			DescriptionTemplate: "Timeclip auto-log for {date}",
 			Magnetic: MagneticConfig{
 				Enabled: true,
 				BaseURL: "https://app.magnetichq.com/v2/rest/coreAPI",
+				ErrorFields: []string{"error", "errors"},
+				RefreshOnUnauthorized: true,
 			},
 			Clockify: ClockifyConfig{
 				Enabled: false,
 				BaseURL: "https://api.clockify.me/api/v1",
+				ErrorFields: []string{"message", "code"},
+				RefreshOnUnauthorized: true,
 			},
 		},
 		UI: UIConfig{
 			ShowMenuBar:     true,
 			ShowSeconds:     false,
 			Use12HourFormat: true,
+			ShowNetActive:   true,
+			NotificationSound: "none",
+			GoalBasis:       GoalBasisDaily,
 		},
 	}
 }
\ No newline at end of file
@@ -0,0 +1,46 @@
+package models
+
+import "testing"
+
+func TestMinGoalMinutes(t *testing.T) {
+	tests := []struct {
+		hours float64
+		want  int
+	}{
+		{0, 0},
+		{6, 360},
+		{6.5, 390},
+	}
+
+	for _, tc := range tests {
+		general := GeneralConfig{MinGoalHours: tc.hours}
+		if got := general.MinGoalMinutes(); got != tc.want {
+			t.Errorf("MinGoalMinutes(%v) = %d, want %d", tc.hours, got, tc.want)
+		}
+	}
+}
+
+func TestAutoLogThresholdMinutes(t *testing.T) {
+	tests := []struct {
+		name        string
+		ratio       float64
+		goalMinutes int
+		want        int
+	}{
+		{"hours without a ratio", 0, 480, 360},
+		{"ratio of a full day", 0.75, 480, 360},
+		{"ratio of a short day", 0.75, 240, 180},
+		{"ratio rounds up", 0.9, 425, 383},
+		{"ratio without a goal", 0.75, 0, 360},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.API.AutoLogThresholdRatio = tc.ratio
+			if got := config.AutoLogThresholdMinutes(tc.goalMinutes); got != tc.want {
+				t.Errorf("AutoLogThresholdMinutes(%d) = %d, want %d", tc.goalMinutes, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package models
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// Goal distributions for general.weekly_goal_hours
+const (
+	DistributionEven        = "even"        // Same goal on every track day
+	DistributionFrontloaded = "frontloaded" // Longer days early in the week, shorter later
+	DistributionCustom      = "custom"      // Per-day weights from general.distribution_weights
+)
+
+// Goal bases for ui.goal_basis
+const (
+	GoalBasisDaily  = "daily"  // The menu bar shows progress towards today's goal
+	GoalBasisWeekly = "weekly" // The menu bar shows progress towards the week's goal
+)
+
+// Log bases for api.log_basis
+const (
+	LogBasisDaily  = "daily"  // Each day is logged once it reaches the auto-log threshold
+	LogBasisWeekly = "weekly" // The days of a week are logged once the week has ended
+)
+
+// frontloadedSpread is how far the first and last track days of a
+// frontloaded week are above and below the even share (0.2 = 120% / 80%)
+const frontloadedSpread = 0.2
+
+// GoalMinutesFor returns the goal for the day containing t. Without a weekly
+// goal every day gets goal_time_hours. With general.weekly_goal_hours the
+// weekly goal is split across the week's track days by general.distribution,
+// and days that aren't track days get no goal.
+func (g *GeneralConfig) GoalMinutesFor(t time.Time) int {
+	if g.WeeklyGoalHours <= 0 {
+		return g.GoalTimeHours * 60
+	}
+	if !g.IsTrackDay(t) {
+		return 0
+	}
+
+	weights := g.weekWeights(t)
+	total := 0.0
+	for _, weight := range weights {
+		total += weight
+	}
+	if total <= 0 {
+		return 0
+	}
+
+	share := weights[t.Weekday()] / total
+	return int(math.Round(g.WeeklyGoalHours * 60 * share))
+}
+
+// WeeklyGoalMinutes returns the goal for the week containing t:
+// general.weekly_goal_hours when set, otherwise the daily goals of the
+// week's track days added up
+func (g *GeneralConfig) WeeklyGoalMinutes(t time.Time) int {
+	if g.WeeklyGoalHours > 0 {
+		return int(math.Round(g.WeeklyGoalHours * 60))
+	}
+
+	start := StartOfWeek(t, g.WeekStartDay())
+	total := 0
+	for i := 0; i < 7; i++ {
+		day := start.AddDate(0, 0, i)
+		if g.IsTrackDay(day) {
+			total += g.GoalMinutesFor(day)
+		}
+	}
+	return total
+}
+
+// weekWeights returns the distribution weight of each track day in t's week
+func (g *GeneralConfig) weekWeights(t time.Time) map[time.Weekday]float64 {
+	start := StartOfWeek(t, g.WeekStartDay())
+
+	var trackDays []time.Weekday
+	for i := 0; i < 7; i++ {
+		day := start.AddDate(0, 0, i)
+		if g.IsTrackDay(day) {
+			trackDays = append(trackDays, day.Weekday())
+		}
+	}
+
+	weights := make(map[time.Weekday]float64, len(trackDays))
+	for i, day := range trackDays {
+		switch strings.ToLower(g.Distribution) {
+		case DistributionFrontloaded:
+			weights[day] = 1
+			if len(trackDays) > 1 {
+				position := float64(i) / float64(len(trackDays)-1)
+				weights[day] = 1 + frontloadedSpread - 2*frontloadedSpread*position
+			}
+		case DistributionCustom:
+			weights[day] = g.distributionWeight(day)
+		default:
+			weights[day] = 1
+		}
+	}
+	return weights
+}
+
+// distributionWeight looks up a day in general.distribution_weights,
+// ignoring the case of the day name
+func (g *GeneralConfig) distributionWeight(day time.Weekday) float64 {
+	name := strings.ToLower(day.String())
+	for key, weight := range g.DistributionWeights {
+		if strings.ToLower(key) == name {
+			return weight
+		}
+	}
+	return 0
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGoalMinutesFor(t *testing.T) {
+	// The week of Monday 2024-05-06
+	day := func(offset int) time.Time {
+		return time.Date(2024, 5, 6+offset, 12, 0, 0, 0, time.Local)
+	}
+	weekdays := []string{"monday", "tuesday", "wednesday", "thursday", "friday"}
+
+	tests := []struct {
+		name         string
+		general      GeneralConfig
+		want         []int // Monday through Sunday
+		wantWeekGoal int
+	}{
+		{
+			name:         "daily goal",
+			general:      GeneralConfig{GoalTimeHours: 8, TrackDays: weekdays},
+			want:         []int{480, 480, 480, 480, 480, 480, 480},
+			wantWeekGoal: 2400,
+		},
+		{
+			name:         "weekly goal, even",
+			general:      GeneralConfig{WeeklyGoalHours: 40, TrackDays: weekdays},
+			want:         []int{480, 480, 480, 480, 480, 0, 0},
+			wantWeekGoal: 2400,
+		},
+		{
+			name:         "weekly goal, frontloaded",
+			general:      GeneralConfig{WeeklyGoalHours: 40, TrackDays: weekdays, Distribution: DistributionFrontloaded},
+			want:         []int{576, 528, 480, 432, 384, 0, 0},
+			wantWeekGoal: 2400,
+		},
+		{
+			name: "weekly goal, custom",
+			general: GeneralConfig{WeeklyGoalHours: 30, TrackDays: []string{"monday", "tuesday"}, Distribution: DistributionCustom,
+				DistributionWeights: map[string]float64{"Monday": 2, "tuesday": 1}},
+			want:         []int{1200, 600, 0, 0, 0, 0, 0},
+			wantWeekGoal: 1800,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for offset, want := range tc.want {
+				if got := tc.general.GoalMinutesFor(day(offset)); got != want {
+					t.Errorf("GoalMinutesFor(%s) = %d, want %d", day(offset).Weekday(), got, want)
+				}
+			}
+			if got := tc.general.WeeklyGoalMinutes(day(2)); got != tc.wantWeekGoal {
+				t.Errorf("WeeklyGoalMinutes = %d, want %d", got, tc.wantWeekGoal)
+			}
+		})
+	}
+}
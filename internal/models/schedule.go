@@ -0,0 +1,83 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeWindow represents a daily time range in 24-hour "HH:MM" format.
+// Windows are half-open: the start minute is inside, the end minute is not.
+type TimeWindow struct {
+	Start string `toml:"start"`
+	End   string `toml:"end"`
+}
+
+// ParseTimeOfDay parses an "HH:MM" string into an offset from midnight
+func ParseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q: expected HH:MM", value)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Bounds returns the start and end offsets from midnight
+func (w TimeWindow) Bounds() (time.Duration, time.Duration, error) {
+	start, err := ParseTimeOfDay(w.Start)
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := ParseTimeOfDay(w.End)
+	if err != nil {
+		return 0, 0, err
+	}
+	if end <= start {
+		return 0, 0, fmt.Errorf("window %s-%s must end after it starts", w.Start, w.End)
+	}
+	return start, end, nil
+}
+
+// Contains returns true if t falls inside the window on its own day
+func (w TimeWindow) Contains(t time.Time) bool {
+	start, end, err := w.Bounds()
+	if err != nil {
+		return false
+	}
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	return offset >= start && offset < end
+}
+
+// WeekStartDay returns the configured first day of the week. Anything but
+// "sunday" means Monday.
+func (g *GeneralConfig) WeekStartDay() time.Weekday {
+	if strings.ToLower(g.WeekStart) == "sunday" {
+		return time.Sunday
+	}
+	return time.Monday
+}
+
+// StartOfWeek returns midnight, in t's location, on the first day of the week containing t
+func StartOfWeek(t time.Time, weekStart time.Weekday) time.Time {
+	offset := (int(t.Weekday()) - int(weekStart) + 7) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
+
+// IsTrackDay returns true if t falls on one of the configured track days
+func (g *GeneralConfig) IsTrackDay(t time.Time) bool {
+	weekday := strings.ToLower(t.Weekday().String())
+	for _, day := range g.TrackDays {
+		if strings.ToLower(day) == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkWindow returns the configured work window and whether one is set
+func (g *GeneralConfig) WorkWindow() (TimeWindow, bool) {
+	if g.WorkStart == "" && g.WorkEnd == "" {
+		return TimeWindow{}, false
+	}
+	return TimeWindow{Start: g.WorkStart, End: g.WorkEnd}, true
+}
@@ -0,0 +1,140 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeWindowBounds(t *testing.T) {
+	tests := []struct {
+		window    TimeWindow
+		wantStart time.Duration
+		wantEnd   time.Duration
+		wantErr   bool
+	}{
+		{TimeWindow{"09:00", "17:30"}, 9 * time.Hour, 17*time.Hour + 30*time.Minute, false},
+		{TimeWindow{"00:00", "23:59"}, 0, 23*time.Hour + 59*time.Minute, false},
+		{TimeWindow{"12:00", "12:00"}, 0, 0, true},
+		{TimeWindow{"17:00", "09:00"}, 0, 0, true},
+		{TimeWindow{"9am", "17:00"}, 0, 0, true},
+		{TimeWindow{"09:00", "24:00"}, 0, 0, true},
+	}
+
+	for _, tc := range tests {
+		start, end, err := tc.window.Bounds()
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s-%s: err = %v, want error %v", tc.window.Start, tc.window.End, err, tc.wantErr)
+			continue
+		}
+		if start != tc.wantStart || end != tc.wantEnd {
+			t.Errorf("%s-%s = %v-%v, want %v-%v", tc.window.Start, tc.window.End, start, end, tc.wantStart, tc.wantEnd)
+		}
+	}
+}
+
+func TestTimeWindowContains(t *testing.T) {
+	lunch := TimeWindow{Start: "12:00", End: "13:00"}
+	day := func(hour, minute int) time.Time {
+		return time.Date(2024, 5, 6, hour, minute, 30, 0, time.Local)
+	}
+
+	tests := []struct {
+		at   time.Time
+		want bool
+	}{
+		{day(11, 59), false},
+		{day(12, 0), true},
+		{day(12, 59), true},
+		{day(13, 0), false},
+	}
+
+	for _, tc := range tests {
+		if got := lunch.Contains(tc.at); got != tc.want {
+			t.Errorf("Contains(%s) = %v, want %v", tc.at.Format("15:04"), got, tc.want)
+		}
+	}
+
+	if (TimeWindow{Start: "13:00", End: "12:00"}).Contains(day(12, 30)) {
+		t.Error("an invalid window contains nothing")
+	}
+}
+
+func TestWorkWindow(t *testing.T) {
+	general := GeneralConfig{}
+	if _, ok := general.WorkWindow(); ok {
+		t.Error("WorkWindow set without work_start or work_end")
+	}
+
+	general.WorkStart, general.WorkEnd = "08:30", "16:30"
+	window, ok := general.WorkWindow()
+	if !ok || window != (TimeWindow{Start: "08:30", End: "16:30"}) {
+		t.Errorf("WorkWindow = %+v, %v; want 08:30-16:30", window, ok)
+	}
+}
+
+func TestStartOfWeek(t *testing.T) {
+	at := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 30, 0, 0, time.Local)
+	}
+	midnight := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name      string
+		t         time.Time
+		weekStart time.Weekday
+		want      time.Time
+	}{
+		{"monday week, on a monday", at(2024, 5, 6, 9), time.Monday, midnight(2024, 5, 6)},
+		{"monday week, on a sunday", at(2024, 5, 12, 23), time.Monday, midnight(2024, 5, 6)},
+		{"sunday week, on a sunday", at(2024, 5, 12, 0), time.Sunday, midnight(2024, 5, 12)},
+		{"sunday week, on a saturday", at(2024, 5, 11, 18), time.Sunday, midnight(2024, 5, 5)},
+		{"across new year", at(2025, 1, 2, 12), time.Monday, midnight(2024, 12, 30)},
+		{"sunday week across new year", at(2025, 1, 4, 12), time.Sunday, midnight(2024, 12, 29)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := StartOfWeek(tc.t, tc.weekStart); !got.Equal(tc.want) {
+				t.Errorf("StartOfWeek = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStartOfWeekAcrossDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+
+	// Clocks went forward on Sunday 2024-03-31
+	got := StartOfWeek(time.Date(2024, 3, 31, 12, 0, 0, 0, berlin), time.Monday)
+	if want := time.Date(2024, 3, 25, 0, 0, 0, 0, berlin); !got.Equal(want) {
+		t.Errorf("StartOfWeek = %v, want local midnight %v", got, want)
+	}
+	got = StartOfWeek(time.Date(2024, 4, 1, 0, 30, 0, 0, berlin), time.Monday)
+	if want := time.Date(2024, 4, 1, 0, 0, 0, 0, berlin); !got.Equal(want) {
+		t.Errorf("StartOfWeek = %v, want local midnight %v", got, want)
+	}
+}
+
+func TestWeekStartDay(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Weekday
+	}{
+		{"", time.Monday},
+		{"monday", time.Monday},
+		{"sunday", time.Sunday},
+		{"Sunday", time.Sunday},
+	}
+
+	for _, tc := range tests {
+		general := GeneralConfig{WeekStart: tc.value}
+		if got := general.WeekStartDay(); got != tc.want {
+			t.Errorf("WeekStartDay(%q) = %v, want %v", tc.value, got, tc.want)
+		}
+	}
+}
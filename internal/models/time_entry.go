@@ -11,10 +11,43 @@ type DailyTimeEntry struct {
 	IsPaused        bool      `db:"is_paused"`       // Current pause state
 	AutoLogged      bool      `db:"auto_logged"`     // Whether auto-log completed
 	AutoLogResponse string    `db:"auto_log_response"` // API response for debugging
+	AutoLogFailures int       `db:"auto_log_failures"` // Failed auto-log attempts, reset on success
+	IsPTO           bool      `db:"pto"`             // Day off: nothing is tracked or logged
+	Notes           string    `db:"notes"`           // Private free-form notes, not logged unless api.include_notes
+	Abandoned       bool      `db:"abandoned"`       // Older than api.max_backlog_days and given up on; never logged
 	CreatedAt       time.Time `db:"created_at"`
 	UpdatedAt       time.Time `db:"updated_at"`
 }
 
+// PendingLog is a failed submission waiting in the offline queue
+type PendingLog struct {
+	ID            int       `db:"id"`
+	Date          string    `db:"date"`            // YYYY-MM-DD format
+	Attempts      int       `db:"attempts"`        // Failed submissions so far
+	LastError     string    `db:"last_error"`      // Why the last attempt failed
+	LastAttemptAt time.Time `db:"last_attempt_at"`
+	NextAttemptAt time.Time `db:"next_attempt_at"` // Not retried before this
+	Approved      bool      `db:"approved"`        // Cleared to submit in a held catch-up
+	CreatedAt     time.Time `db:"created_at"`
+}
+
+// AwaitingApproval returns how many queued days must be approved before the
+// queue is submitted: none while the queue holds threshold days or fewer (or
+// threshold is 0), otherwise every day not yet approved
+func AwaitingApproval(pending []*PendingLog, threshold int) int {
+	if threshold <= 0 || len(pending) <= threshold {
+		return 0
+	}
+
+	unapproved := 0
+	for _, item := range pending {
+		if !item.Approved {
+			unapproved++
+		}
+	}
+	return unapproved
+}
+
 // SystemEvent represents a system state change event
 type SystemEvent struct {
 	ID        int       `db:"id"`
@@ -35,19 +68,40 @@ func (d *DailyTimeEntry) GoalTime() time.Duration {
 
 // Progress returns the completion percentage (0.0 to 1.0)
 func (d *DailyTimeEntry) Progress() float64 {
-	if d.GoalMinutes == 0 {
-		return 0.0
-	}
-	progress := float64(d.ActiveMinutes) / float64(d.GoalMinutes)
+	progress := d.UnclampedProgress()
 	if progress > 1.0 {
 		return 1.0
 	}
 	return progress
 }
 
+// UnclampedProgress returns the completion percentage without capping it at
+// 1.0, so overtime shows as e.g. 1.12
+func (d *DailyTimeEntry) UnclampedProgress() float64 {
+	if !d.HasGoal() {
+		return 0.0
+	}
+	return float64(d.ActiveMinutes) / float64(d.GoalMinutes)
+}
+
+// HasGoal returns false for days whose goal is 0 (e.g. a tracked day outside
+// the weekly goal). Such days have no progress and never reach their goal.
+func (d *DailyTimeEntry) HasGoal() bool {
+	return d.GoalMinutes > 0
+}
+
+// OvertimeMinutes returns the active minutes beyond the goal (0 when under goal
+// or without a goal)
+func (d *DailyTimeEntry) OvertimeMinutes() int {
+	if !d.HasGoal() || d.ActiveMinutes <= d.GoalMinutes {
+		return 0
+	}
+	return d.ActiveMinutes - d.GoalMinutes
+}
+
 // IsGoalReached returns true if the daily goal has been achieved
 func (d *DailyTimeEntry) IsGoalReached() bool {
-	return d.ActiveMinutes >= d.GoalMinutes
+	return d.HasGoal() && d.ActiveMinutes >= d.GoalMinutes
 }
 
 // ShouldAutoLog returns true if the entry should trigger auto-logging
@@ -0,0 +1,46 @@
+package models
+
+import "testing"
+
+func TestProgress(t *testing.T) {
+	tests := []struct {
+		active, goal        int
+		progress, unclamped float64
+	}{
+		{240, 480, 0.5, 0.5},
+		{480, 480, 1, 1},
+		{540, 480, 1, 1.125},
+	}
+
+	for _, tc := range tests {
+		entry := DailyTimeEntry{ActiveMinutes: tc.active, GoalMinutes: tc.goal}
+		if got := entry.Progress(); got != tc.progress {
+			t.Errorf("%d/%d: Progress = %v, want %v", tc.active, tc.goal, got, tc.progress)
+		}
+		if got := entry.UnclampedProgress(); got != tc.unclamped {
+			t.Errorf("%d/%d: UnclampedProgress = %v, want %v", tc.active, tc.goal, got, tc.unclamped)
+		}
+	}
+}
+
+func TestNoGoal(t *testing.T) {
+	entry := DailyTimeEntry{ActiveMinutes: 90, GoalMinutes: 0}
+
+	if entry.HasGoal() {
+		t.Error("HasGoal = true for a goal of 0")
+	}
+	if entry.IsGoalReached() {
+		t.Error("IsGoalReached = true without a goal")
+	}
+	if got := entry.OvertimeMinutes(); got != 0 {
+		t.Errorf("OvertimeMinutes = %d, want 0 without a goal", got)
+	}
+	if entry.Progress() != 0 || entry.UnclampedProgress() != 0 {
+		t.Errorf("progress = %v/%v, want 0 without a goal", entry.Progress(), entry.UnclampedProgress())
+	}
+
+	entry.GoalMinutes = 60
+	if !entry.IsGoalReached() || entry.OvertimeMinutes() != 30 {
+		t.Errorf("with a 60 minute goal: reached %v, overtime %d; want reached with 30 overtime", entry.IsGoalReached(), entry.OvertimeMinutes())
+	}
+}
@@ -0,0 +1,116 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// NoSound turns notification sounds off
+const NoSound = "none"
+
+// Sounds returns the macOS system alert sounds (/System/Library/Sounds)
+// that notifications can play
+func Sounds() []string {
+	return []string{
+		"Basso", "Blow", "Bottle", "Frog", "Funk", "Glass", "Hero",
+		"Morse", "Ping", "Pop", "Purr", "Sosumi", "Submarine", "Tink",
+	}
+}
+
+var (
+	soundMu sync.Mutex
+	sound   string
+)
+
+// SetSound sets the system sound played with every notification; "none" or
+// an empty name keeps them silent. The sound is part of the notification, so
+// Focus and Do Not Disturb silence it along with the banner.
+func SetSound(name string) {
+	soundMu.Lock()
+	defer soundMu.Unlock()
+
+	if name == NoSound {
+		name = ""
+	}
+	sound = name
+}
+
+// Send shows a macOS user notification using osascript
+func Send(title, message string) error {
+	script := fmt.Sprintf("display notification %s with title %s", quote(message), quote(title))
+
+	soundMu.Lock()
+	if sound != "" {
+		script += " sound name " + quote(sound)
+	}
+	soundMu.Unlock()
+
+	if output, err := exec.Command("osascript", "-e", script).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to send notification: %w (%s)", err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// quote returns s as an AppleScript string literal
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// Ask shows a macOS dialog with the given buttons and returns the label of
+// the one clicked. The last button is the default. A button labeled
+// "Cancel" is the dialog's cancel button: clicking it, or dismissing the
+// dialog with Escape, returns ErrCanceled.
+func Ask(title, message string, buttons ...string) (string, error) {
+	if len(buttons) == 0 {
+		return "", fmt.Errorf("at least one button is required")
+	}
+
+	quoted := make([]string, len(buttons))
+	for i, button := range buttons {
+		quoted[i] = quote(button)
+	}
+	script := fmt.Sprintf("button returned of (display dialog %s with title %s buttons {%s} default button %s)",
+		quote(message), quote(title), strings.Join(quoted, ", "), quoted[len(quoted)-1])
+
+	output, err := exec.Command("osascript", "-e", script).CombinedOutput()
+	if err != nil {
+		if canceled(output) {
+			return "", ErrCanceled
+		}
+		return "", fmt.Errorf("failed to show dialog: %w (%s)", err, strings.TrimSpace(string(output)))
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// ErrCanceled is returned by Ask and Prompt when the user clicks Cancel
+var ErrCanceled = errors.New("canceled")
+
+// canceled reports whether osascript failed because the user canceled the
+// dialog; -128 is AppleScript's "User canceled"
+func canceled(output []byte) bool {
+	return strings.Contains(string(output), "-128")
+}
+
+// Prompt shows a macOS dialog with a text field prefilled with defaultAnswer
+// and returns what was entered
+func Prompt(title, message, defaultAnswer string) (string, error) {
+	script := fmt.Sprintf("text returned of (display dialog %s with title %s default answer %s)",
+		quote(message), quote(title), quote(defaultAnswer))
+
+	output, err := exec.Command("osascript", "-e", script).CombinedOutput()
+	if err != nil {
+		if canceled(output) {
+			return "", ErrCanceled
+		}
+		return "", fmt.Errorf("failed to show dialog: %w (%s)", err, strings.TrimSpace(string(output)))
+	}
+
+	return strings.TrimRight(string(output), "\n"), nil
+}
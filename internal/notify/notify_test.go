@@ -0,0 +1,20 @@
+package notify
+
+import "testing"
+
+func TestCanceled(t *testing.T) {
+	tests := []struct {
+		output string
+		want   bool
+	}{
+		{"36:120: execution error: User canceled. (-128)\n", true},
+		{"36:120: execution error: Expected end of line. (-2741)\n", false},
+		{"", false},
+	}
+
+	for _, tc := range tests {
+		if got := canceled([]byte(tc.output)); got != tc.want {
+			t.Errorf("canceled(%q) = %v, want %v", tc.output, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package snapshot
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"timeclip/internal/database"
+	"timeclip/internal/models"
+	"timeclip/internal/version"
+)
+
+// redactedValue replaces secrets in a debug bundle
+const redactedValue = "[REDACTED]"
+
+// DebugBundle writes a zip for bug reports with the version, the effective
+// config, and the daily totals, system events and offline queue between start
+// and end (YYYY-MM-DD). API keys are replaced wherever they appear, including
+// in stored API responses and errors.
+func DebugBundle(config *models.Config, bundlePath, start, end string) error {
+	db, err := database.OpenReadOnly(config.DatabasePath())
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	files := []struct {
+		name  string
+		build func() ([]byte, error)
+	}{
+		{"version.txt", func() ([]byte, error) { return bundleVersion(db), nil }},
+		{"config.toml", func() ([]byte, error) { return bundleConfig(config) }},
+		{"daily_time.csv", func() ([]byte, error) { return bundleDailyTime(db, start, end) }},
+		{"system_events.csv", func() ([]byte, error) { return bundleSystemEvents(db, start, end) }},
+		{"pending_logs.csv", func() ([]byte, error) { return bundlePendingLogs(db) }},
+	}
+
+	file, err := os.OpenFile(bundlePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle %s: %w", bundlePath, err)
+	}
+	defer file.Close()
+
+	redact := secretRedactor(config)
+	zipWriter := zip.NewWriter(file)
+	for _, entry := range files {
+		data, err := entry.build()
+		if err != nil {
+			return fmt.Errorf("failed to collect %s: %w", entry.name, err)
+		}
+
+		writer, err := zipWriter.CreateHeader(&zip.FileHeader{Name: entry.name, Method: zip.Deflate, Modified: time.Now()})
+		if err != nil {
+			return fmt.Errorf("failed to write %s to bundle: %w", entry.name, err)
+		}
+		if _, err := writer.Write([]byte(redact.Replace(string(data)))); err != nil {
+			return fmt.Errorf("failed to write %s to bundle: %w", entry.name, err)
+		}
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return fmt.Errorf("failed to finish bundle: %w", err)
+	}
+	return file.Close()
+}
+
+// secretRedactor replaces every configured API key
+func secretRedactor(config *models.Config) *strings.Replacer {
+	var pairs []string
+	for _, key := range []string{config.API.Magnetic.APIKey, config.API.Clockify.APIKey} {
+		if key != "" {
+			pairs = append(pairs, key, redactedValue)
+		}
+	}
+	return strings.NewReplacer(pairs...)
+}
+
+// bundleVersion describes the build and database schema
+func bundleVersion(db *database.DB) []byte {
+	schema := "unknown"
+	if schemaVersion, err := db.SchemaVersion(); err == nil {
+		schema = strconv.Itoa(schemaVersion)
+	}
+	return []byte(fmt.Sprintf("%s\nDatabase schema: %s\n", version.Get(), schema))
+}
+
+// bundleConfig returns the effective config with API keys redacted
+func bundleConfig(config *models.Config) ([]byte, error) {
+	redacted := *config
+	if redacted.API.Magnetic.APIKey != "" {
+		redacted.API.Magnetic.APIKey = redactedValue
+	}
+	if redacted.API.Clockify.APIKey != "" {
+		redacted.API.Clockify.APIKey = redactedValue
+	}
+	return toml.Marshal(&redacted)
+}
+
+// bundleDailyTime returns the daily totals in the range as CSV
+func bundleDailyTime(db *database.DB, start, end string) ([]byte, error) {
+	entries, err := db.GetEntriesForDateRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := [][]string{{"date", "active_minutes", "goal_minutes", "is_paused", "auto_logged", "auto_log_failures", "auto_log_response"}}
+	for _, entry := range entries {
+		rows = append(rows, []string{
+			entry.Date,
+			strconv.Itoa(entry.ActiveMinutes),
+			strconv.Itoa(entry.GoalMinutes),
+			strconv.FormatBool(entry.IsPaused),
+			strconv.FormatBool(entry.AutoLogged),
+			strconv.Itoa(entry.AutoLogFailures),
+			entry.AutoLogResponse,
+		})
+	}
+	return encodeCSV(rows)
+}
+
+// bundleSystemEvents returns the system events in the range as CSV
+func bundleSystemEvents(db *database.DB, start, end string) ([]byte, error) {
+	events, err := db.GetSystemEventsForDateRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := [][]string{{"id", "timestamp", "event_type", "details"}}
+	for _, event := range events {
+		rows = append(rows, []string{
+			strconv.Itoa(event.ID),
+			event.Timestamp.Format(time.RFC3339),
+			event.EventType,
+			event.Details,
+		})
+	}
+	return encodeCSV(rows)
+}
+
+// bundlePendingLogs returns the offline queue as CSV
+func bundlePendingLogs(db *database.DB) ([]byte, error) {
+	pending, err := db.GetPendingLogs()
+	if err != nil {
+		return nil, err
+	}
+
+	rows := [][]string{{"date", "attempts", "next_attempt_at", "last_error"}}
+	for _, item := range pending {
+		rows = append(rows, []string{
+			item.Date,
+			strconv.Itoa(item.Attempts),
+			item.NextAttemptAt.Format(time.RFC3339),
+			item.LastError,
+		})
+	}
+	return encodeCSV(rows)
+}
+
+// encodeCSV writes rows as CSV
+func encodeCSV(rows [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
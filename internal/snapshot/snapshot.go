@@ -0,0 +1,258 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"timeclip/internal/database"
+	"timeclip/internal/models"
+)
+
+// Archive entry names
+const (
+	configEntry   = "config.toml"
+	databaseEntry = "timeclip.db"
+)
+
+// importBackupSuffix is appended to files replaced by Import
+const importBackupSuffix = ".before-import"
+
+// Export writes a tar.gz archive containing the configuration and a consistent
+// database snapshot. API keys are blanked unless includeKeys is set.
+func Export(config *models.Config, configPath, archivePath string, includeKeys bool) error {
+	tmpDir, err := os.MkdirTemp("", "timeclip-export-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// Snapshot the database without copying the live file
+	db, err := database.OpenReadOnly(config.DatabasePath())
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	snapshotPath := filepath.Join(tmpDir, databaseEntry)
+	err = db.SnapshotTo(snapshotPath)
+	db.Close()
+	if err != nil {
+		return err
+	}
+
+	configData, err := exportConfig(config, configPath, includeKeys)
+	if err != nil {
+		return err
+	}
+
+	dbData, err := os.ReadFile(snapshotPath)
+	if err != nil {
+		return fmt.Errorf("failed to read database snapshot: %w", err)
+	}
+
+	file, err := os.OpenFile(archivePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create archive %s: %w", archivePath, err)
+	}
+	defer file.Close()
+
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	now := time.Now()
+	for _, entry := range []struct {
+		name string
+		data []byte
+	}{
+		{configEntry, configData},
+		{databaseEntry, dbData},
+	} {
+		header := &tar.Header{
+			Name:    entry.name,
+			Mode:    0600,
+			Size:    int64(len(entry.data)),
+			ModTime: now,
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write %s to archive: %w", entry.name, err)
+		}
+		if _, err := tarWriter.Write(entry.data); err != nil {
+			return fmt.Errorf("failed to write %s to archive: %w", entry.name, err)
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+
+	return file.Close()
+}
+
+// exportConfig returns the config file contents, with API keys removed unless includeKeys is set
+func exportConfig(config *models.Config, configPath string, includeKeys bool) ([]byte, error) {
+	if includeKeys {
+		data, err := os.ReadFile(configPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file %s: %w", configPath, err)
+		}
+		return data, nil
+	}
+
+	redacted := *config
+	redacted.API.Magnetic.APIKey = ""
+	redacted.API.Clockify.APIKey = ""
+
+	data, err := toml.Marshal(&redacted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	return data, nil
+}
+
+// Import restores the configuration and database from an archive created by Export.
+// The database is integrity-checked before and after it is put in place. Existing
+// files are kept alongside with a ".before-import" suffix.
+//
+// Timeclip must not be running while importing.
+func Import(archivePath, configPath, dbPath string) error {
+	dbPath, err := database.ExpandPath(dbPath)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0755); err != nil {
+		return fmt.Errorf("failed to create database directory: %w", err)
+	}
+
+	// Extract next to the database so the final rename stays on one volume
+	tmpDir, err := os.MkdirTemp(filepath.Dir(dbPath), ".import-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	extracted, err := extract(archivePath, tmpDir)
+	if err != nil {
+		return err
+	}
+	if !extracted[databaseEntry] {
+		return fmt.Errorf("archive %s does not contain a database", archivePath)
+	}
+
+	extractedDB := filepath.Join(tmpDir, databaseEntry)
+	if err := checkIntegrity(extractedDB); err != nil {
+		return fmt.Errorf("archived database is not usable: %w", err)
+	}
+
+	// Move the current database (and any WAL files) out of the way
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		if err := backupExisting(dbPath + suffix); err != nil {
+			return err
+		}
+	}
+	if err := moveFile(extractedDB, dbPath); err != nil {
+		return err
+	}
+
+	if err := checkIntegrity(dbPath); err != nil {
+		return fmt.Errorf("imported database failed verification: %w", err)
+	}
+
+	if extracted[configEntry] {
+		if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+			return fmt.Errorf("failed to create config directory: %w", err)
+		}
+		if err := backupExisting(configPath); err != nil {
+			return err
+		}
+		if err := moveFile(filepath.Join(tmpDir, configEntry), configPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// extract unpacks the known archive entries into dir and reports which were found
+func extract(archivePath, dir string) (map[string]bool, error) {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive %s: %w", archivePath, err)
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive %s: %w", archivePath, err)
+	}
+	defer gzipReader.Close()
+
+	found := make(map[string]bool)
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive %s: %w", archivePath, err)
+		}
+
+		// Only the fixed entry names are restored, never arbitrary paths
+		if header.Name != configEntry && header.Name != databaseEntry {
+			continue
+		}
+
+		target, err := os.OpenFile(filepath.Join(dir, header.Name), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract %s: %w", header.Name, err)
+		}
+		_, err = io.Copy(target, tarReader)
+		target.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract %s: %w", header.Name, err)
+		}
+
+		found[header.Name] = true
+	}
+
+	return found, nil
+}
+
+// checkIntegrity opens a database read-only and runs SQLite's integrity check
+func checkIntegrity(path string) error {
+	db, err := database.OpenReadOnly(path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return db.CheckIntegrity()
+}
+
+// backupExisting renames path to path+".before-import" if it exists
+func backupExisting(path string) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+
+	if err := os.Rename(path, path+importBackupSuffix); err != nil {
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	return nil
+}
+
+// moveFile renames src to dst
+func moveFile(src, dst string) error {
+	if err := os.Rename(src, dst); err != nil {
+		return fmt.Errorf("failed to move %s into place: %w", filepath.Base(dst), err)
+	}
+	return nil
+}
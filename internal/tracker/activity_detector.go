@@ -21,6 +21,19 @@ type ActivityDetector struct {
 	lastActiveTime     time.Time
 	currentEntry       *models.DailyTimeEntry
 	stateChangeCallbacks []ActivityStateChangeCallback
+	callbackQueues       []*callbackQueue // One per callback, same order
+	trackingDisabled   bool // Global kill switch; no days are created or credited
+	paceAlertedDate    string // Date the low-pace alert last fired
+	pausedAt           time.Time // When the current pause started, if known
+	lastTickAt         time.Time // Wall-clock time of the previous minute tick
+	tickWake           time.Time // Last wake detected from a gap between minute ticks
+	inputActive        time.Duration // Input activity not yet credited (ActivityModeInput)
+	awaitingInput      bool          // Idle, and only sustained input ends it (ResumeRequiresSustainedInput)
+
+	// State-change event throttling (see ActivityConfig.EventMinInterval)
+	eventMu           sync.Mutex
+	pendingEventTimer *time.Timer
+	lastLoggedEvent   string
 }
 
 // ActivityConfig contains configuration for activity detection
@@ -28,6 +41,28 @@ type ActivityConfig struct {
 	CheckInterval         time.Duration `json:"check_interval"`
 	GoalMinutes          int           `json:"goal_minutes"`
 	AutoLogThresholdMinutes int        `json:"auto_log_threshold_minutes"`
+	WorkWindow            *models.TimeWindow  `json:"work_window,omitempty"`
+	Breaks                []models.TimeWindow `json:"breaks,omitempty"`
+	IdleThreshold         time.Duration       `json:"idle_threshold"` // 0 disables idle detection
+	EventMinInterval      time.Duration       `json:"event_min_interval"` // 0 logs every state change
+	DetectMeetings        bool                `json:"detect_meetings"`
+	LockedCountsInactive  bool                `json:"locked_counts_inactive"`
+	CreditOnStartup       bool                `json:"credit_on_startup"`
+	RequireRecentInput    bool                `json:"require_recent_input"`
+	IdleIsPrimary         bool                `json:"idle_is_primary"`
+	ResumeRequiresSustainedInput bool         `json:"resume_requires_sustained_input"`
+	ClamshellCountsInactive bool              `json:"clamshell_counts_inactive"`
+	DisplaySleepInactive  bool                `json:"display_sleep_inactive"`
+	ScreensaverCountsActive bool              `json:"screensaver_counts_active"`
+	PaceAlertRatio        float64             `json:"pace_alert_ratio"` // 0 disables the low-pace alert
+	LongPause             time.Duration       `json:"long_pause"` // 0 disables the long pause report
+	Heartbeat             time.Duration       `json:"heartbeat"` // 0 disables the periodic heartbeat log line
+	PostWakeWarmup        time.Duration       `json:"post_wake_warmup"` // 0 credits right after wake
+	OnUnknownState        UnknownStatePolicy  `json:"on_unknown_state"`
+	NetworkProbeHost      string              `json:"network_probe_host,omitempty"` // Pause while unreachable; empty disables
+	ActivityMode          ActivityMode        `json:"activity_mode"`
+	Hooks                 StateHooks          `json:"hooks"`
+	IsTrackDay            func(time.Time) bool `json:"-"`
 }
 
 // ActivityStateChangeCallback is called when tracking state changes
@@ -35,9 +70,22 @@ type ActivityStateChangeCallback func(isActive bool, entry *models.DailyTimeEntr
 
 // NewActivityDetector creates a new activity detector
 func NewActivityDetector(db *database.DB, config *ActivityConfig) *ActivityDetector {
+	monitor := NewMonitor()
+	monitor.SetDetectMeetings(config.DetectMeetings)
+	monitor.SetLockedCountsInactive(config.LockedCountsInactive)
+	monitor.SetUnknownStatePolicy(config.OnUnknownState)
+	monitor.SetActiveCriteria(ActiveCriteria{
+		RequireRecentInput: config.RequireRecentInput,
+		IdleIsPrimary:      config.IdleIsPrimary,
+		InputWindow:        config.IdleThreshold,
+		ClamshellInactive:  config.ClamshellCountsInactive,
+		DisplaySleepInactive: config.DisplaySleepInactive,
+		ScreensaverActive:  config.ScreensaverCountsActive,
+	})
+
 	return &ActivityDetector{
 		db:         db,
-		monitor:    NewMonitor(),
+		monitor:    monitor,
 		config:     config,
 		stopChan:   make(chan bool),
 	}
@@ -60,21 +108,50 @@ func (ad *ActivityDetector) Start() error {
 	// Register state change callback
 	ad.monitor.AddStateChangeCallback(ad.onSystemStateChange)
 
-	// Get or create today's entry
-	entry, err := ad.db.GetTodayEntry()
-	if err != nil {
-		return fmt.Errorf("failed to get today's entry: %w", err)
+	// Get or create today's entry, unless tracking is globally disabled
+	ad.trackingDisabled = ad.db.IsTrackingDisabled()
+	if !ad.trackingDisabled {
+		entry, err := ad.db.GetTodayEntry()
+		if err != nil {
+			return fmt.Errorf("failed to get today's entry: %w", err)
+		}
+		ad.currentEntry = entry
+
+		// Pick up a pause that was started before a restart
+		if entry.IsPaused && ad.config.LongPause > 0 {
+			if pausedAt, err := ad.db.LastEventTime("pause"); err != nil {
+				log.Printf("Error getting pause start: %v", err)
+			} else {
+				ad.pausedAt = pausedAt
+			}
+		}
 	}
-	ad.currentEntry = entry
 
 	ad.isTracking = true
 	ad.lastActiveTime = time.Now()
 
-	log.Printf("Activity detector started - Today: %d minutes (%.1f hours)", 
-		entry.ActiveMinutes, float64(entry.ActiveMinutes)/60.0)
+	if ad.trackingDisabled {
+		log.Println("Activity detector started - tracking is disabled")
+	} else {
+		log.Printf("Activity detector started - Today: %d minutes (%.1f hours)", 
+			ad.currentEntry.ActiveMinutes, float64(ad.currentEntry.ActiveMinutes)/60.0)
+
+		if ad.config.CreditOnStartup {
+			ad.creditStartupMinute()
+		}
+	}
 
 	// Start tracking loop
 	go ad.trackingLoop()
+	if ad.config.NetworkProbeHost != "" {
+		go ad.networkLoop()
+	}
+	if ad.config.ActivityMode == ActivityModeInput {
+		go ad.inputLoop()
+	}
+	if ad.config.Heartbeat > 0 {
+		go ad.heartbeatLoop(time.Now())
+	}
 
 	return nil
 }
@@ -92,6 +169,16 @@ func (ad *ActivityDetector) Stop() {
 	ad.monitor.Stop()
 	close(ad.stopChan)
 
+	ad.eventMu.Lock()
+	if ad.pendingEventTimer != nil {
+		ad.pendingEventTimer.Stop()
+	}
+	ad.eventMu.Unlock()
+
+	if err := ad.db.Flush(); err != nil {
+		log.Printf("Error writing pending active time: %v", err)
+	}
+
 	log.Println("Activity detector stopped")
 }
 
@@ -133,6 +220,7 @@ func (ad *ActivityDetector) TogglePause() error {
 
 	// Update local entry
 	ad.currentEntry.IsPaused = newPauseState
+	ad.trackPauseChange(newPauseState, time.Now())
 
 	log.Printf("Time tracking %s", map[bool]string{true: "paused", false: "resumed"}[newPauseState])
 
@@ -161,6 +249,7 @@ func (ad *ActivityDetector) SetPause(paused bool) error {
 
 	// Update local entry
 	ad.currentEntry.IsPaused = paused
+	ad.trackPauseChange(paused, time.Now())
 
 	log.Printf("Time tracking %s", map[bool]string{true: "paused", false: "resumed"}[paused])
 
@@ -170,11 +259,51 @@ func (ad *ActivityDetector) SetPause(paused bool) error {
 	return nil
 }
 
+// SetTrackingEnabled turns the global kill switch off (true) or on (false).
+// The setting is persisted so it survives restarts.
+func (ad *ActivityDetector) SetTrackingEnabled(enabled bool) error {
+	ad.mu.Lock()
+	defer ad.mu.Unlock()
+
+	if ad.trackingDisabled == !enabled {
+		return nil // No change needed
+	}
+
+	if err := ad.db.SetTrackingDisabled(!enabled); err != nil {
+		return fmt.Errorf("failed to set tracking state: %w", err)
+	}
+	ad.trackingDisabled = !enabled
+
+	if enabled {
+		entry, err := ad.db.GetTodayEntry()
+		if err != nil {
+			return fmt.Errorf("failed to get today's entry: %w", err)
+		}
+		ad.currentEntry = entry
+		log.Println("Tracking enabled")
+	} else {
+		ad.currentEntry = nil
+		log.Println("Tracking disabled")
+	}
+
+	ad.notifyStateChange(ad.monitor.IsSystemActive(), ad.currentEntry)
+
+	return nil
+}
+
+// IsTrackingEnabled returns false while the global kill switch is on
+func (ad *ActivityDetector) IsTrackingEnabled() bool {
+	ad.mu.RLock()
+	defer ad.mu.RUnlock()
+	return !ad.trackingDisabled
+}
+
 // AddStateChangeCallback adds a callback for activity state changes
 func (ad *ActivityDetector) AddStateChangeCallback(callback ActivityStateChangeCallback) {
 	ad.mu.Lock()
 	defer ad.mu.Unlock()
 	ad.stateChangeCallbacks = append(ad.stateChangeCallbacks, callback)
+	ad.callbackQueues = append(ad.callbackQueues, newCallbackQueue())
 }
 
 // GetSystemState returns the current system monitoring state
@@ -184,12 +313,23 @@ func (ad *ActivityDetector) GetSystemState() *SystemState {
 
 // GetStateDescription returns a description of the current state
 func (ad *ActivityDetector) GetStateDescription() string {
+	if !ad.IsTrackingEnabled() {
+		return "Tracking off"
+	}
+
 	entry := ad.GetCurrentEntry()
 
 	if entry != nil && entry.IsPaused {
 		return "Paused"
 	}
 
+	ad.mu.RLock()
+	waiting := ad.awaitingInput
+	ad.mu.RUnlock()
+	if waiting {
+		return "Inactive (waiting for sustained input)"
+	}
+
 	return ad.monitor.GetStateDescription()
 }
 
@@ -210,12 +350,47 @@ func (ad *ActivityDetector) trackingLoop() {
 
 // processMinuteIncrement handles the minutely time increment logic
 func (ad *ActivityDetector) processMinuteIncrement() {
+	// Sampling takes a few seconds, so it happens before ad.mu is taken
+	resumed := ad.confirmIdleResume()
+
 	ad.mu.Lock()
 	defer ad.mu.Unlock()
 
+	// Nothing is created or credited while tracking is disabled
+	if ad.trackingDisabled || ad.currentEntry == nil {
+		return
+	}
+
+	// Never credit from the placeholder state NewMonitor starts with
+	if !ad.monitor.HasPolled() {
+		log.Println("Skipping minute increment - no completed system check yet")
+		return
+	}
+
 	// Check if we should increment time
+	now := time.Now()
 	systemState := ad.monitor.GetCurrentState()
-	shouldIncrement := systemState.IsActive && !ad.currentEntry.IsPaused
+	isActive, idle := systemState.IsActive, ad.monitor.IdleDuration()
+	if ad.config.ActivityMode == ActivityModeInput {
+		// Sampled input decides activity, which already accounts for idle time
+		isActive, idle = ad.takeInputMinute(), 0
+	} else if ad.awaitingInput && idle < ad.config.IdleThreshold && !resumed {
+		// Brief input doesn't end an idle stretch
+		idle = ad.config.IdleThreshold
+	}
+	shouldIncrement, reason := decideCredit(now, ad.config, isActive, ad.currentEntry.IsPaused, idle, ad.observeWake(now))
+	ad.trackIdleStretch(idle, resumed)
+	if ad.refreshPTO(isActive) {
+		shouldIncrement, reason = false, CreditReasonPTO
+	}
+
+	// Record why an active minute wasn't counted
+	if !shouldIncrement && isActive && reason.recordsSkipEvent() {
+		details := fmt.Sprintf("Date: %s", ad.currentEntry.Date)
+		if err := ad.db.LogSystemEvent("increment_skipped_"+string(reason), details); err != nil {
+			log.Printf("Error logging system event: %v", err)
+		}
+	}
 
 	if shouldIncrement {
 		// Increment time in database
@@ -245,8 +420,10 @@ func (ad *ActivityDetector) processMinuteIncrement() {
 		ad.notifyStateChange(systemState.IsActive, entry)
 	}
 
+	ad.checkPace(time.Now())
+
 	// Handle day rollover
-	now := time.Now()
+	now = time.Now()
 	todayStr := now.Format("2006-01-02")
 	if ad.currentEntry.Date != todayStr {
 		log.Println("Day rollover detected, creating new entry")
@@ -260,6 +437,83 @@ func (ad *ActivityDetector) processMinuteIncrement() {
 	}
 }
 
+// refreshPTO re-reads the current day's PTO mark, which can be changed from
+// the command line while running, and notifies callbacks when it changes.
+// Returns true on PTO days. Must be called with ad.mu held.
+func (ad *ActivityDetector) refreshPTO(isActive bool) bool {
+	pto, err := ad.db.IsPTO(ad.currentEntry.Date)
+	if err != nil {
+		log.Printf("Error checking PTO: %v", err)
+		return ad.currentEntry.IsPTO
+	}
+	if pto == ad.currentEntry.IsPTO {
+		return pto
+	}
+
+	entry, err := ad.db.GetEntryForDate(ad.currentEntry.Date)
+	if err != nil {
+		log.Printf("Error refreshing current entry: %v", err)
+		return pto
+	}
+	ad.currentEntry = entry
+	if pto {
+		log.Printf("🌴 %s is marked as PTO, not tracking", entry.Date)
+	}
+	ad.notifyStateChange(isActive, entry)
+	return pto
+}
+
+// creditStartupMinute credits one minute at launch when the initial system
+// check says the minute would be credited. Must be called with ad.mu held.
+func (ad *ActivityDetector) creditStartupMinute() {
+	if !ad.monitor.HasPolled() {
+		return
+	}
+
+	// There is no measured input to credit yet
+	if ad.config.ActivityMode == ActivityModeInput {
+		log.Println("No startup credit (activity_mode is input)")
+		return
+	}
+
+	systemState := ad.monitor.GetCurrentState()
+
+	// A fallback from on_unknown_state is fine for regular minutes, but the
+	// launch minute is only credited from a state macOS actually reported
+	if systemState.IsUnknown {
+		log.Println("No startup credit (system state unknown at launch)")
+		return
+	}
+
+	shouldIncrement, reason := decideCredit(time.Now(), ad.config, systemState.IsActive, ad.currentEntry.IsPaused, ad.monitor.IdleDuration(), ad.monitor.LastWake())
+	if ad.currentEntry.IsPTO {
+		shouldIncrement, reason = false, CreditReasonPTO
+	}
+	if !shouldIncrement {
+		log.Printf("No startup credit (%s)", reason)
+		return
+	}
+
+	if err := ad.db.IncrementActiveTime(); err != nil {
+		log.Printf("Error crediting startup minute: %v", err)
+		return
+	}
+
+	entry, err := ad.db.GetTodayEntry()
+	if err != nil {
+		log.Printf("Error refreshing current entry: %v", err)
+		return
+	}
+	ad.currentEntry = entry
+
+	if err := ad.db.LogSystemEvent("startup_credit", fmt.Sprintf("Date: %s", entry.Date)); err != nil {
+		log.Printf("Error logging system event: %v", err)
+	}
+
+	log.Printf("Startup minute credited - Total: %d minutes", entry.ActiveMinutes)
+	ad.notifyStateChange(systemState.IsActive, entry)
+}
+
 // onSystemStateChange is called when the system monitor detects state changes
 func (ad *ActivityDetector) onSystemStateChange(oldState, newState *SystemState) {
 	ad.mu.RLock()
@@ -267,16 +521,42 @@ func (ad *ActivityDetector) onSystemStateChange(oldState, newState *SystemState)
 	ad.mu.RUnlock()
 
 	// Log state change to database for debugging
-	eventType := "inactive"
-	if newState.IsActive {
-		eventType = "active"
+	ad.logStateChange(newState)
+
+	// Meeting transitions are informational and don't affect crediting
+	if oldState.IsInMeeting != newState.IsInMeeting {
+		eventType := "meeting_end"
+		if newState.IsInMeeting {
+			eventType = "meeting_start"
+		}
+		if err := ad.db.LogSystemEvent(eventType, fmt.Sprintf("Date: %s", time.Now().Format("2006-01-02"))); err != nil {
+			log.Printf("Error logging system event: %v", err)
+		}
 	}
 
-	details := fmt.Sprintf("Session:%v, Lid:%v, Screensaver:%v", 
-		newState.IsUserSessionActive, newState.IsLidOpen, !newState.IsScreenSaverRunning)
-	
-	if err := ad.db.LogSystemEvent(eventType, details); err != nil {
-		log.Printf("Error logging system event: %v", err)
+	// Display sleep transitions are logged whether or not they stop crediting
+	if oldState.IsDisplayAsleep != newState.IsDisplayAsleep {
+		eventType := "display_wake"
+		if newState.IsDisplayAsleep {
+			eventType = "display_sleep"
+		}
+		if err := ad.db.LogSystemEvent(eventType, fmt.Sprintf("Date: %s", time.Now().Format("2006-01-02"))); err != nil {
+			log.Printf("Error logging system event: %v", err)
+		}
+	}
+
+	if oldState.IsActive != newState.IsActive {
+		// With database.commit_interval_minutes, an active stretch is
+		// written once it ends
+		if err := ad.db.Flush(); err != nil {
+			log.Printf("Error writing pending active time: %v", err)
+		}
+
+		if newState.IsActive {
+			runHook("active", ad.config.Hooks.OnActive, currentEntry)
+		} else {
+			runHook("inactive", ad.config.Hooks.OnInactive, currentEntry)
+		}
 	}
 
 	// Notify callbacks
@@ -285,10 +565,64 @@ func (ad *ActivityDetector) onSystemStateChange(oldState, newState *SystemState)
 	}
 }
 
-// notifyStateChange calls all registered state change callbacks
+// logStateChange records a system state change in system_events.
+// When EventMinInterval is set, the event is only written once the state has
+// been stable for that long, so rapid flips (docking, display sleep) collapse
+// into a single row. Crediting always uses the real state and is unaffected.
+func (ad *ActivityDetector) logStateChange(state *SystemState) {
+	if ad.config.EventMinInterval <= 0 {
+		ad.writeStateEvent(state)
+		return
+	}
+
+	ad.eventMu.Lock()
+	defer ad.eventMu.Unlock()
+
+	if ad.pendingEventTimer != nil {
+		ad.pendingEventTimer.Stop()
+	}
+	ad.pendingEventTimer = time.AfterFunc(ad.config.EventMinInterval, func() {
+		ad.writeStateEvent(ad.monitor.GetCurrentState())
+	})
+}
+
+// writeStateEvent writes a state event unless it repeats the last one written
+func (ad *ActivityDetector) writeStateEvent(state *SystemState) {
+	eventType := "inactive"
+	if state.IsActive {
+		eventType = "active"
+	}
+
+	details := fmt.Sprintf("Session:%v, Lid:%v, Screensaver:%v, Locked:%v, Clamshell:%v, DisplayAsleep:%v",
+		state.IsUserSessionActive, state.IsLidOpen, !state.IsScreenSaverRunning, state.IsScreenLocked, state.IsClamshell, state.IsDisplayAsleep)
+
+	ad.eventMu.Lock()
+	key := eventType + "|" + details
+	if ad.config.EventMinInterval > 0 && key == ad.lastLoggedEvent {
+		// The state flipped and flipped back within the interval
+		ad.eventMu.Unlock()
+		return
+	}
+	ad.lastLoggedEvent = key
+	ad.eventMu.Unlock()
+
+	if err := ad.db.LogSystemEvent(eventType, details); err != nil {
+		log.Printf("Error logging system event: %v", err)
+	}
+}
+
+// notifyStateChange queues a call to every registered state change
+// callback. Each callback gets its own copy of the entry, since the
+// detector keeps updating currentEntry while the call waits in the queue.
 func (ad *ActivityDetector) notifyStateChange(isActive bool, entry *models.DailyTimeEntry) {
-	for _, callback := range ad.stateChangeCallbacks {
-		go callback(isActive, entry)
+	for i, callback := range ad.stateChangeCallbacks {
+		callback := callback
+		var entryCopy *models.DailyTimeEntry
+		if entry != nil {
+			copied := *entry
+			entryCopy = &copied
+		}
+		ad.callbackQueues[i].send(func() { callback(isActive, entryCopy) })
 	}
 }
 
@@ -301,15 +635,29 @@ func (ad *ActivityDetector) GetTodayStats() (*TodayStats, error) {
 
 	systemState := ad.GetSystemState()
 
+	idleMinutes, err := ad.db.GetIdleMinutes(entry.Date)
+	if err != nil {
+		log.Printf("Error getting idle minutes: %v", err)
+	}
+
+	breakMinutes, err := ad.db.GetBreakMinutes(entry.Date)
+	if err != nil {
+		log.Printf("Error getting break minutes: %v", err)
+	}
+
 	return &TodayStats{
 		Date:            entry.Date,
 		ActiveMinutes:   entry.ActiveMinutes,
 		GoalMinutes:     entry.GoalMinutes,
 		Progress:        entry.Progress(),
+		UnclampedProgress: entry.UnclampedProgress(),
 		IsGoalReached:   entry.IsGoalReached(),
 		IsPaused:        entry.IsPaused,
 		IsSystemActive:  systemState.IsActive,
 		AutoLogged:      entry.AutoLogged,
+		IsPTO:           entry.IsPTO,
+		IdleMinutes:     idleMinutes,
+		BreakMinutes:    breakMinutes,
 		LastUpdated:     entry.UpdatedAt,
 	}, nil
 }
@@ -320,10 +668,14 @@ type TodayStats struct {
 	ActiveMinutes  int       `json:"active_minutes"`
 	GoalMinutes    int       `json:"goal_minutes"`
 	Progress       float64   `json:"progress"`
+	UnclampedProgress float64 `json:"unclamped_progress"` // Progress past 1.0 when over goal
 	IsGoalReached  bool      `json:"is_goal_reached"`
 	IsPaused       bool      `json:"is_paused"`
 	IsSystemActive bool      `json:"is_system_active"`
 	AutoLogged     bool      `json:"auto_logged"`
+	IsPTO          bool      `json:"is_pto"`
+	IdleMinutes    int       `json:"idle_minutes"` // Active minutes skipped due to idle
+	BreakMinutes   int       `json:"break_minutes"` // Active minutes skipped inside break windows
 	LastUpdated    time.Time `json:"last_updated"`
 }
 
@@ -0,0 +1,133 @@
+package tracker
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"timeclip/internal/database"
+)
+
+// newTestDetector returns a detector on a fresh database whose monitor is
+// never started, so tests set the system state themselves
+func newTestDetector(t *testing.T, config *ActivityConfig) *ActivityDetector {
+	t.Helper()
+	db, err := database.NewDB(filepath.Join(t.TempDir(), "timeclip.db"))
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewActivityDetector(db, config)
+}
+
+// setState makes state the monitor's current state and returns a copy
+func setState(ad *ActivityDetector, state SystemState) *SystemState {
+	ad.monitor.mu.Lock()
+	ad.monitor.currentState = &state
+	ad.monitor.mu.Unlock()
+	return ad.monitor.GetCurrentState()
+}
+
+// stateEvents returns the active and inactive events logged today, in order
+func stateEvents(t *testing.T, ad *ActivityDetector) []string {
+	t.Helper()
+	today := time.Now().Format("2006-01-02")
+	events, err := ad.db.GetSystemEventsForDateRange(today, today)
+	if err != nil {
+		t.Fatalf("GetSystemEventsForDateRange: %v", err)
+	}
+	var types []string
+	for _, event := range events {
+		if event.EventType == "active" || event.EventType == "inactive" {
+			types = append(types, event.EventType)
+		}
+	}
+	return types
+}
+
+var (
+	activeState   = SystemState{IsActive: true, IsUserSessionActive: true, IsLidOpen: true}
+	inactiveState = SystemState{IsActive: false, IsUserSessionActive: true, IsLidOpen: false}
+)
+
+func TestLogStateChangeUnthrottled(t *testing.T) {
+	ad := newTestDetector(t, &ActivityConfig{})
+
+	ad.logStateChange(setState(ad, inactiveState))
+	ad.logStateChange(setState(ad, activeState))
+	ad.logStateChange(setState(ad, inactiveState))
+
+	if got := stateEvents(t, ad); len(got) != 3 {
+		t.Errorf("events = %v, want every change logged", got)
+	}
+}
+
+func TestLogStateChangeThrottled(t *testing.T) {
+	const interval = 50 * time.Millisecond
+	ad := newTestDetector(t, &ActivityConfig{EventMinInterval: interval})
+
+	// Rapid flips collapse into the state they settle in
+	ad.logStateChange(setState(ad, inactiveState))
+	ad.logStateChange(setState(ad, activeState))
+	ad.logStateChange(setState(ad, inactiveState))
+	if got := stateEvents(t, ad); len(got) != 0 {
+		t.Errorf("events before the interval = %v, want none", got)
+	}
+
+	time.Sleep(3 * interval)
+	if got := stateEvents(t, ad); len(got) != 1 || got[0] != "inactive" {
+		t.Fatalf("events after settling = %v, want [inactive]", got)
+	}
+
+	// Flipping away and back within the interval repeats the last event,
+	// which isn't written again
+	ad.logStateChange(setState(ad, activeState))
+	ad.logStateChange(setState(ad, inactiveState))
+	time.Sleep(3 * interval)
+	if got := stateEvents(t, ad); len(got) != 1 {
+		t.Errorf("events after a flip back = %v, want still [inactive]", got)
+	}
+
+	ad.logStateChange(setState(ad, activeState))
+	time.Sleep(3 * interval)
+	if got := stateEvents(t, ad); len(got) != 2 || got[1] != "active" {
+		t.Errorf("events after a stable change = %v, want [inactive active]", got)
+	}
+}
+
+func TestCreditStartupMinute(t *testing.T) {
+	tests := []struct {
+		name   string
+		polled bool
+		state  SystemState
+		want   int
+	}{
+		{"before the first check", false, activeState, 0},
+		{"active", true, activeState, 1},
+		{"inactive", true, inactiveState, 0},
+		{"unknown state fallback", true, SystemState{IsActive: true, IsUnknown: true}, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ad := newTestDetector(t, &ActivityConfig{CreditOnStartup: true})
+			entry, err := ad.db.GetTodayEntry()
+			if err != nil {
+				t.Fatalf("GetTodayEntry: %v", err)
+			}
+			ad.currentEntry = entry
+			setState(ad, tc.state)
+			ad.monitor.mu.Lock()
+			ad.monitor.hasPolled = tc.polled
+			ad.monitor.mu.Unlock()
+
+			ad.mu.Lock()
+			ad.creditStartupMinute()
+			ad.mu.Unlock()
+
+			if got := ad.currentEntry.ActiveMinutes; got != tc.want {
+				t.Errorf("credited %d minutes at launch, want %d", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package tracker
+
+// callbackQueueSize bounds the notifications waiting for one subscriber.
+// When a slow subscriber (e.g. the menu bar) falls this far behind, the
+// oldest waiting notification is dropped; later ones carry newer state.
+const callbackQueueSize = 16
+
+// callbackQueue delivers notifications to one subscriber in order on a
+// single goroutine, so rapid state flapping (e.g. docking and undocking)
+// can't pile up goroutines or update the UI out of order
+type callbackQueue struct {
+	pending chan func()
+}
+
+// newCallbackQueue starts the delivery goroutine for one subscriber. It
+// lives as long as the process, like the subscribers themselves.
+func newCallbackQueue() *callbackQueue {
+	q := &callbackQueue{pending: make(chan func(), callbackQueueSize)}
+	go func() {
+		for deliver := range q.pending {
+			deliver()
+		}
+	}()
+	return q
+}
+
+// send queues a notification without blocking the caller
+func (q *callbackQueue) send(deliver func()) {
+	for {
+		select {
+		case q.pending <- deliver:
+			return
+		default:
+		}
+
+		// Full: drop the oldest waiting notification and try again
+		select {
+		case <-q.pending:
+		default:
+		}
+	}
+}
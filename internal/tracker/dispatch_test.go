@@ -0,0 +1,98 @@
+package tracker
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCallbackQueueDropsOldest(t *testing.T) {
+	q := newCallbackQueue()
+
+	var mu sync.Mutex
+	var delivered []int
+	var wg sync.WaitGroup
+	record := func(n int) func() {
+		return func() {
+			mu.Lock()
+			delivered = append(delivered, n)
+			mu.Unlock()
+			wg.Done()
+		}
+	}
+
+	// Hold up the subscriber on its first notification
+	started := make(chan struct{})
+	release := make(chan struct{})
+	wg.Add(1)
+	q.send(func() {
+		close(started)
+		<-release
+		record(0)()
+	})
+	<-started
+
+	const sent = callbackQueueSize + 10
+	sendDone := make(chan struct{})
+	go func() {
+		for n := 1; n <= sent; n++ {
+			q.send(func() {})
+		}
+		close(sendDone)
+	}()
+	select {
+	case <-sendDone:
+	case <-time.After(time.Second):
+		t.Fatal("send blocked on a slow subscriber")
+	}
+
+	// Replace what's waiting with numbered notifications: the newest
+	// callbackQueueSize survive, in order
+	for n := 1; n <= sent; n++ {
+		q.send(record(n))
+	}
+	wg.Add(callbackQueueSize)
+	close(release)
+
+	waited := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(waited)
+	}()
+	select {
+	case <-waited:
+	case <-time.After(time.Second):
+		t.Fatal("queued notifications not delivered")
+	}
+
+	want := []int{0}
+	for n := sent - callbackQueueSize + 1; n <= sent; n++ {
+		want = append(want, n)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if !reflect.DeepEqual(delivered, want) {
+		t.Errorf("delivered %v, want %v", delivered, want)
+	}
+}
+
+func TestCallbackQueueDeliversInOrder(t *testing.T) {
+	q := newCallbackQueue()
+	results := make(chan int, callbackQueueSize)
+	for n := 0; n < callbackQueueSize; n++ {
+		n := n
+		q.send(func() { results <- n })
+	}
+
+	for want := 0; want < callbackQueueSize; want++ {
+		select {
+		case got := <-results:
+			if got != want {
+				t.Fatalf("delivered %d, want %d", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("notification %d not delivered", want)
+		}
+	}
+}
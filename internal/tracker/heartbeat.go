@@ -0,0 +1,43 @@
+package tracker
+
+import (
+	"log"
+	"time"
+)
+
+// heartbeatLoop logs a one-line summary every ActivityConfig.Heartbeat, so
+// the logs show that tracking was alive and in which state
+func (ad *ActivityDetector) heartbeatLoop(startedAt time.Time) {
+	ticker := time.NewTicker(ad.config.Heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ad.logHeartbeat(startedAt)
+		case <-ad.stopChan:
+			return
+		}
+	}
+}
+
+// logHeartbeat logs uptime, today's minutes, the current state and how many
+// days are queued for auto-logging
+func (ad *ActivityDetector) logHeartbeat(startedAt time.Time) {
+	uptime := time.Since(startedAt).Round(time.Minute)
+
+	minutes := 0
+	if entry := ad.GetCurrentEntry(); entry != nil {
+		minutes = entry.ActiveMinutes
+	}
+
+	queued := 0
+	if pending, err := ad.db.GetPendingLogs(); err != nil {
+		log.Printf("Error reading auto-log queue for heartbeat: %v", err)
+	} else {
+		queued = len(pending)
+	}
+
+	log.Printf("💓 Heartbeat: uptime=%s today_minutes=%d state=%q queued_logs=%d",
+		uptime, minutes, ad.GetStateDescription(), queued)
+}
@@ -0,0 +1,58 @@
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"timeclip/internal/models"
+)
+
+// hookTimeout bounds how long a state hook may run before it is killed
+const hookTimeout = 30 * time.Second
+
+// StateHooks are shell commands run on tracking state transitions
+// (general.on_active_cmd etc.). Empty commands are skipped.
+type StateHooks struct {
+	OnActive   string
+	OnInactive string
+	OnPause    string
+	OnResume   string
+}
+
+// runHook runs command with /bin/sh in the background. The event and the
+// day's totals are passed as TIMECLIP_* environment variables; failures are
+// only logged.
+func runHook(event, command string, entry *models.DailyTimeEntry) {
+	if command == "" || entry == nil {
+		return
+	}
+
+	env := append(os.Environ(),
+		"TIMECLIP_EVENT="+event,
+		"TIMECLIP_DATE="+entry.Date,
+		"TIMECLIP_MINUTES="+strconv.Itoa(entry.ActiveMinutes),
+		"TIMECLIP_GOAL_MINUTES="+strconv.Itoa(entry.GoalMinutes),
+		"TIMECLIP_PAUSED="+strconv.FormatBool(entry.IsPaused),
+	)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+		defer cancel()
+
+		cmd := exec.CommandContext(ctx, "/bin/sh", "-c", command)
+		cmd.Env = env
+		output, err := cmd.CombinedOutput()
+		if ctx.Err() == context.DeadlineExceeded {
+			err = fmt.Errorf("timed out after %v", hookTimeout)
+		}
+		if err != nil {
+			log.Printf("Error running on_%s_cmd: %v (%s)", event, err, strings.TrimSpace(string(output)))
+		}
+	}()
+}
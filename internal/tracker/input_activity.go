@@ -0,0 +1,63 @@
+package tracker
+
+import (
+	"log"
+	"time"
+)
+
+// ActivityMode decides how active minutes are measured
+type ActivityMode string
+
+const (
+	// ActivityModePresence credits a minute if the system is active when the
+	// minute is checked
+	ActivityModePresence ActivityMode = "presence"
+	// ActivityModeInput samples keyboard/mouse input and credits time in
+	// proportion to it
+	ActivityModeInput ActivityMode = "input"
+)
+
+// Input sampling settings for ActivityModeInput
+const (
+	inputSampleInterval = 5 * time.Second
+	maxBankedInput      = 2 * time.Minute // Caps input carried over between minute ticks
+)
+
+// inputLoop samples the time since the last input event. A sample with input
+// since the previous one counts as inputSampleInterval of activity.
+func (ad *ActivityDetector) inputLoop() {
+	ticker := time.NewTicker(inputSampleInterval)
+	defer ticker.Stop()
+
+	log.Printf("Sampling input activity every %v", inputSampleInterval)
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ad.stopChan:
+			return
+		}
+
+		if ad.monitor.IdleDuration() >= inputSampleInterval {
+			continue
+		}
+
+		ad.mu.Lock()
+		ad.inputActive += inputSampleInterval
+		if ad.inputActive > maxBankedInput {
+			ad.inputActive = maxBankedInput
+		}
+		ad.mu.Unlock()
+	}
+}
+
+// takeInputMinute consumes a minute of sampled input activity if one has
+// accumulated. Partial minutes carry over, so bursts in consecutive minutes
+// add up. Must be called with ad.mu held.
+func (ad *ActivityDetector) takeInputMinute() bool {
+	if ad.inputActive < time.Minute {
+		return false
+	}
+	ad.inputActive -= time.Minute
+	return true
+}
@@ -0,0 +1,153 @@
+package tracker
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"timeclip/internal/database"
+	"timeclip/internal/models"
+)
+
+// DefaultJournalTemplate is used when general.journal_file is set without a
+// general.journal_template
+const DefaultJournalTemplate = "{date} {weekday} {hours}h/{goal_hours}h {goal_status} logged:{logged}"
+
+// journal appends a summary line for each finished day to
+// general.journal_file. A day is finished when the current entry moves to a
+// new date while the app is running.
+type journal struct {
+	db       *database.DB
+	path     string
+	template string
+	lastDate string // Date of the entry seen last
+}
+
+// newJournal creates a journal writer for path, with ~ expanded
+func newJournal(db *database.DB, path, template string) (*journal, error) {
+	path, err := database.ExpandPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if template == "" {
+		template = DefaultJournalTemplate
+	}
+	return &journal{db: db, path: path, template: template}, nil
+}
+
+// update is an ActivityStateChangeCallback that writes the previous day's
+// line once the entry's date changes
+func (j *journal) update(isActive bool, entry *models.DailyTimeEntry) {
+	if entry == nil || entry.Date == j.lastDate {
+		return
+	}
+	finished := j.lastDate
+	j.lastDate = entry.Date
+	if finished == "" {
+		return
+	}
+
+	// Read the day again for its final minutes and logged state
+	day, err := j.db.GetEntryForDate(finished)
+	if err != nil {
+		log.Printf("Error reading %s for the journal: %v", finished, err)
+		return
+	}
+	if err := j.append(FormatJournalLine(j.template, day)); err != nil {
+		log.Printf("Error writing journal: %v", err)
+		return
+	}
+	log.Printf("📓 Wrote %s to the journal", finished)
+}
+
+// append adds line to the journal, creating the file and its directory if
+// needed. The line goes out in a single write to a file opened for
+// appending, so it is never interleaved with or split by other writers.
+func (j *journal) append(line string) error {
+	if err := os.MkdirAll(filepath.Dir(j.path), 0755); err != nil {
+		return fmt.Errorf("failed to create journal directory: %w", err)
+	}
+
+	file, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open journal: %w", err)
+	}
+	if _, err := file.WriteString(line + "\n"); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to append to journal: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close journal: %w", err)
+	}
+	return nil
+}
+
+// FormatJournalLine renders a journal template for a finished day. Values
+// never contain spaces, so lines split cleanly into fields.
+//
+// Supported placeholders:
+//   - {date}: the entry date (YYYY-MM-DD)
+//   - {weekday}: short day name (e.g. Mon)
+//   - {hours}, {goal_hours}: active and goal hours with one decimal, without a trailing .0
+//   - {minutes}, {goal_minutes}: active and goal minutes
+//   - {goal_status}: "goal-reached", "under-goal", "no-goal" or "pto"
+//   - {logged}: the provider the day was logged to, "yes" if unknown, or "no"
+func FormatJournalLine(template string, entry *models.DailyTimeEntry) string {
+	weekday := ""
+	if day, err := time.ParseInLocation("2006-01-02", entry.Date, time.Local); err == nil {
+		weekday = day.Format("Mon")
+	}
+
+	goalStatus := "under-goal"
+	switch {
+	case entry.IsPTO:
+		goalStatus = "pto"
+	case !entry.HasGoal():
+		goalStatus = "no-goal"
+	case entry.IsGoalReached():
+		goalStatus = "goal-reached"
+	}
+
+	replacer := strings.NewReplacer(
+		"{date}", entry.Date,
+		"{weekday}", weekday,
+		"{hours}", journalHours(entry.ActiveMinutes),
+		"{goal_hours}", journalHours(entry.GoalMinutes),
+		"{minutes}", strconv.Itoa(entry.ActiveMinutes),
+		"{goal_minutes}", strconv.Itoa(entry.GoalMinutes),
+		"{goal_status}", goalStatus,
+		"{logged}", loggedTo(entry),
+	)
+	return replacer.Replace(template)
+}
+
+// journalHours formats minutes as hours with one decimal, dropping ".0"
+func journalHours(minutes int) string {
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", float64(minutes)/60.0), ".0")
+}
+
+// loggedTo returns the provider a logged day went to, read from the stored
+// response ("Successfully logged to Clockify: ...")
+func loggedTo(entry *models.DailyTimeEntry) string {
+	if !entry.AutoLogged {
+		return "no"
+	}
+	rest, ok := strings.CutPrefix(entry.AutoLogResponse, "Successfully logged to ")
+	if !ok {
+		return "yes"
+	}
+	if provider, _, found := strings.Cut(rest, ":"); found {
+		rest = provider
+	}
+	if provider, _, found := strings.Cut(rest, " "); found {
+		rest = provider
+	}
+	if rest == "" {
+		return "yes"
+	}
+	return strings.ToLower(rest)
+}
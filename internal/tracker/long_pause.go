@@ -0,0 +1,55 @@
+package tracker
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"timeclip/internal/notify"
+)
+
+// trackPauseChange runs the pause/resume hooks, remembers when a pause
+// started and, on resume, reports pauses of at least ActivityConfig.LongPause
+// so work done elsewhere can be reconciled later. Must be called with ad.mu held.
+func (ad *ActivityDetector) trackPauseChange(paused bool, now time.Time) {
+	if paused {
+		runHook("pause", ad.config.Hooks.OnPause, ad.currentEntry)
+		ad.pausedAt = now
+		return
+	}
+	runHook("resume", ad.config.Hooks.OnResume, ad.currentEntry)
+
+	pausedAt := ad.pausedAt
+	ad.pausedAt = time.Time{}
+	if ad.config.LongPause <= 0 || pausedAt.IsZero() || ad.currentEntry == nil {
+		return
+	}
+
+	duration := now.Sub(pausedAt)
+	if duration < ad.config.LongPause {
+		return
+	}
+
+	minutes := int(duration.Minutes())
+	log.Printf("⏸️  Resumed after a long pause: %s (since %s)", formatPauseDuration(minutes), pausedAt.Format("15:04"))
+
+	details := fmt.Sprintf("Date: %s, Minutes: %d", ad.currentEntry.Date, minutes)
+	if err := ad.db.LogSystemEvent("long_pause", details); err != nil {
+		log.Printf("Error logging system event: %v", err)
+	}
+
+	message := fmt.Sprintf("Welcome back — tracking was paused for %s. If you worked elsewhere, that time was not tracked.", formatPauseDuration(minutes))
+	go func() {
+		if err := notify.Send("Timeclip", message); err != nil {
+			log.Printf("Error sending long pause notification: %v", err)
+		}
+	}()
+}
+
+// formatPauseDuration formats minutes as "45m" or "3h 10m"
+func formatPauseDuration(minutes int) string {
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh %dm", minutes/60, minutes%60)
+}
@@ -0,0 +1,107 @@
+package tracker
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"time"
+)
+
+// Network probe settings for ActivityConfig.NetworkProbeHost
+const (
+	networkProbeTimeout  = 3 * time.Second
+	networkProbePort     = "443"
+	networkLossThreshold = 2 // Consecutive failed probes before pausing
+)
+
+// networkLoop probes NetworkProbeHost every check interval. Tracking is paused
+// when the host becomes unreachable and resumed when it is back. A pause the
+// user started before the loss is left alone.
+func (ad *ActivityDetector) networkLoop() {
+	ticker := time.NewTicker(ad.config.CheckInterval)
+	defer ticker.Stop()
+
+	address := probeAddress(ad.config.NetworkProbeHost)
+	failures := 0
+	pausedByNetwork := false
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ad.stopChan:
+			return
+		}
+
+		if probeReachable(address) {
+			if failures >= networkLossThreshold {
+				ad.onNetworkRestored(address, pausedByNetwork)
+				pausedByNetwork = false
+			}
+			failures = 0
+			continue
+		}
+
+		failures++
+		if failures == networkLossThreshold {
+			pausedByNetwork = ad.onNetworkLost(address)
+		}
+	}
+}
+
+// onNetworkLost records the loss and pauses tracking. It returns true if
+// tracking was paused because of the loss.
+func (ad *ActivityDetector) onNetworkLost(address string) bool {
+	log.Printf("🔌 %s is unreachable - pausing tracking", address)
+	ad.logNetworkEvent("network_lost", address)
+
+	entry := ad.GetCurrentEntry()
+	if entry == nil || entry.IsPaused {
+		return false
+	}
+	if err := ad.SetPause(true); err != nil {
+		log.Printf("Error pausing on network loss: %v", err)
+		return false
+	}
+	return true
+}
+
+// onNetworkRestored records the recovery and resumes tracking if it was
+// paused by the loss and is still paused
+func (ad *ActivityDetector) onNetworkRestored(address string, pausedByNetwork bool) {
+	log.Printf("🔌 %s is reachable again", address)
+	ad.logNetworkEvent("network_restored", address)
+
+	entry := ad.GetCurrentEntry()
+	if !pausedByNetwork || entry == nil || !entry.IsPaused {
+		return
+	}
+	if err := ad.SetPause(false); err != nil {
+		log.Printf("Error resuming after network loss: %v", err)
+	}
+}
+
+// logNetworkEvent writes a network transition to system_events
+func (ad *ActivityDetector) logNetworkEvent(eventType, address string) {
+	details := fmt.Sprintf("Date: %s, Host: %s", time.Now().Format("2006-01-02"), address)
+	if err := ad.db.LogSystemEvent(eventType, details); err != nil {
+		log.Printf("Error logging system event: %v", err)
+	}
+}
+
+// probeAddress adds the default port to a host without one
+func probeAddress(host string) string {
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return host
+	}
+	return net.JoinHostPort(host, networkProbePort)
+}
+
+// probeReachable returns true if a TCP connection to address succeeds
+func probeReachable(address string) bool {
+	conn, err := net.DialTimeout("tcp", address, networkProbeTimeout)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
@@ -0,0 +1,185 @@
+package tracker
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"timeclip/internal/database"
+	"timeclip/internal/models"
+)
+
+// defaultObserverInterval is used when general.check_interval_seconds is unset
+const defaultObserverInterval = time.Minute
+
+// Observer shows the time tracked by another machine from a shared database
+// (general.observer_mode). It opens the database read-only and polls today's
+// entry; nothing is tracked, paused or logged. Read-only connections see the
+// tracking machine's WAL commits on their next query.
+type Observer struct {
+	mu             sync.RWMutex
+	db             *database.DB
+	config         *models.Config
+	interval       time.Duration
+	stopChan       chan bool
+	isRunning      bool
+	lastEntry      *models.DailyTimeEntry
+	callbacks      []ActivityStateChangeCallback
+	callbackQueues []*callbackQueue // One per callback, same order
+}
+
+// NewObserver opens the configured database read-only for observing
+func NewObserver(config *models.Config) (*Observer, error) {
+	db, err := database.OpenReadOnly(config.DatabasePath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database for observing: %w", err)
+	}
+
+	interval := time.Duration(config.General.CheckIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = defaultObserverInterval
+	}
+
+	return &Observer{
+		db:       db,
+		config:   config,
+		interval: interval,
+		stopChan: make(chan bool),
+	}, nil
+}
+
+// Start begins polling the database
+func (o *Observer) Start() error {
+	o.mu.Lock()
+	if o.isRunning {
+		o.mu.Unlock()
+		return fmt.Errorf("observer is already running")
+	}
+	o.isRunning = true
+	o.mu.Unlock()
+
+	log.Printf("👁  Observer mode: showing %s read-only, refreshing every %v", o.db.GetDatabasePath(), o.interval)
+	o.poll()
+	go o.pollLoop()
+	return nil
+}
+
+// Stop stops polling and closes the database
+func (o *Observer) Stop() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if !o.isRunning {
+		return
+	}
+
+	o.isRunning = false
+	close(o.stopChan)
+	if err := o.db.Close(); err != nil {
+		log.Printf("Error closing observed database: %v", err)
+	}
+}
+
+// AddStateChangeCallback adds a callback called whenever today's entry changes
+func (o *Observer) AddStateChangeCallback(callback ActivityStateChangeCallback) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.callbacks = append(o.callbacks, callback)
+	o.callbackQueues = append(o.callbackQueues, newCallbackQueue())
+}
+
+// GetCurrentEntry returns today's entry as last read
+func (o *Observer) GetCurrentEntry() *models.DailyTimeEntry {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	if o.lastEntry == nil {
+		return nil
+	}
+	entry := *o.lastEntry
+	return &entry
+}
+
+// GetTodayStats returns statistics for today as recorded by the tracking
+// machine. IsSystemActive is always false since that machine's state isn't
+// stored.
+func (o *Observer) GetTodayStats() (*TodayStats, error) {
+	entry := o.GetCurrentEntry()
+	if entry == nil {
+		return nil, fmt.Errorf("no current entry available")
+	}
+
+	idleMinutes, err := o.db.GetIdleMinutes(entry.Date)
+	if err != nil {
+		log.Printf("Error getting idle minutes: %v", err)
+	}
+
+	breakMinutes, err := o.db.GetBreakMinutes(entry.Date)
+	if err != nil {
+		log.Printf("Error getting break minutes: %v", err)
+	}
+
+	return &TodayStats{
+		Date:              entry.Date,
+		ActiveMinutes:     entry.ActiveMinutes,
+		GoalMinutes:       entry.GoalMinutes,
+		Progress:          entry.Progress(),
+		UnclampedProgress: entry.UnclampedProgress(),
+		IsGoalReached:     entry.IsGoalReached(),
+		IsPaused:          entry.IsPaused,
+		AutoLogged:        entry.AutoLogged,
+		IsPTO:             entry.IsPTO,
+		IdleMinutes:       idleMinutes,
+		BreakMinutes:      breakMinutes,
+		LastUpdated:       entry.UpdatedAt,
+	}, nil
+}
+
+// pollLoop re-reads today's entry every interval until stopped
+func (o *Observer) pollLoop() {
+	ticker := time.NewTicker(o.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			o.poll()
+		case <-o.stopChan:
+			return
+		}
+	}
+}
+
+// poll reads today's entry and notifies callbacks if it changed. Until the
+// tracking machine creates today's entry an empty one is shown.
+func (o *Observer) poll() {
+	today := time.Now().Format("2006-01-02")
+	entries, err := o.db.GetEntriesForDateRange(today, today)
+	if err != nil {
+		log.Printf("Error reading observed database: %v", err)
+		return
+	}
+
+	entry := &models.DailyTimeEntry{Date: today, GoalMinutes: o.config.General.GoalMinutesFor(time.Now())}
+	if len(entries) > 0 {
+		entry = entries[0]
+	}
+
+	o.mu.Lock()
+	changed := o.lastEntry == nil || *o.lastEntry != *entry
+	o.lastEntry = entry
+	callbacks := make([]ActivityStateChangeCallback, len(o.callbacks))
+	copy(callbacks, o.callbacks)
+	queues := make([]*callbackQueue, len(o.callbackQueues))
+	copy(queues, o.callbackQueues)
+	o.mu.Unlock()
+
+	if !changed {
+		return
+	}
+	for i, callback := range callbacks {
+		callback := callback
+		queues[i].send(func() { callback(false, entry) })
+	}
+}
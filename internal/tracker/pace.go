@@ -0,0 +1,64 @@
+package tracker
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"timeclip/internal/models"
+	"timeclip/internal/notify"
+)
+
+// paceGracePeriod is how far into the work window the pace check waits,
+// so a late start doesn't trigger an alert straight away
+const paceGracePeriod = time.Hour
+
+// checkPace sends a single notification per day when tracked time is far below
+// the pace needed to reach the goal by the end of the work window.
+// Must be called with ad.mu held.
+func (ad *ActivityDetector) checkPace(now time.Time) {
+	if ad.config.PaceAlertRatio <= 0 || ad.config.WorkWindow == nil || ad.currentEntry == nil {
+		return
+	}
+	if ad.currentEntry.IsPaused || ad.paceAlertedDate == ad.currentEntry.Date {
+		return
+	}
+	if ad.config.IsTrackDay != nil && !ad.config.IsTrackDay(now) {
+		return
+	}
+
+	expected, ok := expectedMinutes(now, *ad.config.WorkWindow, ad.currentEntry.GoalMinutes)
+	if !ok || float64(ad.currentEntry.ActiveMinutes) >= ad.config.PaceAlertRatio*float64(expected) {
+		return
+	}
+
+	ad.paceAlertedDate = ad.currentEntry.Date
+	log.Printf("⚠️  Tracking seems low: %d minutes tracked, about %d expected by now", ad.currentEntry.ActiveMinutes, expected)
+
+	if err := ad.db.LogSystemEvent("pace_alert", fmt.Sprintf("Date: %s", ad.currentEntry.Date)); err != nil {
+		log.Printf("Error logging system event: %v", err)
+	}
+	go func() {
+		if err := notify.Send("Timeclip", "Tracking seems low — is Timeclip running correctly?"); err != nil {
+			log.Printf("Error sending pace alert: %v", err)
+		}
+	}()
+}
+
+// expectedMinutes returns how many minutes an even pace toward goalMinutes
+// across the work window would have reached by now. It returns false outside
+// the window or during its grace period.
+func expectedMinutes(now time.Time, window models.TimeWindow, goalMinutes int) (int, bool) {
+	start, end, err := window.Bounds()
+	if err != nil {
+		return 0, false
+	}
+
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	elapsed := now.Sub(midnight) - start
+	if elapsed < paceGracePeriod || now.Sub(midnight) >= end {
+		return 0, false
+	}
+
+	return int(float64(goalMinutes) * float64(elapsed) / float64(end-start)), true
+}
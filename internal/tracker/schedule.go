@@ -0,0 +1,74 @@
+package tracker
+
+import (
+	"time"
+
+	"timeclip/internal/models"
+)
+
+// CreditReason explains why a minute was or wasn't credited
+type CreditReason string
+
+const (
+	CreditReasonActive           CreditReason = "active"
+	CreditReasonInactive         CreditReason = "inactive"
+	CreditReasonPaused           CreditReason = "paused"
+	CreditReasonOutsideWorkHours CreditReason = "outside_work_window"
+	CreditReasonBreak            CreditReason = "break"
+	CreditReasonIdle             CreditReason = "idle"
+	CreditReasonWarmup           CreditReason = "warmup"
+	CreditReasonPTO              CreditReason = "pto"
+)
+
+// decideCredit determines whether the minute at now should be credited.
+//
+// Precedence:
+//  1. outside the work window → never credited
+//  2. inside the work window but inside a break → not credited
+//  3. otherwise credited if the system is active, tracking isn't paused, the
+//     post-wake warmup has passed and, when an idle threshold is configured,
+//     there was recent input
+//
+// All windows are half-open, so a break ending at 12:00 does not cover 12:00,
+// and a work window ending at 17:00 does not cover 17:00.
+func decideCredit(now time.Time, config *ActivityConfig, isActive, isPaused bool, idle time.Duration, lastWake time.Time) (bool, CreditReason) {
+	if config.WorkWindow != nil && !config.WorkWindow.Contains(now) {
+		return false, CreditReasonOutsideWorkHours
+	}
+
+	for _, breakWindow := range config.Breaks {
+		if breakWindow.Contains(now) {
+			return false, CreditReasonBreak
+		}
+	}
+
+	if isPaused {
+		return false, CreditReasonPaused
+	}
+	if !isActive {
+		return false, CreditReasonInactive
+	}
+	if config.PostWakeWarmup > 0 && !lastWake.IsZero() && now.Sub(lastWake) < config.PostWakeWarmup {
+		return false, CreditReasonWarmup
+	}
+	if config.IdleThreshold > 0 && idle >= config.IdleThreshold {
+		return false, CreditReasonIdle
+	}
+
+	return true, CreditReasonActive
+}
+
+// recordsSkipEvent returns true if an active minute skipped for this reason
+// should be written to system_events
+func (r CreditReason) recordsSkipEvent() bool {
+	return r == CreditReasonOutsideWorkHours || r == CreditReasonBreak || r == CreditReasonIdle || r == CreditReasonWarmup
+}
+
+// workWindowFromConfig returns the configured work window, or nil when unset
+func workWindowFromConfig(general *models.GeneralConfig) *models.TimeWindow {
+	window, ok := general.WorkWindow()
+	if !ok {
+		return nil
+	}
+	return &window
+}
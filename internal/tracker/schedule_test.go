@@ -0,0 +1,63 @@
+package tracker
+
+import (
+	"testing"
+	"time"
+
+	"timeclip/internal/models"
+)
+
+func TestDecideCredit(t *testing.T) {
+	config := &ActivityConfig{
+		WorkWindow: &models.TimeWindow{Start: "09:00", End: "17:00"},
+		Breaks:     []models.TimeWindow{{Start: "12:00", End: "12:30"}},
+	}
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 5, 6, hour, minute, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name       string
+		now        time.Time
+		isActive   bool
+		isPaused   bool
+		wantCredit bool
+		wantReason CreditReason
+	}{
+		{"before work", at(8, 59), true, false, false, CreditReasonOutsideWorkHours},
+		{"work starts", at(9, 0), true, false, true, CreditReasonActive},
+		{"break starts", at(12, 0), true, false, false, CreditReasonBreak},
+		{"break ends", at(12, 30), true, false, true, CreditReasonActive},
+		{"work ends", at(17, 0), true, false, false, CreditReasonOutsideWorkHours},
+		{"inactive in a break", at(12, 10), false, false, false, CreditReasonBreak},
+		{"paused", at(10, 0), true, true, false, CreditReasonPaused},
+		{"inactive", at(10, 0), false, false, false, CreditReasonInactive},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			credit, reason := decideCredit(tc.now, config, tc.isActive, tc.isPaused, 0, time.Time{})
+			if credit != tc.wantCredit || reason != tc.wantReason {
+				t.Errorf("decideCredit = %v, %s; want %v, %s", credit, reason, tc.wantCredit, tc.wantReason)
+			}
+		})
+	}
+}
+
+func TestDecideCreditWithoutWindows(t *testing.T) {
+	config := &ActivityConfig{}
+	late := time.Date(2024, 5, 6, 23, 30, 0, 0, time.Local)
+	if credit, reason := decideCredit(late, config, true, false, 0, time.Time{}); !credit {
+		t.Errorf("decideCredit = %v, %s; want any time credited without a work window", credit, reason)
+	}
+}
+
+func TestWorkWindowFromConfig(t *testing.T) {
+	if window := workWindowFromConfig(&models.GeneralConfig{}); window != nil {
+		t.Errorf("workWindowFromConfig = %+v, want nil when unset", window)
+	}
+	window := workWindowFromConfig(&models.GeneralConfig{WorkStart: "09:00", WorkEnd: "17:00"})
+	if window == nil || *window != (models.TimeWindow{Start: "09:00", End: "17:00"}) {
+		t.Errorf("workWindowFromConfig = %+v, want 09:00-17:00", window)
+	}
+}
@@ -0,0 +1,94 @@
+package tracker
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"timeclip/internal/models"
+)
+
+// StatusFileName is the status file written to the data directory when
+// general.write_status_file is set
+const StatusFileName = "status.json"
+
+// Status is the content of the status file. Shell prompts and other simple
+// integrations read it instead of talking to the app.
+type Status struct {
+	Date          string    `json:"date"`
+	ActiveMinutes int       `json:"active_minutes"`
+	GoalMinutes   int       `json:"goal_minutes"`
+	Progress      float64   `json:"progress"`
+	GoalReached   bool      `json:"goal_reached"`
+	Paused        bool      `json:"paused"`
+	SystemActive  bool      `json:"system_active"`
+	UpdatedAt     time.Time `json:"updated_at"` // Stops advancing when the app isn't running
+}
+
+// statusFile rewrites the status file on every state change
+type statusFile struct {
+	path string
+}
+
+// newStatusFile creates a status file writer for a file in dir
+func newStatusFile(dir string) *statusFile {
+	return &statusFile{path: filepath.Join(dir, StatusFileName)}
+}
+
+// update is an ActivityStateChangeCallback that writes the new state
+func (sf *statusFile) update(isActive bool, entry *models.DailyTimeEntry) {
+	if entry == nil {
+		return
+	}
+
+	status := &Status{
+		Date:          entry.Date,
+		ActiveMinutes: entry.ActiveMinutes,
+		GoalMinutes:   entry.GoalMinutes,
+		Progress:      entry.Progress(),
+		GoalReached:   entry.IsGoalReached(),
+		Paused:        entry.IsPaused,
+		SystemActive:  isActive,
+		UpdatedAt:     time.Now(),
+	}
+
+	if err := sf.write(status); err != nil {
+		log.Printf("Error writing status file: %v", err)
+	}
+}
+
+// write replaces the status file atomically: the JSON goes to a temporary
+// file in the same directory, which is then renamed over the old one, so
+// readers see either the previous or the new status, never a partial one
+func (sf *statusFile) write(status *Status) error {
+	data, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode status: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(sf.path), ".status-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary status file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write temporary status file: %w", err)
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to set status file permissions: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary status file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), sf.path); err != nil {
+		return fmt.Errorf("failed to replace status file: %w", err)
+	}
+	return nil
+}
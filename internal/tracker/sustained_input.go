@@ -0,0 +1,77 @@
+package tracker
+
+import (
+	"log"
+	"time"
+)
+
+// Settings for general.resume_requires_sustained_input
+const (
+	resumeConfirmWindow  = 5 * time.Second // How long input is sampled before an idle stretch ends
+	resumeConfirmSamples = 3               // One-second samples that must see input within the window
+)
+
+// sustainedInput samples the time since the last input once a second for up
+// to resumeConfirmWindow and returns true once resumeConfirmSamples samples
+// saw input within the past second. A single stray event (a cat on the
+// keyboard, a notification click) only shows up in the first sample.
+// sample and sleep are parameters so the decision can be followed without
+// the macOS APIs.
+func sustainedInput(sample func() time.Duration, sleep func(time.Duration)) bool {
+	samples := int(resumeConfirmWindow / time.Second)
+	seen := 0
+	for i := 0; i < samples; i++ {
+		if i > 0 {
+			sleep(time.Second)
+		}
+		if sample() < time.Second {
+			seen++
+		}
+		if seen >= resumeConfirmSamples {
+			return true
+		}
+		if seen+samples-i-1 < resumeConfirmSamples {
+			return false // Can't get enough samples any more
+		}
+	}
+	return false
+}
+
+// confirmIdleResume checks, before the minute tick takes ad.mu, whether the
+// input ending an idle stretch is sustained. It only samples while an idle
+// stretch is waiting to end, and blocks for up to resumeConfirmWindow.
+func (ad *ActivityDetector) confirmIdleResume() bool {
+	if !ad.config.ResumeRequiresSustainedInput || ad.config.IdleThreshold <= 0 {
+		return false
+	}
+
+	ad.mu.RLock()
+	waiting := ad.awaitingInput
+	ad.mu.RUnlock()
+
+	if !waiting || ad.monitor.IdleDuration() >= ad.config.IdleThreshold {
+		return false
+	}
+	if sustainedInput(ad.monitor.IdleDuration, time.Sleep) {
+		log.Println("Input resumed after idle")
+		return true
+	}
+	log.Println("Ignoring brief input after idle (resume_requires_sustained_input)")
+	return false
+}
+
+// trackIdleStretch starts an idle stretch once idle reaches the threshold,
+// whether that made the minute idle (idle_threshold_seconds) or the monitor
+// inactive (require_recent_input, idle_is_primary, which use the same
+// threshold), and ends it once confirmIdleResume saw sustained input. Must
+// be called with ad.mu held.
+func (ad *ActivityDetector) trackIdleStretch(idle time.Duration, resumed bool) {
+	if !ad.config.ResumeRequiresSustainedInput || ad.config.IdleThreshold <= 0 || ad.config.ActivityMode == ActivityModeInput {
+		return
+	}
+	if idle >= ad.config.IdleThreshold {
+		ad.awaitingInput = true
+	} else if resumed {
+		ad.awaitingInput = false
+	}
+}
@@ -0,0 +1,79 @@
+package tracker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSustainedInput(t *testing.T) {
+	const (
+		input = 200 * time.Millisecond // Input within the past second
+		idle  = 10 * time.Minute
+	)
+
+	tests := []struct {
+		name        string
+		samples     []time.Duration
+		want        bool
+		wantSampled int
+	}{
+		{"steady input", []time.Duration{input, input, input, input, input}, true, 3},
+		{"input after a pause", []time.Duration{input, idle, input, input, idle}, true, 4},
+		{"single stray event", []time.Duration{input, idle, idle, idle, idle}, false, 4},
+		{"input at the very end", []time.Duration{idle, idle, input, input, input}, true, 5},
+		{"too late to catch up", []time.Duration{idle, idle, idle, input, input}, false, 3},
+		{"no input", []time.Duration{idle, idle, idle, idle, idle}, false, 3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sampled := 0
+			sample := func() time.Duration {
+				sampled++
+				return tc.samples[sampled-1]
+			}
+			var slept time.Duration
+			sleep := func(d time.Duration) { slept += d }
+
+			if got := sustainedInput(sample, sleep); got != tc.want {
+				t.Errorf("sustainedInput = %v, want %v", got, tc.want)
+			}
+			if sampled != tc.wantSampled {
+				t.Errorf("sampled %d times, want %d", sampled, tc.wantSampled)
+			}
+			if want := time.Duration(sampled-1) * time.Second; slept != want {
+				t.Errorf("slept %v, want %v", slept, want)
+			}
+		})
+	}
+}
+
+func TestTrackIdleStretch(t *testing.T) {
+	ad := newTestDetector(t, &ActivityConfig{IdleThreshold: 5 * time.Minute, ResumeRequiresSustainedInput: true})
+
+	steps := []struct {
+		name    string
+		idle    time.Duration
+		resumed bool
+		want    bool
+	}{
+		{"active", time.Second, false, false},
+		{"idle threshold reached", 5 * time.Minute, false, true},
+		{"brief input", time.Second, false, true},
+		{"sustained input", time.Second, true, false},
+	}
+	for _, step := range steps {
+		ad.mu.Lock()
+		ad.trackIdleStretch(step.idle, step.resumed)
+		got := ad.awaitingInput
+		ad.mu.Unlock()
+
+		if got != step.want {
+			t.Errorf("%s: awaiting input = %v, want %v", step.name, got, step.want)
+		}
+		waiting := ad.GetStateDescription() == "Inactive (waiting for sustained input)"
+		if waiting != step.want {
+			t.Errorf("%s: state description = %q", step.name, ad.GetStateDescription())
+		}
+	}
+}
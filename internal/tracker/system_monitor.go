@@ -1,50 +1,227 @@
 package tracker
 
 /*
-#cgo LDFLAGS: -framework CoreGraphics -framework IOKit -framework Foundation
+#cgo LDFLAGS: -framework CoreGraphics -framework IOKit -framework Foundation -framework CoreAudio -framework CoreMediaIO
 #include <CoreGraphics/CoreGraphics.h>
 #include <IOKit/IOKitLib.h>
 #include <IOKit/pwr_mgt/IOPMLib.h>
+#include <CoreAudio/CoreAudio.h>
+#include <CoreMediaIO/CMIOHardware.h>
 #include <stdlib.h>
 
-// Check if screensaver is running
-bool isScreenSaverRunning() {
+// Check if screensaver is running.
+// Returns 1 if running, 0 if not, -1 if it couldn't be determined.
+int isScreenSaverRunning() {
     CFDictionaryRef sessionDict = CGSessionCopyCurrentDictionary();
     if (sessionDict == NULL) {
-        return true; // Assume screensaver if we can't get session info
+        return -1;
     }
     
     CFBooleanRef sessionState = CFDictionaryGetValue(sessionDict, kCGSessionOnConsoleKey);
     bool onConsole = (sessionState != NULL && CFBooleanGetValue(sessionState));
     
     CFRelease(sessionDict);
-    return !onConsole;
+    return onConsole ? 0 : 1;
 }
 
-// Check if user session is active
-bool isUserSessionActive() {
+// Check if user session is active.
+// Returns 1 if active, 0 if not, -1 if it couldn't be determined.
+int isUserSessionActive() {
     CFDictionaryRef sessionDict = CGSessionCopyCurrentDictionary();
     if (sessionDict == NULL) {
-        return false;
+        return -1;
     }
     
     CFBooleanRef sessionState = CFDictionaryGetValue(sessionDict, kCGSessionOnConsoleKey);
     bool onConsole = (sessionState != NULL && CFBooleanGetValue(sessionState));
     
     CFRelease(sessionDict);
-    return onConsole;
+    return onConsole ? 1 : 0;
 }
 
-// Check if laptop lid is open (approximation using display state)
-bool isLidOpen() {
-    // Get the number of active displays
-    CGDirectDisplayID displays[32];
-    uint32_t displayCount;
-    CGGetActiveDisplayList(32, displays, &displayCount);
+// Check if the screen is locked (the login window is shown over the session).
+// Returns 1 if locked, 0 if not, -1 if it couldn't be determined.
+int isScreenLocked() {
+    CFDictionaryRef sessionDict = CGSessionCopyCurrentDictionary();
+    if (sessionDict == NULL) {
+        return -1;
+    }
     
-    // If we have active displays, assume lid is open
-    // This is an approximation - perfect lid detection requires private APIs
-    return displayCount > 0;
+    // The key is only present while the screen is locked
+    CFBooleanRef lockedState = CFDictionaryGetValue(sessionDict, CFSTR("CGSSessionScreenIsLocked"));
+    bool locked = (lockedState != NULL && CFBooleanGetValue(lockedState));
+    
+    CFRelease(sessionDict);
+    return locked ? 1 : 0;
+}
+
+// Read the lid state from AppleClamshellState, which IOPMrootDomain only
+// publishes on machines with a clamshell.
+// Returns 1 if closed, 0 if open, 2 if there is no lid (a desktop), -1 if
+// it couldn't be determined.
+int clamshellState() {
+    io_registry_entry_t rootDomain = IORegistryEntryFromPath(kIOMasterPortDefault, kIOPowerPlane ":/IOPowerConnection/IOPMrootDomain");
+    if (rootDomain == MACH_PORT_NULL) {
+        return -1;
+    }
+
+    CFTypeRef state = IORegistryEntryCreateCFProperty(rootDomain, CFSTR("AppleClamshellState"), kCFAllocatorDefault, 0);
+    IOObjectRelease(rootDomain);
+    if (state == NULL) {
+        return 2;
+    }
+
+    int closed = -1;
+    if (CFGetTypeID(state) == CFBooleanGetTypeID()) {
+        closed = CFBooleanGetValue((CFBooleanRef)state) ? 1 : 0;
+    }
+    CFRelease(state);
+    return closed;
+}
+
+// Check if laptop lid is open. AppleClamshellState answers directly; when
+// it can't be read, the lid counts as open while the built-in display is
+// online. Online displays include sleeping ones, so a built-in display that
+// is merely asleep still means the lid is open: display sleep is judged
+// separately by areDisplaysAsleep and general.display_sleep_inactive. With
+// the lid closed the built-in panel goes offline, and macOS may keep running
+// on an external display (clamshell mode). Desktops have no lid and count as
+// open while any display is online.
+// Returns 1 if open, 0 if closed, -1 if it couldn't be determined.
+int isLidOpen() {
+    int clamshell = clamshellState();
+    if (clamshell == 0 || clamshell == 1) {
+        return clamshell == 0 ? 1 : 0;
+    }
+
+    CGDirectDisplayID displays[32];
+    uint32_t displayCount = 0;
+    if (CGGetOnlineDisplayList(32, displays, &displayCount) != kCGErrorSuccess) {
+        return -1;
+    }
+
+    for (uint32_t i = 0; i < displayCount; i++) {
+        if (CGDisplayIsBuiltin(displays[i])) {
+            return 1;
+        }
+    }
+
+    if (clamshell == 2) {
+        return displayCount > 0 ? 1 : 0;
+    }
+    return -1;
+}
+
+// Check if an external (non built-in) display is active.
+// Returns 1 if one is, 0 if not, -1 if it couldn't be determined.
+int hasExternalDisplay() {
+    CGDirectDisplayID displays[32];
+    uint32_t displayCount = 0;
+    if (CGGetActiveDisplayList(32, displays, &displayCount) != kCGErrorSuccess) {
+        return -1;
+    }
+
+    for (uint32_t i = 0; i < displayCount; i++) {
+        if (!CGDisplayIsBuiltin(displays[i]) && !CGDisplayIsAsleep(displays[i])) {
+            return 1;
+        }
+    }
+    return 0;
+}
+
+// Check if the displays are asleep (Energy Saver display sleep). Sleeping
+// displays drop out of the active list, so this looks at every online one.
+// Returns 1 if all online displays are asleep, 0 if any is awake or there
+// are none, -1 if it couldn't be determined.
+int areDisplaysAsleep() {
+    CGDirectDisplayID displays[32];
+    uint32_t displayCount = 0;
+    if (CGGetOnlineDisplayList(32, displays, &displayCount) != kCGErrorSuccess) {
+        return -1;
+    }
+    if (displayCount == 0) {
+        return 0;
+    }
+
+    for (uint32_t i = 0; i < displayCount; i++) {
+        if (!CGDisplayIsAsleep(displays[i])) {
+            return 0;
+        }
+    }
+    return 1;
+}
+
+// Check if the default microphone is being used by any process.
+// Returns 1 if in use, 0 if not, -1 if it couldn't be determined.
+int isMicrophoneInUse() {
+    AudioObjectPropertyAddress address = {
+        kAudioHardwarePropertyDefaultInputDevice,
+        kAudioObjectPropertyScopeGlobal,
+        0 // main element
+    };
+    AudioDeviceID device = kAudioObjectUnknown;
+    UInt32 size = sizeof(device);
+    if (AudioObjectGetPropertyData(kAudioObjectSystemObject, &address, 0, NULL, &size, &device) != noErr ||
+        device == kAudioObjectUnknown) {
+        return -1;
+    }
+
+    UInt32 running = 0;
+    size = sizeof(running);
+    address.mSelector = kAudioDevicePropertyDeviceIsRunningSomewhere;
+    if (AudioObjectGetPropertyData(device, &address, 0, NULL, &size, &running) != noErr) {
+        return -1;
+    }
+    return running ? 1 : 0;
+}
+
+// Check if any camera is being used by any process.
+// Returns 1 if in use, 0 if not, -1 if it couldn't be determined.
+int isCameraInUse() {
+    CMIOObjectPropertyAddress address = {
+        kCMIOHardwarePropertyDevices,
+        kCMIOObjectPropertyScopeGlobal,
+        0 // main element
+    };
+    UInt32 dataSize = 0;
+    if (CMIOObjectGetPropertyDataSize(kCMIOObjectSystemObject, &address, 0, NULL, &dataSize) != 0) {
+        return -1;
+    }
+
+    int count = dataSize / sizeof(CMIODeviceID);
+    if (count == 0) {
+        return 0;
+    }
+
+    CMIODeviceID *devices = malloc(dataSize);
+    UInt32 dataUsed = 0;
+    if (CMIOObjectGetPropertyData(kCMIOObjectSystemObject, &address, 0, NULL, dataSize, &dataUsed, devices) != 0) {
+        free(devices);
+        return -1;
+    }
+
+    int inUse = 0;
+    CMIOObjectPropertyAddress runningAddress = {
+        kCMIODevicePropertyDeviceIsRunningSomewhere,
+        kCMIOObjectPropertyScopeWildcard,
+        kCMIOObjectPropertyElementWildcard
+    };
+    for (int i = 0; i < count; i++) {
+        UInt32 running = 0;
+        UInt32 used = 0;
+        if (CMIOObjectGetPropertyData(devices[i], &runningAddress, 0, NULL, sizeof(running), &used, &running) == 0 && running) {
+            inUse = 1;
+            break;
+        }
+    }
+
+    free(devices);
+    return inUse;
+}
+
+// Seconds since the last keyboard/mouse input in the current session
+double secondsSinceLastInput() {
+    return CGEventSourceSecondsSinceLastEventType(kCGEventSourceStateCombinedSessionState, kCGAnyInputEventType);
 }
 */
 import "C"
@@ -60,8 +237,15 @@ import (
 type SystemState struct {
 	IsUserSessionActive bool      `json:"is_user_session_active"`
 	IsScreenSaverRunning bool      `json:"is_screensaver_running"`
-	IsLidOpen           bool      `json:"is_lid_open"`
+	IsScreenLocked      bool      `json:"is_screen_locked"`
+	IsLidOpen           bool      `json:"is_lid_open"` // Lid open, even with the built-in display asleep (always open on desktops with a display)
+	HasExternalDisplay  bool      `json:"has_external_display"` // An external display is active
+	IsClamshell         bool      `json:"is_clamshell"` // Lid closed while running on an external display
+	IsDisplayAsleep     bool      `json:"is_display_asleep"` // Every display is asleep (Energy Saver display sleep)
 	IsActive            bool      `json:"is_active"`
+	IdleSeconds         float64   `json:"idle_seconds"`
+	IsInMeeting         bool      `json:"is_in_meeting"` // Camera or microphone in use (informational only)
+	IsUnknown           bool      `json:"is_unknown"` // The macOS APIs failed; IsActive comes from the fallback
 	LastChecked         time.Time `json:"last_checked"`
 }
 
@@ -70,10 +254,51 @@ type Monitor struct {
 	mu           sync.RWMutex
 	currentState *SystemState
 	callbacks    []StateChangeCallback
+	callbackQueues []*callbackQueue // One per callback, same order
 	stopChan     chan bool
 	isRunning    bool
+	detectMeetings bool
+	lockedCountsInactive bool // A locked screen stops crediting
+	hasPolled    bool // false until currentState comes from a real check
+	criteria     ActiveCriteria
+	checkInterval time.Duration
+	unknownPolicy UnknownStatePolicy
+	lastWake     time.Time // Wall-clock time the last wake from sleep was detected
 }
 
+// ActiveCriteria adds optional requirements to the definition of "active"
+// on top of session, lid and screensaver state
+type ActiveCriteria struct {
+	RequireRecentInput bool          // Inactive when there was no input within InputWindow
+	IdleIsPrimary      bool          // Same, but reported as "idle" (idle threshold is the main signal)
+	InputWindow        time.Duration // Defaults to DefaultInputWindow when zero
+	ClamshellInactive  bool          // Clamshell mode (lid closed, external display) counts as inactive
+	DisplaySleepInactive bool        // All displays asleep counts as inactive
+	ScreensaverActive  bool          // A running screensaver doesn't count as inactive (presentations)
+}
+
+// inactiveFromIdle returns true if the idle time makes the system inactive
+func (c ActiveCriteria) inactiveFromIdle(idleSeconds float64) bool {
+	return (c.RequireRecentInput || c.IdleIsPrimary) && idleSeconds >= c.InputWindow.Seconds()
+}
+
+// DefaultInputWindow is used for RequireRecentInput when no window is configured
+const DefaultInputWindow = 5 * time.Minute
+
+// UnknownStatePolicy decides whether the system counts as active when the
+// macOS session/display APIs fail
+type UnknownStatePolicy string
+
+const (
+	UnknownStateActive    UnknownStatePolicy = "active"
+	UnknownStateInactive  UnknownStatePolicy = "inactive"
+	UnknownStateLastKnown UnknownStatePolicy = "last_known"
+)
+
+// sleepGapFactor is how many check intervals must pass between two polls
+// before the gap is treated as the machine having slept
+const sleepGapFactor = 2
+
 // StateChangeCallback is called when system state changes
 type StateChangeCallback func(oldState, newState *SystemState)
 
@@ -84,6 +309,7 @@ func NewMonitor() *Monitor {
 			LastChecked: time.Now(),
 		},
 		stopChan: make(chan bool),
+		lockedCountsInactive: true,
 	}
 }
 
@@ -93,13 +319,60 @@ func (m *Monitor) GetCurrentState() *SystemState {
 	defer m.mu.RUnlock()
 	
 	// Return a copy to avoid race conditions
-	return &SystemState{
-		IsUserSessionActive: m.currentState.IsUserSessionActive,
-		IsScreenSaverRunning: m.currentState.IsScreenSaverRunning,
-		IsLidOpen:           m.currentState.IsLidOpen,
-		IsActive:            m.currentState.IsActive,
-		LastChecked:         m.currentState.LastChecked,
+	state := *m.currentState
+	return &state
+}
+
+// SetDetectMeetings enables camera/microphone meeting detection
+func (m *Monitor) SetDetectMeetings(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.detectMeetings = enabled
+}
+
+// SetLockedCountsInactive sets whether a locked screen makes the system
+// inactive. When false, only the other signals (session, lid, screensaver,
+// idle) decide.
+func (m *Monitor) SetLockedCountsInactive(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lockedCountsInactive = enabled
+}
+
+// SetUnknownStatePolicy sets what the system counts as when its state can't be determined
+func (m *Monitor) SetUnknownStatePolicy(policy UnknownStatePolicy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.unknownPolicy = policy
+}
+
+// SetActiveCriteria sets additional requirements for the system to count as active
+func (m *Monitor) SetActiveCriteria(criteria ActiveCriteria) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if criteria.InputWindow <= 0 {
+		criteria.InputWindow = DefaultInputWindow
 	}
+	m.criteria = criteria
+}
+
+// HasPolled returns true once at least one system check has completed
+func (m *Monitor) HasPolled() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.hasPolled
+}
+
+// LastWake returns when the last wake from sleep was detected, or the zero time
+func (m *Monitor) LastWake() time.Time {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.lastWake
+}
+
+// IdleDuration returns the time since the last keyboard/mouse input, queried live
+func (m *Monitor) IdleDuration() time.Duration {
+	return time.Duration(float64(C.secondsSinceLastInput()) * float64(time.Second))
 }
 
 // IsSystemActive returns true if the system is currently active for time tracking
@@ -113,6 +386,7 @@ func (m *Monitor) AddStateChangeCallback(callback StateChangeCallback) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.callbacks = append(m.callbacks, callback)
+	m.callbackQueues = append(m.callbackQueues, newCallbackQueue())
 }
 
 // Start begins monitoring system state at the specified interval
@@ -125,16 +399,21 @@ func (m *Monitor) Start(checkInterval time.Duration) error {
 	}
 
 	m.isRunning = true
+	m.checkInterval = checkInterval
 	
 	// Perform initial state check
-	initialState := m.checkSystemState()
+	initialState := m.checkSystemState(m.checkSettings())
+	m.applyUnknownPolicy(m.currentState, initialState)
 	m.currentState = initialState
+	m.hasPolled = true
 	
-	log.Printf("System monitor started - Initial state: Active=%v, Session=%v, Screensaver=%v, Lid=%v", 
+	log.Printf("System monitor started - Initial state: Active=%v, Session=%v, Screensaver=%v, Locked=%v, Lid=%v, External=%v", 
 		initialState.IsActive,
 		initialState.IsUserSessionActive, 
 		initialState.IsScreenSaverRunning,
-		initialState.IsLidOpen)
+		initialState.IsScreenLocked,
+		initialState.IsLidOpen,
+		initialState.HasExternalDisplay)
 
 	// Start monitoring goroutine
 	go m.monitorLoop(checkInterval)
@@ -173,62 +452,195 @@ func (m *Monitor) monitorLoop(checkInterval time.Duration) {
 
 // updateState checks current system state and updates internal state
 func (m *Monitor) updateState() {
-	newState := m.checkSystemState()
+	m.mu.RLock()
+	settings := m.checkSettings()
+	m.mu.RUnlock()
+	newState := m.checkSystemState(settings)
 
 	m.mu.Lock()
 	oldState := m.currentState
+	m.applyUnknownPolicy(oldState, newState)
 	m.currentState = newState
+	m.hasPolled = true
+
+	// Tickers don't fire while the machine sleeps, so a long wall-clock gap
+	// since the previous poll means we just woke up
+	gap := newState.LastChecked.Round(0).Sub(oldState.LastChecked.Round(0))
+	if m.checkInterval > 0 && gap >= sleepGapFactor*m.checkInterval {
+		m.lastWake = newState.LastChecked
+		log.Printf("💤 Wake detected - no system check for %s", gap.Round(time.Second))
+	}
 
 	// Check if state has changed
 	stateChanged := (oldState.IsActive != newState.IsActive ||
 		oldState.IsUserSessionActive != newState.IsUserSessionActive ||
 		oldState.IsScreenSaverRunning != newState.IsScreenSaverRunning ||
-		oldState.IsLidOpen != newState.IsLidOpen)
+		oldState.IsScreenLocked != newState.IsScreenLocked ||
+		oldState.IsLidOpen != newState.IsLidOpen ||
+		oldState.IsClamshell != newState.IsClamshell ||
+		oldState.IsDisplayAsleep != newState.IsDisplayAsleep ||
+		oldState.IsInMeeting != newState.IsInMeeting ||
+		oldState.IsUnknown != newState.IsUnknown)
 
 	callbacks := make([]StateChangeCallback, len(m.callbacks))
 	copy(callbacks, m.callbacks)
+	queues := make([]*callbackQueue, len(m.callbackQueues))
+	copy(queues, m.callbackQueues)
 	m.mu.Unlock()
 
 	// Call callbacks if state changed
 	if stateChanged {
-		log.Printf("System state changed - Active=%v, Session=%v, Screensaver=%v, Lid=%v",
+		log.Printf("System state changed - Active=%v, Session=%v, Screensaver=%v, Locked=%v, Lid=%v, External=%v, DisplayAsleep=%v",
 			newState.IsActive,
 			newState.IsUserSessionActive,
 			newState.IsScreenSaverRunning,
-			newState.IsLidOpen)
-
-		for _, callback := range callbacks {
-			go callback(oldState, newState)
+			newState.IsScreenLocked,
+			newState.IsLidOpen,
+			newState.HasExternalDisplay,
+			newState.IsDisplayAsleep)
+
+		for i, callback := range callbacks {
+			callback := callback
+			queues[i].send(func() { callback(oldState, newState) })
 		}
 	}
 }
 
+// checkSettings are the monitor settings a system check applies
+type checkSettings struct {
+	criteria             ActiveCriteria
+	detectMeetings       bool
+	lockedCountsInactive bool
+}
+
+// checkSettings copies the settings for checkSystemState, which runs
+// without m.mu. Must be called with m.mu held.
+func (m *Monitor) checkSettings() checkSettings {
+	return checkSettings{
+		criteria:             m.criteria,
+		detectMeetings:       m.detectMeetings,
+		lockedCountsInactive: m.lockedCountsInactive,
+	}
+}
+
 // checkSystemState performs the actual system state checking using macOS APIs
-func (m *Monitor) checkSystemState() *SystemState {
+func (m *Monitor) checkSystemState(settings checkSettings) *SystemState {
 	now := time.Now()
 
-	// Check individual system components
-	isUserSessionActive := bool(C.isUserSessionActive())
-	isScreenSaverRunning := bool(C.isScreenSaverRunning())
-	isLidOpen := bool(C.isLidOpen())
+	// Check individual system components (1 = yes, 0 = no, -1 = unknown)
+	sessionResult := C.isUserSessionActive()
+	screenSaverResult := C.isScreenSaverRunning()
+	lockedResult := C.isScreenLocked()
+	lidResult := C.isLidOpen()
+	externalResult := C.hasExternalDisplay()
+	asleepResult := C.areDisplaysAsleep()
+	isUserSessionActive := sessionResult == 1
+	isScreenSaverRunning := screenSaverResult == 1
+	isScreenLocked := lockedResult == 1
+	isLidOpen := lidResult == 1
+	hasExternalDisplay := externalResult == 1
+	isClamshell := lidResult == 0 && hasExternalDisplay
+	isDisplayAsleep := asleepResult == 1 // -1 is treated as awake; the lid check still applies
+	isUnknown := sessionResult < 0 || screenSaverResult < 0 || lockedResult < 0 || lidResult < 0
+	idleSeconds := float64(C.secondsSinceLastInput())
+
+	// Meeting detection is opt-in; if the audio/video APIs are unavailable
+	// (-1) the signal is simply treated as "not in a meeting"
+	isInMeeting := false
+	if settings.detectMeetings {
+		isInMeeting = C.isMicrophoneInUse() == 1 || C.isCameraInUse() == 1
+	}
 
 	// Determine if system is "active" for time tracking
-	// Active = user logged in + lid open + screensaver not running
-	isActive := isUserSessionActive && isLidOpen && !isScreenSaverRunning
+	// Active = user logged in + lid open + screensaver not running. Working
+	// on an external display with the lid closed counts as open unless the
+	// criteria say otherwise (general.clamshell_counts_inactive), and the
+	// screensaver can be ignored while presenting
+	// (general.screensaver_counts_active).
+	screenOn := isLidOpen || (isClamshell && !settings.criteria.ClamshellInactive)
+	screensaverStops := isScreenSaverRunning && !settings.criteria.ScreensaverActive
+	isActive := isUserSessionActive && screenOn && !screensaverStops
+
+	// A locked screen is distinct from the screensaver; it only stops
+	// crediting with general.locked_counts_inactive
+	if isScreenLocked && settings.lockedCountsInactive {
+		isActive = false
+	}
+
+	// Display sleep from Energy Saver means the user walked away even when
+	// no screensaver is set (general.display_sleep_inactive)
+	if isDisplayAsleep && settings.criteria.DisplaySleepInactive {
+		isActive = false
+	}
+
+	// Optionally also require recent keyboard/mouse input, so an untouched
+	// machine with the screensaver disabled doesn't count as active
+	if settings.criteria.inactiveFromIdle(idleSeconds) {
+		isActive = false
+	}
 
 	return &SystemState{
 		IsUserSessionActive: isUserSessionActive,
 		IsScreenSaverRunning: isScreenSaverRunning,
+		IsScreenLocked:      isScreenLocked,
 		IsLidOpen:           isLidOpen,
+		HasExternalDisplay:  hasExternalDisplay,
+		IsClamshell:         isClamshell,
+		IsDisplayAsleep:     isDisplayAsleep,
 		IsActive:            isActive,
+		IdleSeconds:         idleSeconds,
+		IsInMeeting:         isInMeeting,
+		IsUnknown:           isUnknown,
 		LastChecked:         now,
 	}
 }
 
+// applyUnknownPolicy decides IsActive for a state the macOS APIs couldn't
+// determine, using general.on_unknown_state. Must be called with m.mu held.
+func (m *Monitor) applyUnknownPolicy(oldState, newState *SystemState) {
+	if !newState.IsUnknown {
+		if oldState.IsUnknown {
+			log.Println("System state can be determined again")
+		}
+		return
+	}
+
+	switch m.unknownPolicy {
+	case UnknownStateActive:
+		newState.IsActive = !m.criteria.inactiveFromIdle(newState.IdleSeconds)
+	case UnknownStateLastKnown:
+		// Before the first completed check oldState is NewMonitor's
+		// placeholder, which is no baseline to carry forward
+		if !m.hasPolled {
+			log.Println("No earlier system state to fall back to at launch; treating it as inactive")
+			newState.IsActive = false
+			break
+		}
+		newState.IsActive = oldState.IsActive && !m.criteria.inactiveFromIdle(newState.IdleSeconds)
+	default:
+		newState.IsActive = false
+	}
+
+	if !oldState.IsUnknown {
+		policy := m.unknownPolicy
+		if policy == "" {
+			policy = UnknownStateInactive
+		}
+		log.Printf("⚠️  Couldn't determine system state from macOS; using on_unknown_state=%s (Active=%v)", policy, newState.IsActive)
+	}
+}
+
 // GetStateDescription returns a human-readable description of the current state
 func (m *Monitor) GetStateDescription() string {
 	state := m.GetCurrentState()
 	
+	if state.IsUnknown {
+		if state.IsActive {
+			return "Active (state unknown)"
+		}
+		return "Inactive (state unknown)"
+	}
+
 	if state.IsActive {
 		return "Active"
 	}
@@ -237,12 +649,30 @@ func (m *Monitor) GetStateDescription() string {
 	if !state.IsUserSessionActive {
 		reasons = append(reasons, "not logged in")
 	}
-	if !state.IsLidOpen {
+	m.mu.RLock()
+	criteria := m.criteria
+	lockedCountsInactive := m.lockedCountsInactive
+	m.mu.RUnlock()
+	if state.IsDisplayAsleep && criteria.DisplaySleepInactive {
+		reasons = append(reasons, "display asleep")
+	} else if state.IsClamshell && criteria.ClamshellInactive {
+		reasons = append(reasons, "clamshell mode")
+	} else if !state.IsLidOpen && !state.IsClamshell {
 		reasons = append(reasons, "lid closed")
 	}
-	if state.IsScreenSaverRunning {
+	if state.IsScreenSaverRunning && !criteria.ScreensaverActive {
 		reasons = append(reasons, "screensaver active")
 	}
+	if state.IsScreenLocked && lockedCountsInactive {
+		reasons = append(reasons, "screen locked")
+	}
+	if criteria.inactiveFromIdle(state.IdleSeconds) {
+		if criteria.IdleIsPrimary {
+			reasons = append(reasons, "idle")
+		} else {
+			reasons = append(reasons, "no recent input")
+		}
+	}
 
 	if len(reasons) == 0 {
 		return "Inactive (unknown reason)"
@@ -0,0 +1,24 @@
+package tracker
+
+import "testing"
+
+func TestApplyUnknownPolicyLastKnownAtLaunch(t *testing.T) {
+	monitor := NewMonitor()
+	monitor.SetUnknownStatePolicy(UnknownStateLastKnown)
+
+	// The placeholder state from NewMonitor is no baseline
+	placeholder := &SystemState{IsActive: true}
+	state := &SystemState{IsActive: true, IsUnknown: true}
+	monitor.applyUnknownPolicy(placeholder, state)
+	if state.IsActive {
+		t.Error("unknown first check counted as active, want inactive")
+	}
+
+	// After a real check the last known state carries forward
+	monitor.hasPolled = true
+	state = &SystemState{IsUnknown: true}
+	monitor.applyUnknownPolicy(&SystemState{IsActive: true}, state)
+	if !state.IsActive {
+		t.Error("unknown check after an active one counted as inactive, want the last known state")
+	}
+}
@@ -3,10 +3,12 @@ package tracker
 import (
 	"fmt"
 	"log"
+	"os"
 	"time"
 
 	"timeclip/internal/database"
 	"timeclip/internal/models"
+	"timeclip/internal/notify"
 )
 
 // Timer coordinates system monitoring, activity detection, and time tracking
@@ -23,10 +25,68 @@ func NewTimer(db *database.DB, config *models.Config) *Timer {
 		CheckInterval:           time.Duration(config.General.CheckIntervalSeconds) * time.Second,
 		GoalMinutes:            config.General.GoalTimeHours * 60,
 		AutoLogThresholdMinutes: int(config.General.AutoLogThresholdHours * 60),
+		WorkWindow:              workWindowFromConfig(&config.General),
+		Breaks:                  config.General.Breaks,
+		IdleThreshold:           time.Duration(config.General.IdleThresholdSeconds) * time.Second,
+		EventMinInterval:        time.Duration(config.General.EventMinIntervalSeconds) * time.Second,
+		DetectMeetings:          config.General.DetectMeetings,
+		LockedCountsInactive:    config.General.LockedCountsInactive,
+		CreditOnStartup:         config.General.CreditOnStartup,
+		RequireRecentInput:      config.General.RequireRecentInput,
+		IdleIsPrimary:           config.General.IdleIsPrimary,
+		ResumeRequiresSustainedInput: config.General.ResumeRequiresSustainedInput,
+		ClamshellCountsInactive: config.General.ClamshellCountsInactive,
+		DisplaySleepInactive:    config.General.DisplaySleepInactive,
+		ScreensaverCountsActive: config.General.ScreensaverCountsActive,
+		PaceAlertRatio:          config.General.PaceAlertRatio,
+		LongPause:               time.Duration(config.General.LongPauseMinutes) * time.Minute,
+		Heartbeat:               time.Duration(config.General.HeartbeatMinutes) * time.Minute,
+		PostWakeWarmup:          time.Duration(config.General.PostWakeWarmupSeconds) * time.Second,
+		OnUnknownState:          UnknownStatePolicy(config.General.OnUnknownState),
+		ActivityMode:            ActivityMode(config.General.ActivityMode),
+		IsTrackDay:              config.General.IsTrackDay,
+		Hooks: StateHooks{
+			OnActive:   config.General.OnActiveCmd,
+			OnInactive: config.General.OnInactiveCmd,
+			OnPause:    config.General.OnPauseCmd,
+			OnResume:   config.General.OnResumeCmd,
+		},
+	}
+
+	if config.General.PauseOnNetworkLoss {
+		activityConfig.NetworkProbeHost = config.General.NetworkProbeHost
+	}
+
+	// New days take their goal from goal_time_hours or weekly_goal_hours
+	db.SetGoalFunc(config.General.GoalMinutesFor)
+
+	if err := db.SetCommitInterval(time.Duration(config.Database.CommitIntervalMinutes) * time.Minute); err != nil {
+		log.Printf("Error setting commit interval: %v", err)
 	}
 
 	detector := NewActivityDetector(db, activityConfig)
 
+	if config.General.WriteStatusFile {
+		if dataDir, err := database.ExpandPath(config.DataDir()); err != nil {
+			log.Printf("Error locating data directory, not writing status file: %v", err)
+		} else if err := os.MkdirAll(dataDir, 0755); err != nil {
+			log.Printf("Error creating data directory, not writing status file: %v", err)
+		} else {
+			detector.AddStateChangeCallback(newStatusFile(dataDir).update)
+		}
+	}
+
+	if config.General.JournalFile != "" {
+		if journal, err := newJournal(db, config.General.JournalFile, config.General.JournalTemplate); err != nil {
+			log.Printf("Error locating journal file, not writing the journal: %v", err)
+		} else {
+			detector.AddStateChangeCallback(journal.update)
+		}
+	}
+
+	// Notifications come from several packages, so the sound is set once here
+	notify.SetSound(config.UI.NotificationSound)
+
 	return &Timer{
 		detector: detector,
 		config:   config,
@@ -38,6 +98,10 @@ func NewTimer(db *database.DB, config *models.Config) *Timer {
 func (t *Timer) Start() error {
 	log.Println("Starting time tracking timer...")
 
+	if t.config.General.ObserverMode {
+		return fmt.Errorf("tracking is disabled in observer mode; use NewObserver")
+	}
+
 	if err := t.detector.Start(); err != nil {
 		return fmt.Errorf("failed to start activity detector: %w", err)
 	}
@@ -87,6 +151,30 @@ func (t *Timer) SetPause(paused bool) error {
 	return t.detector.SetPause(paused)
 }
 
+// DisableTracking turns on the global kill switch. No days are created,
+// credited or auto-logged until EnableTracking is called, even across restarts.
+func (t *Timer) DisableTracking() error {
+	return t.detector.SetTrackingEnabled(false)
+}
+
+// EnableTracking turns off the global kill switch
+func (t *Timer) EnableTracking() error {
+	return t.detector.SetTrackingEnabled(true)
+}
+
+// IsTrackingEnabled returns false while tracking is globally disabled
+func (t *Timer) IsTrackingEnabled() bool {
+	return t.detector.IsTrackingEnabled()
+}
+
+// ToggleTracking flips the global kill switch
+func (t *Timer) ToggleTracking() error {
+	if t.IsTrackingEnabled() {
+		return t.DisableTracking()
+	}
+	return t.EnableTracking()
+}
+
 // IsPaused returns true if tracking is currently paused
 func (t *Timer) IsPaused() bool {
 	entry := t.GetCurrentEntry()
@@ -110,28 +198,17 @@ func (t *Timer) ForceIncrement() error {
 
 // GetWeeklyStats returns this week's statistics
 func (t *Timer) GetWeeklyStats() (*database.WeeklyStats, error) {
-	return t.db.GetWeeklyStats()
+	return t.db.GetWeeklyStats(t.config.General.MinTrackedMinutes, t.config.General.ExcludePTOFromStats, t.config.General.WeekStartDay())
 }
 
 // GetMonthlyStats returns this month's statistics
 func (t *Timer) GetMonthlyStats() (*database.MonthlyStats, error) {
-	return t.db.GetMonthlyStats()
+	return t.db.GetMonthlyStats(t.config.General.MinTrackedMinutes, t.config.General.ExcludePTOFromStats)
 }
 
 // ShouldTrackToday returns true if today is a tracking day
 func (t *Timer) ShouldTrackToday() bool {
-	today := time.Now().Weekday().String()
-	todayLower := map[string]string{
-		"Monday": "monday", "Tuesday": "tuesday", "Wednesday": "wednesday",
-		"Thursday": "thursday", "Friday": "friday", "Saturday": "saturday", "Sunday": "sunday",
-	}[today]
-
-	for _, day := range t.config.General.TrackDays {
-		if day == todayLower {
-			return true
-		}
-	}
-	return false
+	return t.config.General.IsTrackDay(time.Now())
 }
 
 // GetConfig returns the configuration
@@ -0,0 +1,27 @@
+package tracker
+
+import (
+	"log"
+	"time"
+)
+
+// observeWake records the minute tick at now and returns the most recent wake
+// from sleep seen either by the monitor or by a gap between minute ticks. The
+// minute ticker can fire on wake before the monitor's next poll, while the
+// monitor still reports the state from before sleep.
+// Must be called with ad.mu held.
+func (ad *ActivityDetector) observeWake(now time.Time) time.Time {
+	now = now.Round(0) // Compare wall-clock time; the monotonic clock stops during sleep
+	if !ad.lastTickAt.IsZero() {
+		if gap := now.Sub(ad.lastTickAt); gap >= sleepGapFactor*time.Minute {
+			ad.tickWake = now
+			log.Printf("💤 Wake detected - no minute tick for %s", gap.Round(time.Second))
+		}
+	}
+	ad.lastTickAt = now
+
+	if monitorWake := ad.monitor.LastWake(); monitorWake.After(ad.tickWake) {
+		return monitorWake
+	}
+	return ad.tickWake
+}
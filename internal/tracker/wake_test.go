@@ -0,0 +1,67 @@
+package tracker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestObserveWake(t *testing.T) {
+	ad := newTestDetector(t, &ActivityConfig{PostWakeWarmup: 5 * time.Minute})
+	start := time.Date(2024, 5, 6, 9, 0, 0, 0, time.Local)
+
+	// Regular minute ticks are no wake
+	for i := 0; i < 3; i++ {
+		if wake := ad.observeWake(start.Add(time.Duration(i) * time.Minute)); !wake.IsZero() {
+			t.Fatalf("tick %d: wake = %v, want none", i, wake)
+		}
+	}
+
+	// An hour without ticks is a wake at the first tick after it
+	woke := start.Add(62 * time.Minute)
+	if wake := ad.observeWake(woke); !wake.Equal(woke) {
+		t.Fatalf("wake = %v, want %v", wake, woke)
+	}
+	if wake := ad.observeWake(woke.Add(time.Minute)); !wake.Equal(woke) {
+		t.Errorf("wake on the next tick = %v, want still %v", wake, woke)
+	}
+
+	// A later wake seen by the monitor wins
+	monitorWake := woke.Add(90 * time.Second)
+	ad.monitor.mu.Lock()
+	ad.monitor.lastWake = monitorWake
+	ad.monitor.mu.Unlock()
+	if wake := ad.observeWake(woke.Add(2 * time.Minute)); !wake.Equal(monitorWake) {
+		t.Errorf("wake = %v, want the monitor's %v", wake, monitorWake)
+	}
+}
+
+func TestDecideCreditPostWakeWarmup(t *testing.T) {
+	config := &ActivityConfig{PostWakeWarmup: 5 * time.Minute}
+	wake := time.Date(2024, 5, 6, 9, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name       string
+		now        time.Time
+		lastWake   time.Time
+		wantCredit bool
+		wantReason CreditReason
+	}{
+		{"never slept", wake, time.Time{}, true, CreditReasonActive},
+		{"right after wake", wake.Add(time.Minute), wake, false, CreditReasonWarmup},
+		{"warmup ends", wake.Add(5 * time.Minute), wake, true, CreditReasonActive},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			credit, reason := decideCredit(tc.now, config, true, false, 0, tc.lastWake)
+			if credit != tc.wantCredit || reason != tc.wantReason {
+				t.Errorf("decideCredit = %v, %s; want %v, %s", credit, reason, tc.wantCredit, tc.wantReason)
+			}
+		})
+	}
+
+	// No warmup configured credits right away
+	if credit, _ := decideCredit(wake.Add(time.Minute), &ActivityConfig{}, true, false, 0, wake); !credit {
+		t.Error("minute after wake not credited without a warmup")
+	}
+}
@@ -0,0 +1,61 @@
+// Package version reports build information for diagnostics and support requests.
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+)
+
+// Set at build time, e.g.
+//
+//	go build -ldflags "-X timeclip/internal/version.Version=1.2.0 -X timeclip/internal/version.Commit=abc1234"
+var (
+	Version = "dev"
+	Commit  = ""
+)
+
+// Info describes the running binary
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	GoVersion string `json:"go_version"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+}
+
+// Get returns the build information. When no commit was injected it falls
+// back to the VCS revision Go embeds in builds from a git checkout.
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}
+
+	if info.Commit == "" {
+		if build, ok := debug.ReadBuildInfo(); ok {
+			for _, setting := range build.Settings {
+				if setting.Key == "vcs.revision" {
+					info.Commit = setting.Value
+				}
+			}
+		}
+	}
+	if len(info.Commit) > 12 {
+		info.Commit = info.Commit[:12]
+	}
+
+	return info
+}
+
+// String returns a one-line summary, e.g. "timeclip 1.2.0 (abc1234) go1.24.6 darwin/arm64"
+func (i Info) String() string {
+	version := "timeclip " + i.Version
+	if i.Commit != "" {
+		version += fmt.Sprintf(" (%s)", i.Commit)
+	}
+	return fmt.Sprintf("%s %s %s/%s", version, i.GoVersion, i.OS, i.Arch)
+}
@@ -13,7 +13,10 @@ cd "$PROJECT_DIR"
 
 # Build the application
 echo "📦 Compiling Go application..."
-go build -o timeclip cmd/timeclip/main.go
+VERSION="$(git describe --tags --always --dirty 2>/dev/null || echo dev)"
+COMMIT="$(git rev-parse --short HEAD 2>/dev/null || true)"
+LDFLAGS="-X timeclip/internal/version.Version=$VERSION -X timeclip/internal/version.Commit=$COMMIT"
+go build -ldflags "$LDFLAGS" -o timeclip cmd/timeclip/main.go
 
 # Make executable
 chmod +x timeclip